@@ -0,0 +1,53 @@
+package neverforgetvps
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultAckCooldown is the default period during which an acknowledged provider stays silent
+const DefaultAckCooldown = 24 * time.Hour
+
+// acknowledgement records that the user has seen a provider's alert
+type acknowledgement struct {
+	severity Severity  // Severity at the time of acknowledgement
+	until    time.Time // Notifications are suppressed until this moment
+}
+
+// Acknowledge suppresses further notifications for the provider for the ack cooldown
+// A status more severe than the acknowledged one breaks through and clears the acknowledgement
+// Returns an error if the provider has not been checked yet
+func (m *vpsMonitor[T]) Acknowledge(providerName string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	status, found := m.statuses[providerName]
+	if !found {
+		return fmt.Errorf("provider %s: %w", providerName, ErrNotChecked)
+	}
+
+	if m.acks == nil {
+		m.acks = make(map[string]acknowledgement)
+	}
+	m.acks[providerName] = acknowledgement{
+		severity: status.Severity,
+		until:    m.now().Add(m.ackCooldown),
+	}
+	return nil
+}
+
+// isAcknowledged checks whether notifications for the status are suppressed by an acknowledgement
+// Expired acknowledgements and those broken by a severity increase are removed
+// Must be called with m.mu held
+func (m *vpsMonitor[T]) isAcknowledged(status ProviderStatus) bool {
+	ack, found := m.acks[status.Provider]
+	if !found {
+		return false
+	}
+
+	if !m.now().Before(ack.until) || status.Severity > ack.severity {
+		delete(m.acks, status.Provider)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,59 @@
+package neverforgetvps
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAcknowledge(t *testing.T) {
+	clock := newTestClock()
+	p := newStubProvider("vdsina", daysFrom(clock.Now(), 2))
+	m, sent := newTestMonitor(t, Config{AckCooldown: 48 * time.Hour}, clock, p)
+
+	if err := m.Acknowledge("vdsina"); !errors.Is(err, ErrNotChecked) {
+		t.Fatalf("Acknowledge before the first check: %v, want ErrNotChecked", err)
+	}
+
+	m.CheckNow(context.Background(), 0)
+	if got := sent.texts(); len(got) != 1 {
+		t.Fatalf("messages %q, want the warning", got)
+	}
+	if err := m.Acknowledge("vdsina"); err != nil {
+		t.Fatal(err)
+	}
+	sent.reset()
+
+	// The acknowledged warning stays silent
+	clock.Advance(12 * time.Hour)
+	m.CheckNow(context.Background(), 0)
+	if got := sent.texts(); len(got) != 0 {
+		t.Fatalf("messages %q after the acknowledgement, want none", got)
+	}
+
+	// Becoming overdue is more severe and breaks through
+	p.set(daysFrom(clock.Now(), -1), nil)
+	m.CheckNow(context.Background(), 0)
+	if got := sent.texts(); len(got) != 1 || countContaining(got, "CRITICAL") != 1 {
+		t.Fatalf("messages %q after the severity increase, want the critical message", got)
+	}
+}
+
+func TestAcknowledgeCooldownExpires(t *testing.T) {
+	clock := newTestClock()
+	p := newStubProvider("vdsina", daysFrom(clock.Now(), 5))
+	m, sent := newTestMonitor(t, Config{AckCooldown: time.Hour}, clock, p)
+
+	m.CheckNow(context.Background(), 0)
+	if err := m.Acknowledge("vdsina"); err != nil {
+		t.Fatal(err)
+	}
+	sent.reset()
+
+	clock.Advance(2 * time.Hour)
+	m.CheckNow(context.Background(), 0)
+	if got := sent.texts(); len(got) != 1 {
+		t.Fatalf("messages %q after the cooldown, want the reminder", got)
+	}
+}
@@ -0,0 +1,74 @@
+package neverforgetvps
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// IntervalTier shortens the check interval as the nearest payment date approaches
+type IntervalTier struct {
+	WithinDays int           // Tier applies when the nearest payment is due in at most this many days (overdue included)
+	Interval   time.Duration // Check interval used while the tier applies
+}
+
+// DefaultIntervalTiers check every 6 hours within a week of the nearest payment and hourly within 2 days
+// Combined with a daily CheckInterval this checks daily while payments are far away
+var DefaultIntervalTiers = []IntervalTier{
+	{WithinDays: 7, Interval: 6 * time.Hour},
+	{WithinDays: 2, Interval: time.Hour},
+}
+
+// sortIntervalTiers validates tiers and returns a copy sorted by WithinDays ascending
+func sortIntervalTiers(tiers []IntervalTier) ([]IntervalTier, error) {
+	sorted := make([]IntervalTier, len(tiers))
+	copy(sorted, tiers)
+	for _, tier := range sorted {
+		if tier.Interval <= 0 {
+			return nil, fmt.Errorf("interval of tier within %d days must be positive, got %s", tier.WithinDays, tier.Interval)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].WithinDays < sorted[j].WithinDays
+	})
+	return sorted, nil
+}
+
+// adaptiveInterval returns the check interval for the nearest recorded payment date
+// The tier with the smallest WithinDays that applies wins, CheckInterval is used if none applies
+func (m *vpsMonitor[T]) adaptiveInterval() time.Duration {
+	m.mu.Lock()
+	nearest, found := 0, false
+	for _, status := range m.statuses {
+		if status.Outcome() == OutcomePaymentDue && (!found || status.DaysUntil < nearest) {
+			nearest, found = status.DaysUntil, true
+		}
+	}
+	m.mu.Unlock()
+
+	if found {
+		for _, tier := range m.intervalTiers {
+			if nearest <= tier.WithinDays {
+				return tier.Interval
+			}
+		}
+	}
+	return m.checkInterval
+}
+
+// runAdaptiveCheck runs checks with an interval recomputed from the nearest payment date after every check
+func (m *vpsMonitor[T]) runAdaptiveCheck() {
+	for {
+		m.automaticCheck()
+
+		interval := m.adaptiveInterval()
+		m.setNextCheck(m.now().Add(interval))
+		timer := time.NewTimer(interval)
+		select {
+		case <-timer.C:
+		case <-m.ctx.Done():
+			timer.Stop()
+			return
+		}
+	}
+}
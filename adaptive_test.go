@@ -0,0 +1,45 @@
+package neverforgetvps
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestAdaptiveInterval(t *testing.T) {
+	clock := newTestClock()
+	due := daysFrom(clock.Now(), 10)
+	config := Config{CheckInterval: 24 * time.Hour, IntervalTiers: DefaultIntervalTiers}
+	m, _ := newTestMonitor(t, config, clock, newStubProvider("vdsina", due))
+
+	// The same payment date, checked as it comes closer
+	steps := []struct {
+		advance time.Duration
+		want    time.Duration
+	}{
+		{advance: 0, want: 24 * time.Hour},                 // 10 days left
+		{advance: 3 * 24 * time.Hour, want: 6 * time.Hour}, // 7 days left
+		{advance: 4 * 24 * time.Hour, want: 6 * time.Hour}, // 3 days left
+		{advance: 24 * time.Hour, want: time.Hour},         // 2 days left
+		{advance: 3 * 24 * time.Hour, want: time.Hour},     // Overdue
+	}
+	for _, step := range steps {
+		clock.Advance(step.advance)
+		m.CheckNow(context.Background(), 0)
+		if got := m.adaptiveInterval(); got != step.want {
+			t.Errorf("interval %v at %v, want %v", got, clock.Now(), step.want)
+		}
+	}
+}
+
+func TestAdaptiveNextCheck(t *testing.T) {
+	clock := newTestClock()
+	config := Config{CheckInterval: 24 * time.Hour, IntervalTiers: DefaultIntervalTiers}
+	m, _ := newTestMonitor(t, config, clock, newStubProvider("vdsina", daysFrom(clock.Now(), 1)))
+
+	if err := m.Start(); err != nil {
+		t.Fatal(err)
+	}
+	// The loop schedules the next tick from the nearest date seen by the initial check
+	waitForNextCheck(t, m, testNow.Add(time.Hour))
+}
@@ -0,0 +1,72 @@
+package neverforgetvps
+
+import (
+	"time"
+)
+
+// DefaultRepeatBackoffMax is the longest delay between repeated notifications when RepeatBackoffMax is not set
+const DefaultRepeatBackoffMax = 24 * time.Hour
+
+// repeatKey identifies a stream of repeated notifications: one provider and one kind of message,
+// so e.g. a low balance warning never holds back the payment warning of the same provider
+type repeatKey struct {
+	provider string
+	kind     messageKind
+}
+
+// repeatState tracks repeated notifications of a provider with unchanged severity
+type repeatState struct {
+	severity  Severity      // Severity of the repeated notifications
+	failed    bool          // Whether the repeated notifications report a failed check
+	checkedAt time.Time     // Check time of the status last sent, identifies the check it came from
+	lastSent  time.Time     // When the last notification was sent
+	delay     time.Duration // Minimum time until the next notification is sent
+}
+
+// isRepeatBackedOff checks whether a repeat notification of the same provider, message kind and severity
+// is still within its backoff delay, and records the send otherwise
+// Only notifications from earlier checks count as repeats; each sent repeat doubles the delay up to the maximum,
+// and a severity change starts over
+// Must be called with m.mu held
+func (m *vpsMonitor[T]) isRepeatBackedOff(status ProviderStatus, kind messageKind) bool {
+	if m.repeatBackoff <= 0 {
+		return false
+	}
+
+	now := m.now()
+	key := repeatKey{provider: status.Provider, kind: kind}
+	failed := status.Outcome() == OutcomeFailed
+	state, found := m.repeats[key]
+	if !found || state.severity != status.Severity || state.failed != failed {
+		if m.repeats == nil {
+			m.repeats = make(map[repeatKey]repeatState)
+		}
+		m.repeats[key] = repeatState{severity: status.Severity, failed: failed, checkedAt: status.CheckedAt, lastSent: now, delay: m.repeatBackoff}
+		return false
+	}
+
+	// Another message about the same check is not a repeat
+	if state.checkedAt.Equal(status.CheckedAt) {
+		return false
+	}
+
+	if now.Sub(state.lastSent) < state.delay {
+		return true
+	}
+
+	state.checkedAt = status.CheckedAt
+	state.lastSent = now
+	state.delay = min(state.delay*2, m.repeatBackoffMax)
+	m.repeats[key] = state
+	return false
+}
+
+// forgetRepeats drops the repeat state of every message kind of the provider
+// Must be called with m.mu held
+func (m *vpsMonitor[T]) forgetRepeats(name string) {
+	for key := range m.repeats {
+		if key.provider == name {
+			delete(m.repeats, key)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package neverforgetvps
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestRepeatBackoff(t *testing.T) {
+	clock := newTestClock()
+	p := newStubProvider("vdsina", daysFrom(clock.Now(), 2))
+	m, sent := newTestMonitor(t, Config{RepeatBackoff: time.Hour, RepeatBackoffMax: 4 * time.Hour}, clock, p)
+
+	// checkHourly runs hourly checks from hour from to hour to, returning the hours a message was sent at
+	checkHourly := func(from, to int) []int {
+		var hours []int
+		for hour := from; hour < to; hour++ {
+			sent.reset()
+			m.CheckNow(context.Background(), 0)
+			if len(sent.texts()) > 0 {
+				hours = append(hours, hour)
+			}
+			clock.Advance(time.Hour)
+		}
+		return hours
+	}
+
+	// The warning is repeated after 1, 2 and 4 hours, then every 4 hours
+	if hours, want := checkHourly(0, 24), []int{0, 1, 3, 7, 11, 15, 19, 23}; !slices.Equal(hours, want) {
+		t.Errorf("warnings sent at hours %v, want %v", hours, want)
+	}
+
+	// The severity change is sent at once and starts the backoff over
+	p.set(daysFrom(clock.Now(), -1), nil)
+	if hours, want := checkHourly(24, 32), []int{24, 25, 27, 31}; !slices.Equal(hours, want) {
+		t.Errorf("critical messages sent at hours %v, want %v", hours, want)
+	}
+}
+
+func TestRepeatBackoffDisabled(t *testing.T) {
+	clock := newTestClock()
+	m, sent := newTestMonitor(t, Config{}, clock, newStubProvider("vdsina", daysFrom(clock.Now(), 2)))
+
+	for range 5 {
+		m.CheckNow(context.Background(), 0)
+		clock.Advance(time.Hour)
+	}
+	if n := len(sent.texts()); n != 5 {
+		t.Errorf("%d warnings in 5 hourly checks without backoff, want 5", n)
+	}
+}
@@ -0,0 +1,85 @@
+package neverforgetvps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+// checkBalance compares the provider balance with its configured threshold
+// and returns a warning message when the balance is below it
+// Providers without a threshold, without BalanceProvider support or without configuration are skipped
+func (m *vpsMonitor[T]) checkBalance(ctx context.Context, entry providerEntry) (pendingMessage, bool) {
+	name := entry.Provider.GetName()
+	threshold, found := m.balanceThresholds[name]
+	if !found || !entry.Provider.IsConfigured() {
+		return pendingMessage{}, false
+	}
+
+	balanceProvider, ok := provider.Unwrap(entry.Provider).(provider.BalanceProvider)
+	if !ok {
+		return pendingMessage{}, false
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, entry.Timeout)
+	defer cancel()
+
+	status := ProviderStatus{
+		Provider:  name,
+		Severity:  SeverityWarning,
+		CheckedAt: m.now(),
+	}
+
+	balance, err := balanceProvider.GetBalance(ctx)
+	if err != nil {
+		status.Err = err
+		return pendingMessage{status, fmt.Sprintf("Error checking balance for provider %s: %v", name, err), messageBalance}, true
+	}
+
+	if balance.Amount < threshold {
+		return pendingMessage{status, fmt.Sprintf("⚠️ LOW BALANCE: Provider %s - Balance %s is below the threshold of %.2f %s", name, balance, threshold, balance.Currency), messageBalance}, true
+	}
+	return pendingMessage{}, false
+}
+
+// FirstToDeplete returns the balance provider whose balance runs out first and its forecast date
+// Only providers implementing provider.BalanceProvider are considered, for them the next payment date
+// is the depletion forecast; an empty name means no balance provider is running out
+// Results of the last check are reused like in Summary
+// Failed providers are skipped, and their errors are returned joined together with the answer for the others
+func (m *vpsMonitor[T]) FirstToDeplete(ctx context.Context) (string, *time.Time, error) {
+	statuses, err := m.freshStatuses(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+
+	balanceProviders := make(map[string]bool)
+	for _, entry := range m.enabledProviders() {
+		if _, ok := provider.Unwrap(entry.Provider).(provider.BalanceProvider); ok {
+			balanceProviders[entry.Provider.GetName()] = true
+		}
+	}
+
+	var name string
+	var date *time.Time
+	var errs []error
+	for _, status := range statuses {
+		if !balanceProviders[status.Provider] {
+			continue
+		}
+
+		switch status.Outcome() {
+		case OutcomeFailed:
+			errs = append(errs, status.Err)
+		case OutcomePaymentDue:
+			if date == nil || status.NextDate.Before(*date) {
+				name, date = status.Provider, status.NextDate
+			}
+		}
+	}
+
+	return name, date, errors.Join(errs...)
+}
@@ -0,0 +1,84 @@
+package neverforgetvps
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+// balanceStub is a stub provider that also reports a balance
+type balanceStub struct {
+	*stubProvider
+	balance provider.Money
+}
+
+func (p *balanceStub) GetBalance(ctx context.Context) (provider.Money, error) {
+	return p.balance, nil
+}
+
+func TestBalanceThresholds(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   float64
+		wantText string // Expected low balance warning, "" for none
+	}{
+		{name: "under the threshold", amount: 150, wantText: "LOW BALANCE: Provider timeweb - Balance 150.00 RUB is below the threshold of 500.00 RUB"},
+		{name: "at the threshold", amount: 500},
+		{name: "over the threshold", amount: 2000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newTestClock()
+			p := &balanceStub{
+				stubProvider: newStubProvider("timeweb", daysFrom(clock.Now(), 60)),
+				balance:      provider.Money{Amount: tt.amount, Currency: "RUB"},
+			}
+			config := Config{BalanceThresholds: map[string]float64{"timeweb": 500}}
+			m, sent := newTestMonitor(t, config, clock, p)
+
+			m.CheckNow(context.Background(), 0)
+
+			texts := sent.texts()
+			if tt.wantText == "" {
+				if countContaining(texts, "LOW BALANCE") != 0 {
+					t.Fatalf("messages %q, want no low balance warning", texts)
+				}
+				return
+			}
+			if countContaining(texts, tt.wantText) != 1 {
+				t.Fatalf("messages %q, want one containing %q", texts, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestFirstToDeplete(t *testing.T) {
+	clock := newTestClock()
+	newBalanceStub := func(name string, days int) *balanceStub {
+		return &balanceStub{stubProvider: newStubProvider(name, daysFrom(clock.Now(), days))}
+	}
+	later := newBalanceStub("timeweb", 20)
+	sooner := newBalanceStub("vdsina", 6)
+	// An invoice provider due earlier is not a balance provider
+	invoices := newStubProvider("oneprovider", daysFrom(clock.Now(), 2))
+	m, _ := newTestMonitor(t, Config{}, clock, later, sooner, invoices)
+
+	name, date, err := m.FirstToDeplete(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "vdsina" || date == nil || !date.Equal(*daysFrom(clock.Now(), 6)) {
+		t.Fatalf("first to deplete %q at %v, want vdsina in 6 days", name, date)
+	}
+
+	// A failed balance provider is skipped and reported
+	sooner.set(nil, errors.New("boom"))
+	m.ForceRefresh(context.Background())
+	name, _, err = m.FirstToDeplete(context.Background())
+	if name != "timeweb" || err == nil {
+		t.Fatalf("first to deplete %q with error %v, want timeweb and the vdsina failure", name, err)
+	}
+}
@@ -0,0 +1,57 @@
+package neverforgetvps
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// resolveCredentialFiles replaces credentials with the contents of their *File settings
+// (e.g. VdsinaAPIKeyFile), following the Docker and Kubernetes secret convention
+// A configured file takes precedence over the inline value; surrounding whitespace is trimmed
+// Identifiers such as account IDs and usernames can be read from files too, so a provider can be
+// configured entirely from secrets; every unreadable file is reported and leaves its value unchanged
+func resolveCredentialFiles(config *Config) error {
+	credentials := []struct {
+		name  string
+		value *string
+		file  string
+	}{
+		{"VdsinaAPIKeyFile", &config.VdsinaAPIKey, config.VdsinaAPIKeyFile},
+		{"OneProviderAPIKeyFile", &config.OneProviderAPIKey, config.OneProviderAPIKeyFile},
+		{"OneProviderClientKeyFile", &config.OneProviderClientKey, config.OneProviderClientKeyFile},
+		{"CloudflareAPIKeyFile", &config.CloudflareAPIKey, config.CloudflareAPIKeyFile},
+		{"YandexCloudIAMTokenFile", &config.YandexCloudIAMToken, config.YandexCloudIAMTokenFile},
+		{"YandexCloudAccountIDFile", &config.YandexCloudAccountID, config.YandexCloudAccountIDFile},
+		{"TimewebAPIKeyFile", &config.TimewebAPIKey, config.TimewebAPIKeyFile},
+		{"BuyVMAPIKeyFile", &config.BuyVMAPIKey, config.BuyVMAPIKeyFile},
+		{"OracleCloudTenancyOCIDFile", &config.OracleCloudTenancyOCID, config.OracleCloudTenancyOCIDFile},
+		{"OracleCloudUserOCIDFile", &config.OracleCloudUserOCID, config.OracleCloudUserOCIDFile},
+		{"OracleCloudKeyFingerprintFile", &config.OracleCloudKeyFingerprint, config.OracleCloudKeyFingerprintFile},
+		{"OracleCloudPrivateKeyFile", &config.OracleCloudPrivateKey, config.OracleCloudPrivateKeyFile},
+		{"GcoreAPIKeyFile", &config.GcoreAPIKey, config.GcoreAPIKeyFile},
+		{"NetcupCustomerNumberFile", &config.NetcupCustomerNumber, config.NetcupCustomerNumberFile},
+		{"NetcupAPIKeyFile", &config.NetcupAPIKey, config.NetcupAPIKeyFile},
+		{"NetcupAPIPasswordFile", &config.NetcupAPIPassword, config.NetcupAPIPasswordFile},
+		{"HostingerAPIKeyFile", &config.HostingerAPIKey, config.HostingerAPIKeyFile},
+		{"RegRuUsernameFile", &config.RegRuUsername, config.RegRuUsernameFile},
+		{"RegRuPasswordFile", &config.RegRuPassword, config.RegRuPasswordFile},
+		{"KamateraClientIDFile", &config.KamateraClientID, config.KamateraClientIDFile},
+		{"KamateraSecretFile", &config.KamateraSecret, config.KamateraSecretFile},
+	}
+
+	var errs []error
+	for _, credential := range credentials {
+		if credential.file == "" {
+			continue
+		}
+		data, err := os.ReadFile(credential.file)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", credential.name, err))
+			continue
+		}
+		*credential.value = strings.TrimSpace(string(data))
+	}
+	return errors.Join(errs...)
+}
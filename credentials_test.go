@@ -0,0 +1,55 @@
+package neverforgetvps
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolveCredentialFiles(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "vdsina_api_key")
+	if err := os.WriteFile(keyFile, []byte("  secret-key\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	config := Config{
+		VdsinaAPIKey:     "inline",
+		VdsinaAPIKeyFile: keyFile,
+		TimewebAPIKey:    "inline",
+	}
+	if err := resolveCredentialFiles(&config); err != nil {
+		t.Fatalf("resolveCredentialFiles: %v", err)
+	}
+	if config.VdsinaAPIKey != "secret-key" {
+		t.Errorf("VdsinaAPIKey %q, want the trimmed file contents", config.VdsinaAPIKey)
+	}
+	if config.TimewebAPIKey != "inline" {
+		t.Errorf("TimewebAPIKey %q, want the inline value without a file", config.TimewebAPIKey)
+	}
+}
+
+func TestMissingCredentialFile(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing")
+	config := Config{VdsinaAPIKeyFile: missing, GcoreAPIKeyFile: missing}
+
+	err := resolveCredentialFiles(&config)
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("resolveCredentialFiles: %v, want a missing file error", err)
+	}
+	// Every unreadable file is reported
+	for _, name := range []string{"VdsinaAPIKeyFile", "GcoreAPIKeyFile"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("error %q does not name %s", err, name)
+		}
+	}
+
+	// The monitor reports the error on Start instead of panicking, creating the other providers
+	m, _ := newTestMonitor(t, Config{VdsinaAPIKeyFile: missing, TimewebAPIKey: "key"}, nil)
+	if err := m.Start(); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Start: %v, want the missing file error", err)
+	}
+}
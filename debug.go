@@ -0,0 +1,38 @@
+package neverforgetvps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+// ErrDebugFetchDisabled is returned by DebugFetch unless Config.EnableDebugFetch is set
+var ErrDebugFetchDisabled = errors.New("debug fetch is disabled, set Config.EnableDebugFetch")
+
+// DebugFetch performs the primary API call of the provider and returns the raw response body
+// The cache is bypassed and nothing is parsed, recorded or sent
+// Requires Config.EnableDebugFetch, since raw responses may contain sensitive account data
+func (m *vpsMonitor[T]) DebugFetch(ctx context.Context, providerName string) ([]byte, error) {
+	if !m.enableDebugFetch {
+		return nil, ErrDebugFetchDisabled
+	}
+
+	for _, entry := range m.enabledProviders() {
+		if entry.Provider.GetName() != providerName {
+			continue
+		}
+
+		fetcher, ok := provider.Unwrap(entry.Provider).(provider.RawFetcher)
+		if !ok {
+			return nil, fmt.Errorf("provider %s does not support raw fetch", providerName)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, entry.Timeout)
+		defer cancel()
+		return fetcher.FetchRaw(ctx)
+	}
+
+	return nil, fmt.Errorf("unknown provider: %s", providerName)
+}
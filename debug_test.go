@@ -0,0 +1,86 @@
+package neverforgetvps
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// cannedTransport returns a transport answering every HTTPS request with body,
+// whatever host the request is addressed to, and a function returning the paths requested so far
+func cannedTransport(t *testing.T, body string) (*http.Transport, func() []string) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var paths []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	transport := server.Client().Transport.(*http.Transport).Clone()
+	// The test certificate is issued for example.com
+	transport.TLSClientConfig.ServerName = "example.com"
+	transport.DialContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+		var dialer net.Dialer
+		return dialer.DialContext(ctx, network, server.Listener.Addr().String())
+	}
+	return transport, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return slices.Clone(paths)
+	}
+}
+
+func TestDebugFetch(t *testing.T) {
+	const body = `{"status":"ok","data":{"forecast":"2026-11-20","balance":"12.5"}}`
+
+	t.Run("raw body", func(t *testing.T) {
+		transport, paths := cannedTransport(t, body)
+		config := Config{VdsinaAPIKey: "key", EnableDebugFetch: true, Transport: transport, CacheTTL: time.Hour}
+		m, sent := newTestMonitor(t, config, nil)
+
+		raw, err := m.DebugFetch(context.Background(), "vdsina")
+		if err != nil {
+			t.Fatalf("DebugFetch: %v", err)
+		}
+		if string(raw) != body {
+			t.Errorf("raw body %q, want %q", raw, body)
+		}
+		if got := paths(); !slices.Equal(got, []string{"/v1/account"}) {
+			t.Errorf("requests to %q, want the account request", got)
+		}
+		if texts := sent.texts(); len(texts) != 0 {
+			t.Errorf("messages %q, want nothing sent", texts)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		transport, paths := cannedTransport(t, body)
+		m, _ := newTestMonitor(t, Config{VdsinaAPIKey: "key", Transport: transport}, nil)
+
+		if _, err := m.DebugFetch(context.Background(), "vdsina"); !errors.Is(err, ErrDebugFetchDisabled) {
+			t.Fatalf("DebugFetch: %v, want ErrDebugFetchDisabled", err)
+		}
+		if got := paths(); len(got) != 0 {
+			t.Errorf("requests to %q, want none", got)
+		}
+	})
+
+	t.Run("unsupported provider", func(t *testing.T) {
+		m, _ := newTestMonitor(t, Config{EnableDebugFetch: true}, nil, newStubProvider("stub", nil))
+
+		if _, err := m.DebugFetch(context.Background(), "stub"); err == nil {
+			t.Fatal("DebugFetch of a provider without raw fetch succeeded, want an error")
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package neverforgetvps
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"text/template"
+)
+
+// DigestData is the data a Config.DigestTemplate is executed with
+type DigestData struct {
+	Summary   string           // One-line overview, e.g. "2 providers OK, 1 due in 3 day(s), 0 overdue"
+	Providers []DigestProvider // Every enabled provider sorted by name
+}
+
+// DigestProvider is the state of a single provider in DigestData
+type DigestProvider struct {
+	Name      string   // Provider name
+	Label     string   // Message label, empty if none is configured
+	Outcome   Outcome  // Kind of result of the last check
+	DaysUntil int      // Days until the payment date (negative when overdue), 0 if no payment is due
+	Severity  Severity // Severity of the last check
+	Date      string   // Next payment date as "2006-01-02", empty if no payment is due or the check failed
+	Err       error    // Failure of the last check, nil on success
+}
+
+// parseDigestTemplate parses the digest template, an empty text means the built-in digest
+func parseDigestTemplate(text string) (*template.Template, error) {
+	if text == "" {
+		return nil, nil
+	}
+	return template.New("digest").Option("missingkey=error").Parse(text)
+}
+
+// digestData builds the template data from statuses sorted by provider name
+func (m *vpsMonitor[T]) digestData(statuses []ProviderStatus) DigestData {
+	data := DigestData{
+		Summary:   summarize(statuses),
+		Providers: make([]DigestProvider, 0, len(statuses)),
+	}
+	for _, status := range statuses {
+		entry := DigestProvider{
+			Name:      status.Provider,
+			Label:     m.label(status.Provider),
+			Outcome:   status.Outcome(),
+			DaysUntil: status.DaysUntil,
+			Severity:  status.Severity,
+			Err:       status.Err,
+		}
+		if entry.Outcome == OutcomePaymentDue {
+			entry.Date = status.displayDate()
+		}
+		data.Providers = append(data.Providers, entry)
+	}
+	return data
+}
+
+// renderDigest executes the digest template, returning false if it fails
+func (m *vpsMonitor[T]) renderDigest(statuses []ProviderStatus) (string, bool) {
+	var b strings.Builder
+	if err := m.digestTemplate.Execute(&b, m.digestData(statuses)); err != nil {
+		m.logger.Error("digest template failed, using the built-in digest", slog.Any("error", err))
+		return "", false
+	}
+	return b.String(), true
+}
+
+// mustDigestTemplate parses the configured digest template or panics if it is invalid
+func mustDigestTemplate(text string) *template.Template {
+	tmpl, err := parseDigestTemplate(text)
+	if err != nil {
+		panic(fmt.Sprintf("invalid DigestTemplate: %v", err))
+	}
+	return tmpl
+}
@@ -0,0 +1,67 @@
+package neverforgetvps
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestDigestTemplate(t *testing.T) {
+	const digest = `{{.Summary}}
+{{range .Providers}}{{.Name}}{{with .Label}} [{{.}}]{{end}}: {{.Severity}}, {{if .Date}}{{.Date}} in {{.DaysUntil}} days{{else if .Err}}failed{{else}}nothing due{{end}}
+{{end}}`
+
+	clock := newTestClock()
+	failing := newStubProvider("cloudflare", nil)
+	failing.set(nil, errors.New("boom"))
+	config := Config{DigestTemplate: digest, Labels: map[string]string{"vdsina": "prod"}}
+	m, sent := newTestMonitor(t, config, clock,
+		newStubProvider("vdsina", daysFrom(clock.Now(), 2)),
+		newStubProvider("oneprovider", daysFrom(clock.Now(), 20)),
+		newStubProvider("timeweb", nil),
+		failing)
+
+	m.CheckNow(context.Background(), 0)
+	sent.reset()
+	m.sendHeartbeat(context.Background())
+
+	want := `2 providers OK, 1 due in 2 day(s), 0 overdue, 1 failed
+cloudflare: warning, failed
+oneprovider: info, 2026-11-05 in 20 days
+timeweb: info, nothing due
+vdsina [prod]: warning, 2026-10-18 in 2 days
+`
+	if texts := sent.texts(); len(texts) != 1 || texts[0] != want {
+		t.Errorf("digest %q, want %q", texts, want)
+	}
+}
+
+func TestDigestTemplateFallback(t *testing.T) {
+	clock := newTestClock()
+	logs := &syncBuffer{}
+	// Parses, but fails for every provider since the field does not exist
+	config := Config{DigestTemplate: "{{range .Providers}}{{.Missing}}{{end}}", Logger: slog.New(slog.NewTextHandler(logs, nil))}
+	m, sent := newTestMonitor(t, config, clock, newStubProvider("vdsina", daysFrom(clock.Now(), 20)))
+
+	m.CheckNow(context.Background(), 0)
+	sent.reset()
+	m.sendHeartbeat(context.Background())
+
+	if texts := sent.texts(); len(texts) != 1 || !strings.HasPrefix(texts[0], "💓 Monitor is running") {
+		t.Errorf("messages %q, want the built-in heartbeat", texts)
+	}
+	if !strings.Contains(logs.String(), "digest template failed") {
+		t.Errorf("logs %q, want the template failure", logs.String())
+	}
+}
+
+func TestInvalidDigestTemplate(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(r.(string), "invalid DigestTemplate") {
+			t.Errorf("panic %v, want an invalid DigestTemplate panic", r)
+		}
+	}()
+	newTestMonitor(t, Config{DigestTemplate: "{{range .Providers}"}, nil, newStubProvider("vdsina", nil))
+}
@@ -0,0 +1,83 @@
+package neverforgetvps
+
+import (
+	"time"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+// EffectiveConfig is a read-only snapshot of the settings in effect after defaults were applied
+type EffectiveConfig struct {
+	CheckInterval       time.Duration      // Interval between automatic checks, unused when Scheduled is set
+	Scheduled           bool               // Checks run at the fixed times of Config.Schedule
+	IntervalTiers       []IntervalTier     // Adaptive check intervals, empty means a fixed interval
+	CacheTTL            time.Duration      // How long successful provider results are reused, 0 means no caching
+	ConcurrentChecks    bool               // Providers are checked in parallel
+	MaxConcurrentChecks int                // Maximum provider checks in flight with ConcurrentChecks, 0 means unlimited
+	MinNotifySeverity   Severity           // Minimum severity of messages sent to the channel
+	AckCooldown         time.Duration      // How long an acknowledgement suppresses notifications
+	InfoNotifyInterval  time.Duration      // Minimum time between Info-level messages per provider
+	HeartbeatInterval   time.Duration      // Interval between heartbeat messages, 0 means disabled
+	MaxOverdueDays      int                // Maximum overdue days displayed in messages
+	OverdueTiers        []OverdueTier      // Overdue escalation tiers sorted by MinDaysOverdue
+	BalanceThresholds   map[string]float64 // Low balance thresholds keyed by provider name
+	Timezone            *time.Location     // Time zone dates are displayed and counted in, unless DisplayLocation or AccountTimezones applies
+	AccountTimezones    bool               // Dates are displayed in the provider account's time zone when it reports one
+	DisplayLocation     *time.Location     // Zone every provider's dates are displayed in, nil if not configured
+	Providers           []EffectiveProvider
+}
+
+// EffectiveProvider describes a registered provider in EffectiveConfig
+type EffectiveProvider struct {
+	Name         string        // Provider name
+	Label        string        // Message label, empty if none is configured
+	Timeout      time.Duration // Limit of each check of the provider
+	Enabled      bool          // False while disabled with SetProviderEnabled
+	Capabilities []string      // Optional interfaces implemented by the provider
+}
+
+// EffectiveConfig returns the settings in effect, including defaults applied for fields left zero
+// Providers are listed in registration order, disabled ones included
+// The returned value is a copy, changing it does not affect the monitor
+func (m *vpsMonitor[T]) EffectiveConfig() EffectiveConfig {
+	config := EffectiveConfig{
+		CheckInterval:       m.checkInterval,
+		Scheduled:           m.schedule != nil,
+		IntervalTiers:       append([]IntervalTier(nil), m.intervalTiers...),
+		CacheTTL:            m.cacheTTL,
+		ConcurrentChecks:    m.concurrentChecks,
+		MaxConcurrentChecks: m.maxConcurrentChecks,
+		MinNotifySeverity:   m.minNotifySeverity,
+		AckCooldown:         m.ackCooldown,
+		InfoNotifyInterval:  m.infoNotifyInterval,
+		HeartbeatInterval:   m.heartbeatInterval,
+		MaxOverdueDays:      m.maxOverdueDays,
+		OverdueTiers:        append([]OverdueTier(nil), m.overdueTiers...),
+		BalanceThresholds:   make(map[string]float64, len(m.balanceThresholds)),
+		Timezone:            m.timezone,
+		AccountTimezones:    m.accountTimezones,
+		DisplayLocation:     m.displayLocation,
+	}
+	for name, threshold := range m.balanceThresholds {
+		config.BalanceThresholds[name] = threshold
+	}
+
+	m.providersMu.RLock()
+	defer m.providersMu.RUnlock()
+
+	for _, entry := range m.providers {
+		if entry.Provider == nil {
+			continue
+		}
+		name := entry.Provider.GetName()
+		config.Providers = append(config.Providers, EffectiveProvider{
+			Name:         name,
+			Label:        m.labels[name],
+			Timeout:      entry.Timeout,
+			Enabled:      !m.disabled[name],
+			Capabilities: provider.Capabilities(entry.Provider),
+		})
+	}
+
+	return config
+}
@@ -0,0 +1,68 @@
+package neverforgetvps
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEffectiveConfigDefaults(t *testing.T) {
+	m := newVPSMonitor(context.Background(), Config{VdsinaAPIKey: "key", OneProviderAPIKey: "key", OneProviderClientKey: "client"},
+		func(message Message) Message { return message })
+	t.Cleanup(m.Stop)
+
+	config := m.EffectiveConfig()
+	if config.CheckInterval != DefaultCheckInterval || config.Scheduled {
+		t.Errorf("check interval %v (scheduled %v), want the default %v", config.CheckInterval, config.Scheduled, DefaultCheckInterval)
+	}
+	if config.MaxOverdueDays != DefaultMaxOverdueDays {
+		t.Errorf("max overdue days %d, want the default %d", config.MaxOverdueDays, DefaultMaxOverdueDays)
+	}
+	if config.Timezone != time.UTC || config.DisplayLocation != nil || config.AccountTimezones {
+		t.Errorf("time zone %v, display location %v, account time zones %v, want UTC only", config.Timezone, config.DisplayLocation, config.AccountTimezones)
+	}
+	if config.CacheTTL != 0 || config.HeartbeatInterval != 0 || config.ConcurrentChecks || len(config.IntervalTiers) != 0 {
+		t.Errorf("optional features %+v, want them disabled", config)
+	}
+
+	want := []EffectiveProvider{
+		{Name: "vdsina", Timeout: DefaultProviderTimeout + slowProviderMargin, Enabled: true},
+		{Name: "oneprovider", Timeout: DefaultProviderTimeout, Enabled: true},
+	}
+	if len(config.Providers) != len(want) {
+		t.Fatalf("providers %+v, want %+v", config.Providers, want)
+	}
+	for i, p := range config.Providers {
+		p.Capabilities = nil
+		if !reflect.DeepEqual(p, want[i]) {
+			t.Errorf("provider %+v, want %+v", p, want[i])
+		}
+	}
+}
+
+func TestEffectiveConfigIsACopy(t *testing.T) {
+	clock := newTestClock()
+	config := Config{
+		CheckInterval:     time.Hour,
+		BalanceThresholds: map[string]float64{"vdsina": 100},
+		Labels:            map[string]string{"vdsina": "prod"},
+	}
+	m, _ := newTestMonitor(t, config, clock, newStubProvider("vdsina", nil))
+	if err := m.SetProviderEnabled("vdsina", false); err != nil {
+		t.Fatal(err)
+	}
+
+	effective := m.EffectiveConfig()
+	if effective.CheckInterval != time.Hour || effective.BalanceThresholds["vdsina"] != 100 {
+		t.Errorf("effective config %+v, want the configured interval and threshold", effective)
+	}
+	if len(effective.Providers) != 1 || effective.Providers[0].Label != "prod" || effective.Providers[0].Enabled {
+		t.Errorf("providers %+v, want vdsina labelled prod and disabled", effective.Providers)
+	}
+
+	effective.BalanceThresholds["vdsina"] = 0
+	if m.EffectiveConfig().BalanceThresholds["vdsina"] != 100 {
+		t.Error("changing the snapshot changed the monitor")
+	}
+}
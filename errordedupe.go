@@ -0,0 +1,54 @@
+package neverforgetvps
+
+import (
+	"errors"
+)
+
+// trackErrorState compares the status with the failure the user was last told about, for error deduplication
+// Returns whether an error must be reported (the first failure or a changed category) and
+// whether a successful check ended a reported failure streak
+// Nothing is recorded here: recordErrorState does once the message is delivered, so an error dropped on the way
+// (pause, acknowledgement, rate limit, maintenance) is reported by a later check
+// Without Config.DedupeErrors every error is reported and no recovery is signaled
+func (m *vpsMonitor[T]) trackErrorState(status ProviderStatus) (report, recovered bool) {
+	if !m.dedupeErrors {
+		return status.Outcome() == OutcomeFailed, false
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	previous, failing := m.errorCategories[status.Provider]
+	if status.Outcome() != OutcomeFailed {
+		return false, failing
+	}
+	return !failing || previous != errorCategory(status), false
+}
+
+// recordErrorState records a delivered error or recovery message of the provider
+func (m *vpsMonitor[T]) recordErrorState(status ProviderStatus) {
+	if !m.dedupeErrors {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if status.Outcome() != OutcomeFailed {
+		delete(m.errorCategories, status.Provider)
+		return
+	}
+	if m.errorCategories == nil {
+		m.errorCategories = make(map[string]ErrorCategory)
+	}
+	m.errorCategories[status.Provider] = errorCategory(status)
+}
+
+// errorCategory returns the failure category of a failed status
+func errorCategory(status ProviderStatus) ErrorCategory {
+	var providerErr *ProviderError
+	if errors.As(status.Err, &providerErr) {
+		return providerErr.Category
+	}
+	return ErrorCategoryUnknown
+}
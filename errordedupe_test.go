@@ -0,0 +1,101 @@
+package neverforgetvps
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// countContaining returns how many texts contain substr
+func countContaining(texts []string, substr string) int {
+	count := 0
+	for _, text := range texts {
+		if strings.Contains(text, substr) {
+			count++
+		}
+	}
+	return count
+}
+
+func TestDedupeErrors(t *testing.T) {
+	const errorText = "Error checking payment date"
+	const recoveryText = "recovered"
+
+	t.Run("repeated errors are reported once until recovery", func(t *testing.T) {
+		clock := newTestClock()
+		p := newStubProvider("vdsina", nil)
+		p.set(nil, errors.New("boom"))
+		m, sent := newTestMonitor(t, Config{DedupeErrors: true}, clock, p)
+
+		for range 3 {
+			m.CheckNow(context.Background(), 0)
+		}
+		p.set(daysFrom(clock.Now(), 30), nil)
+		m.CheckNow(context.Background(), 0)
+		p.set(nil, errors.New("boom"))
+		m.CheckNow(context.Background(), 0)
+
+		texts := sent.texts()
+		if got := countContaining(texts, errorText); got != 2 {
+			t.Errorf("%d error messages, want one per failure streak: %q", got, texts)
+		}
+		if got := countContaining(texts, recoveryText); got != 1 {
+			t.Errorf("%d recovery messages, want 1: %q", got, texts)
+		}
+	})
+
+	dropped := []struct {
+		name   string
+		config Config
+		drop   func(m *vpsMonitor[Message], clock *testClock)
+		resume func(m *vpsMonitor[Message], clock *testClock)
+	}{
+		{
+			name: "paused",
+			drop: func(m *vpsMonitor[Message], clock *testClock) {
+				if err := m.PauseProviderUntil("vdsina", clock.Now().Add(time.Hour)); err != nil {
+					t.Fatal(err)
+				}
+			},
+			resume: func(m *vpsMonitor[Message], clock *testClock) { clock.Advance(2 * time.Hour) },
+		},
+		{
+			name:   "maintenance",
+			drop:   func(m *vpsMonitor[Message], clock *testClock) { m.EnterMaintenance(clock.Now().Add(time.Hour)) },
+			resume: func(m *vpsMonitor[Message], clock *testClock) { m.ExitMaintenance() },
+		},
+		{
+			name:   "rate limited",
+			config: Config{SeverityRateLimits: map[Severity]int{SeverityWarning: 1}},
+			drop: func(m *vpsMonitor[Message], clock *testClock) {
+				m.sendMessage(monitorMessage(SeverityWarning, "other warning"))
+			},
+			resume: func(m *vpsMonitor[Message], clock *testClock) { clock.Advance(2 * time.Minute) },
+		},
+	}
+	for _, tt := range dropped {
+		t.Run("error dropped while "+tt.name+" is reported later", func(t *testing.T) {
+			clock := newTestClock()
+			p := newStubProvider("vdsina", nil)
+			p.set(nil, errors.New("boom"))
+			config := tt.config
+			config.DedupeErrors = true
+			m, sent := newTestMonitor(t, config, clock, p)
+
+			tt.drop(m, clock)
+			m.CheckNow(context.Background(), 0)
+			if got := countContaining(sent.texts(), errorText); got != 0 {
+				t.Fatalf("%d error messages while %s, want 0: %q", got, tt.name, sent.texts())
+			}
+
+			tt.resume(m, clock)
+			m.CheckNow(context.Background(), 0)
+			m.CheckNow(context.Background(), 0)
+			if got := countContaining(sent.texts(), errorText); got != 1 {
+				t.Fatalf("%d error messages after %s, want 1: %q", got, tt.name, sent.texts())
+			}
+		})
+	}
+}
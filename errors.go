@@ -0,0 +1,119 @@
+package neverforgetvps
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+	"github.com/custom-app/NeverForgetVPS/provider/yandexcloud"
+)
+
+// ErrorCategory classifies provider failures for filtering and routing
+type ErrorCategory string
+
+const (
+	ErrorCategoryTimeout   ErrorCategory = "timeout"    // The check exceeded its deadline
+	ErrorCategoryCanceled  ErrorCategory = "canceled"   // The check was canceled (e.g. the monitor stopped)
+	ErrorCategoryAuth      ErrorCategory = "auth"       // Missing, invalid or insufficient credentials
+	ErrorCategoryRateLimit ErrorCategory = "rate_limit" // The provider API rate limited the request
+	ErrorCategoryServer    ErrorCategory = "server"     // The provider API returned a server error
+	ErrorCategoryNetwork   ErrorCategory = "network"    // The provider API could not be reached
+	ErrorCategoryParse     ErrorCategory = "parse"      // The provider response could not be parsed
+	ErrorCategoryUnknown   ErrorCategory = "unknown"    // Anything else
+)
+
+// ProviderError is the error reported in ProviderStatus.Err when a provider check fails
+// It carries the provider name and a category, the original error is available via Unwrap
+type ProviderError struct {
+	Provider  string        // Provider name
+	Category  ErrorCategory // Failure category
+	RequestID string        // ID of the failed check, also logged and optionally sent to the provider API
+	Err       error         // Original error returned by the provider
+}
+
+// Error returns the error message including provider name and category
+func (e *ProviderError) Error() string {
+	if e.RequestID != "" {
+		return fmt.Sprintf("provider %s (%s, request %s): %v", e.Provider, e.Category, e.RequestID, e.Err)
+	}
+	return fmt.Sprintf("provider %s (%s): %v", e.Provider, e.Category, e.Err)
+}
+
+// Unwrap returns the original error
+func (e *ProviderError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the failure belongs to the provider.ErrTransient or provider.ErrPermanent class
+// Timeouts, network, rate limit and server failures are transient, authentication failures are permanent,
+// and so is any error the provider classified itself, e.g. a 400 reported with provider.StatusError
+func (e *ProviderError) Is(target error) bool {
+	switch target {
+	case provider.ErrTransient:
+		return e.Category.Transient() || errors.Is(e.Err, provider.ErrTransient)
+	case provider.ErrPermanent:
+		return e.Category == ErrorCategoryAuth || errors.Is(e.Err, provider.ErrPermanent)
+	default:
+		return false
+	}
+}
+
+// Transient reports whether failures of the category may go away without a configuration change
+func (c ErrorCategory) Transient() bool {
+	switch c {
+	case ErrorCategoryTimeout, ErrorCategoryNetwork, ErrorCategoryRateLimit, ErrorCategoryServer:
+		return true
+	default:
+		return false
+	}
+}
+
+// newProviderError wraps an error returned by a provider into a ProviderError
+func newProviderError(providerName string, err error) *ProviderError {
+	return &ProviderError{
+		Provider: providerName,
+		Category: categorizeError(err),
+		Err:      err,
+	}
+}
+
+// categorizeError determines the category of a provider error
+func categorizeError(err error) ErrorCategory {
+	var statusErr *provider.HTTPStatusError
+	var netErr net.Error
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrorCategoryTimeout
+	case errors.Is(err, context.Canceled):
+		return ErrorCategoryCanceled
+	case errors.Is(err, provider.ErrMissingCredentials), errors.Is(err, yandexcloud.ErrTokenExpired):
+		return ErrorCategoryAuth
+	case errors.As(err, &statusErr):
+		switch {
+		case statusErr.StatusCode == http.StatusUnauthorized || statusErr.StatusCode == http.StatusForbidden:
+			return ErrorCategoryAuth
+		case statusErr.StatusCode == http.StatusTooManyRequests:
+			return ErrorCategoryRateLimit
+		case statusErr.StatusCode >= http.StatusInternalServerError:
+			return ErrorCategoryServer
+		default:
+			return ErrorCategoryUnknown
+		}
+	case errors.As(err, &netErr):
+		if netErr.Timeout() {
+			return ErrorCategoryTimeout
+		}
+		return ErrorCategoryNetwork
+	case errors.As(err, &syntaxErr), errors.As(err, &typeErr):
+		return ErrorCategoryParse
+	default:
+		return ErrorCategoryUnknown
+	}
+}
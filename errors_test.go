@@ -0,0 +1,116 @@
+package neverforgetvps
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+// errorLogHandler records the "error" attribute of every logged record
+type errorLogHandler struct {
+	mu     sync.Mutex
+	errors []error
+}
+
+func (h *errorLogHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h *errorLogHandler) Handle(_ context.Context, record slog.Record) error {
+	record.Attrs(func(attr slog.Attr) bool {
+		if err, ok := attr.Value.Any().(error); ok && attr.Key == "error" {
+			h.mu.Lock()
+			h.errors = append(h.errors, err)
+			h.mu.Unlock()
+		}
+		return true
+	})
+	return nil
+}
+
+func (h *errorLogHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+
+func (h *errorLogHandler) WithGroup(string) slog.Handler { return h }
+
+func TestProviderError(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		wantCategory ErrorCategory
+	}{
+		{name: "unauthorized", err: provider.StatusError("VDSina", http.StatusUnauthorized, "VDSINA_API_KEY", nil), wantCategory: ErrorCategoryAuth},
+		{name: "rate limited", err: provider.StatusError("VDSina", http.StatusTooManyRequests, "VDSINA_API_KEY", nil), wantCategory: ErrorCategoryRateLimit},
+		{name: "server error", err: provider.StatusError("VDSina", http.StatusBadGateway, "VDSINA_API_KEY", nil), wantCategory: ErrorCategoryServer},
+		{name: "timeout", err: context.DeadlineExceeded, wantCategory: ErrorCategoryTimeout},
+		{name: "malformed response", err: json.Unmarshal([]byte("{"), &struct{}{}), wantCategory: ErrorCategoryParse},
+		{name: "anything else", err: errors.New("boom"), wantCategory: ErrorCategoryUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newStubProvider("vdsina", nil)
+			p.set(nil, tt.err)
+			logs := &errorLogHandler{}
+			var statuses []ProviderStatus
+			config := Config{
+				Logger:   slog.New(logs),
+				OnResult: func(status ProviderStatus) { statuses = append(statuses, status) },
+			}
+			m, _ := newTestMonitor(t, config, newTestClock(), p)
+
+			m.CheckNow(context.Background(), 0)
+
+			if len(statuses) != 1 {
+				t.Fatalf("%d results, want 1", len(statuses))
+			}
+			var providerErr *ProviderError
+			if !errors.As(statuses[0].Err, &providerErr) {
+				t.Fatalf("status error %T, want *ProviderError", statuses[0].Err)
+			}
+			if providerErr.Provider != "vdsina" || providerErr.Category != tt.wantCategory || !errors.Is(providerErr, tt.err) {
+				t.Errorf("error for provider %q in category %q wrapping %v, want vdsina, %q and %v", providerErr.Provider, providerErr.Category, providerErr.Err, tt.wantCategory, tt.err)
+			}
+
+			logs.mu.Lock()
+			defer logs.mu.Unlock()
+			var logged *ProviderError
+			if !slices.ContainsFunc(logs.errors, func(err error) bool { return errors.As(err, &logged) }) || logged.Category != tt.wantCategory {
+				t.Errorf("logged errors %v, want the structured ProviderError", logs.errors)
+			}
+		})
+	}
+}
+
+func TestCheckRetriesByClass(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantCalls int
+	}{
+		{name: "transient server error", err: provider.StatusError("VDSina", http.StatusServiceUnavailable, "VDSINA_API_KEY", nil), wantCalls: 3},
+		{name: "transient timeout", err: context.DeadlineExceeded, wantCalls: 3},
+		{name: "permanent unauthorized", err: provider.StatusError("VDSina", http.StatusUnauthorized, "VDSINA_API_KEY", nil), wantCalls: 1},
+		{name: "permanent bad request", err: provider.StatusError("VDSina", http.StatusBadRequest, "VDSINA_API_KEY", nil), wantCalls: 1},
+		{name: "unclassified", err: errors.New("boom"), wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newStubProvider("vdsina", nil)
+			p.set(nil, tt.err)
+			m, _ := newTestMonitor(t, Config{CheckRetries: 2, CheckRetryDelay: time.Millisecond}, newTestClock(), p)
+
+			m.CheckNow(context.Background(), 0)
+
+			if p.callCount() != tt.wantCalls {
+				t.Errorf("%d attempts, want %d", p.callCount(), tt.wantCalls)
+			}
+		})
+	}
+}
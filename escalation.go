@@ -0,0 +1,57 @@
+package neverforgetvps
+
+import (
+	"sort"
+	"strconv"
+)
+
+// DefaultMaxOverdueDays is the default maximum of overdue days displayed in messages
+const DefaultMaxOverdueDays = 999
+
+// OverdueTier describes how an overdue payment is presented after a number of days
+type OverdueTier struct {
+	MinDaysOverdue int    // Tier applies when the payment is overdue by at least this many days
+	Label          string // Message prefix, e.g. "🚨🚨🚨 CRITICAL"
+	Action         string // Call to action appended to the message
+}
+
+// DefaultOverdueTiers are used when Config.OverdueTiers is empty
+var DefaultOverdueTiers = []OverdueTier{
+	{MinDaysOverdue: 1, Label: "🚨🚨🚨 CRITICAL", Action: "Urgent action required!"},
+	{MinDaysOverdue: 7, Label: "🔥🚨🚨🚨 CRITICAL", Action: "Service suspension is imminent, pay immediately!"},
+	{MinDaysOverdue: 30, Label: "☠️🚨🚨🚨 CRITICAL", Action: "Services may already be suspended or deleted!"},
+}
+
+// sortOverdueTiers returns a copy of tiers sorted by MinDaysOverdue ascending
+func sortOverdueTiers(tiers []OverdueTier) []OverdueTier {
+	if len(tiers) == 0 {
+		tiers = DefaultOverdueTiers
+	}
+	sorted := make([]OverdueTier, len(tiers))
+	copy(sorted, tiers)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].MinDaysOverdue < sorted[j].MinDaysOverdue
+	})
+	return sorted
+}
+
+// clampDays formats a day count, displaying values above maxDays as "maxDays+"
+// Protects messages from nonsensical numbers for years-old or corrupt dates
+func clampDays(days, maxDays int) string {
+	if days > maxDays {
+		return strconv.Itoa(maxDays) + "+"
+	}
+	return strconv.Itoa(days)
+}
+
+// overdueTier selects the most severe tier reached by daysOverdue
+// Falls back to the least severe tier if daysOverdue is below every threshold
+func overdueTier(tiers []OverdueTier, daysOverdue int) OverdueTier {
+	selected := tiers[0]
+	for _, tier := range tiers {
+		if daysOverdue >= tier.MinDaysOverdue {
+			selected = tier
+		}
+	}
+	return selected
+}
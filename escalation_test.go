@@ -0,0 +1,72 @@
+package neverforgetvps
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestOverdueTiers(t *testing.T) {
+	custom := []OverdueTier{
+		{MinDaysOverdue: 30, Label: "LATE", Action: "Pay now."},
+		{MinDaysOverdue: 1, Label: "DUE", Action: "Please pay."},
+	}
+
+	tests := []struct {
+		name        string
+		tiers       []OverdueTier
+		daysOverdue int
+		wantPrefix  string
+		wantAction  string
+	}{
+		{name: "one day", daysOverdue: 1, wantPrefix: "🚨🚨🚨 CRITICAL:", wantAction: "Urgent action required!"},
+		{name: "three days", daysOverdue: 3, wantPrefix: "🚨🚨🚨 CRITICAL:", wantAction: "Urgent action required!"},
+		{name: "one week", daysOverdue: 7, wantPrefix: "🔥🚨🚨🚨 CRITICAL:", wantAction: "Service suspension is imminent"},
+		{name: "over a month", daysOverdue: 45, wantPrefix: "☠️🚨🚨🚨 CRITICAL:", wantAction: "Services may already be suspended"},
+		{name: "custom tiers are sorted", tiers: custom, daysOverdue: 10, wantPrefix: "DUE:", wantAction: "Please pay."},
+		{name: "custom tier reached", tiers: custom, daysOverdue: 30, wantPrefix: "LATE:", wantAction: "Pay now."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newTestClock()
+			p := newStubProvider("vdsina", daysFrom(clock.Now(), -tt.daysOverdue))
+			m, sent := newTestMonitor(t, Config{OverdueTiers: tt.tiers}, clock, p)
+
+			m.CheckNow(context.Background(), 0)
+
+			texts := sent.texts()
+			if len(texts) != 1 || !strings.HasPrefix(texts[0], tt.wantPrefix) || !strings.Contains(texts[0], tt.wantAction) {
+				t.Fatalf("messages %q, want one starting with %q and containing %q", texts, tt.wantPrefix, tt.wantAction)
+			}
+		})
+	}
+}
+
+func TestMaxOverdueDays(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxDays     int
+		daysOverdue int
+		want        string
+	}{
+		{name: "decades ago with the default", daysOverdue: 30 * 365, want: "(999+ days ago)"},
+		{name: "decades ago with a custom maximum", maxDays: 90, daysOverdue: 30 * 365, want: "(90+ days ago)"},
+		{name: "at the maximum", maxDays: 90, daysOverdue: 90, want: "(90 days ago)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newTestClock()
+			p := newStubProvider("vdsina", daysFrom(clock.Now(), -tt.daysOverdue))
+			m, sent := newTestMonitor(t, Config{MaxOverdueDays: tt.maxDays}, clock, p)
+
+			m.CheckNow(context.Background(), 0)
+
+			texts := sent.texts()
+			if len(texts) != 1 || !strings.Contains(texts[0], tt.want) {
+				t.Fatalf("messages %q, want one containing %q", texts, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package neverforgetvps
+
+import (
+	"encoding/json"
+	"log/slog"
+	"time"
+)
+
+// Event types written to Config.EventWriter
+const (
+	EventNotification = "notification"  // A message was sent (or would be sent without a channel)
+	EventSuppressed   = "suppressed"    // A message was dropped by SeverityRateLimits
+	EventStatusChange = "status_change" // A provider's severity, payment date or error state changed
+)
+
+// Event is a single line written to Config.EventWriter as newline-delimited JSON
+type Event struct {
+	Type      string    `json:"type"`               // EventNotification, EventSuppressed or EventStatusChange
+	Provider  string    `json:"provider,omitempty"` // Provider name, empty for monitor-wide notifications
+	Severity  string    `json:"severity"`           // Severity name, e.g. "warning"
+	Date      string    `json:"date,omitempty"`     // Next payment date as "2006-01-02", status changes only
+	Days      *int      `json:"days,omitempty"`     // Days until the payment date, status changes with a payment date only
+	Outcome   string    `json:"outcome,omitempty"`  // Outcome of the check, status changes only
+	Error     string    `json:"error,omitempty"`    // Check failure, status changes only
+	Text      string    `json:"text,omitempty"`     // Message text, notifications and suppressed messages only
+	Timestamp time.Time `json:"timestamp"`          // When the event occurred
+}
+
+// flusher is implemented by buffered writers, e.g. *bufio.Writer
+type flusher interface {
+	Flush() error
+}
+
+// writeEvent writes the event as one JSON line and flushes buffered writers
+// Write failures are logged, they never affect monitoring
+func (m *vpsMonitor[T]) writeEvent(event Event) {
+	if m.eventWriter == nil {
+		return
+	}
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		m.logger.Error("failed to encode event", slog.Any("error", err))
+		return
+	}
+	line = append(line, '\n')
+
+	m.eventMu.Lock()
+	defer m.eventMu.Unlock()
+
+	if _, err := m.eventWriter.Write(line); err != nil {
+		m.logger.Error("failed to write event", slog.Any("error", err))
+		return
+	}
+	if f, ok := m.eventWriter.(flusher); ok {
+		if err := f.Flush(); err != nil {
+			m.logger.Error("failed to flush event", slog.Any("error", err))
+		}
+	}
+}
+
+// notificationEvent describes a sent or suppressed message, eventType is EventNotification or EventSuppressed
+func (m *vpsMonitor[T]) notificationEvent(message Message, eventType string) Event {
+	return Event{
+		Type:      eventType,
+		Provider:  message.Provider,
+		Severity:  message.Severity.String(),
+		Text:      message.Text,
+		Timestamp: m.now(),
+	}
+}
+
+// statusChangeEvent describes a changed provider status
+func statusChangeEvent(status ProviderStatus) Event {
+	event := Event{
+		Type:      EventStatusChange,
+		Provider:  status.Provider,
+		Severity:  status.Severity.String(),
+		Outcome:   status.Outcome().String(),
+		Timestamp: status.CheckedAt,
+	}
+	switch status.Outcome() {
+	case OutcomeFailed:
+		event.Error = status.Err.Error()
+	case OutcomePaymentDue:
+		days := status.DaysUntil
+		event.Date = status.displayDate()
+		event.Days = &days
+	}
+	return event
+}
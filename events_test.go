@@ -0,0 +1,67 @@
+package neverforgetvps
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEventWriter(t *testing.T) {
+	var out bytes.Buffer
+	clock := newTestClock()
+	p := newStubProvider("vdsina", daysFrom(clock.Now(), 2))
+	// Buffered on purpose, every event must be flushed as soon as it is written
+	m, _ := newTestMonitor(t, Config{EventWriter: bufio.NewWriter(&out)}, clock, p)
+
+	m.CheckNow(context.Background(), 0)
+	if lines := strings.Count(out.String(), "\n"); lines != 2 {
+		t.Fatalf("%d lines after the first check, want the status change and the notification flushed", lines)
+	}
+	// The same state is not a status change
+	clock.Advance(time.Hour)
+	m.CheckNow(context.Background(), 0)
+	clock.Advance(time.Hour)
+	p.set(nil, errors.New("boom"))
+	m.CheckNow(context.Background(), 0)
+
+	intPtr := func(i int) *int { return &i }
+	const pay = "\nPay: https://cp.vdsina.com/"
+	want := []Event{
+		{Type: EventStatusChange, Provider: "vdsina", Severity: "warning", Date: "2026-10-18", Days: intPtr(2), Outcome: "payment_due", Timestamp: testNow},
+		{Type: EventNotification, Provider: "vdsina", Severity: "warning", Text: "🚨 WARNING: Provider vdsina - Urgent payment required! Payment due date: 2026-10-18 (2 day(s) left)" + pay, Timestamp: testNow},
+		{Type: EventNotification, Provider: "vdsina", Severity: "warning", Text: "🚨 WARNING: Provider vdsina - Urgent payment required! Payment due date: 2026-10-18 (1 day(s) left)" + pay, Timestamp: testNow.Add(time.Hour)},
+		{Type: EventStatusChange, Provider: "vdsina", Severity: "warning", Outcome: "failed", Error: "boom", Timestamp: testNow.Add(2 * time.Hour)},
+		{Type: EventNotification, Provider: "vdsina", Severity: "warning", Text: "Error checking payment date for provider vdsina: boom", Timestamp: testNow.Add(2 * time.Hour)},
+	}
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("%d events, want %d:\n%s", len(lines), len(want), out.String())
+	}
+	for i, line := range lines {
+		var got Event
+		if err := json.Unmarshal([]byte(line), &got); err != nil {
+			t.Fatalf("line %d %q is not JSON: %v", i+1, line, err)
+		}
+		// The error is wrapped with the provider and request ID
+		if strings.HasSuffix(got.Error, ": "+want[i].Error) {
+			got.Error = want[i].Error
+		}
+		if (got.Days == nil) != (want[i].Days == nil) || (got.Days != nil && *got.Days != *want[i].Days) {
+			t.Errorf("event %d days %v, want %v", i+1, got.Days, want[i].Days)
+		}
+		got.Days, want[i].Days = nil, nil
+		if !got.Timestamp.Equal(want[i].Timestamp) {
+			t.Errorf("event %d at %v, want %v", i+1, got.Timestamp, want[i].Timestamp)
+		}
+		got.Timestamp, want[i].Timestamp = time.Time{}, time.Time{}
+		if got != want[i] {
+			t.Errorf("event %d %+v, want %+v", i+1, got, want[i])
+		}
+	}
+}
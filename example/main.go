@@ -18,8 +18,8 @@ type MessageToSend struct {
 }
 
 func main() {
-	// Create context for cancellation
-	ctx, cancel := context.WithCancel(context.Background())
+	// Create context canceled on interrupt signal
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
 
 	// Create message channel (using string for simplicity, can be chan domain.MessageToSend)
@@ -34,10 +34,29 @@ func main() {
 
 	// Create VPSMonitor configuration
 	config := neverforgetvps.Config{
-		VdsinaAPIKey:         os.Getenv("VDSINA_API_KEY"),         // Set via environment variable
-		OneProviderAPIKey:    os.Getenv("ONEPROVIDER_API_KEY"),    // Set via environment variable
-		OneProviderClientKey: os.Getenv("ONEPROVIDER_CLIENT_KEY"), // Set via environment variable
-		CheckInterval:        1 * time.Minute,                     // Check every hour
+		VdsinaAPIKey:              os.Getenv("VDSINA_API_KEY"),                  // Set via environment variable
+		OneProviderAPIKey:         os.Getenv("ONEPROVIDER_API_KEY"),             // Set via environment variable
+		OneProviderClientKey:      os.Getenv("ONEPROVIDER_CLIENT_KEY"),          // Set via environment variable
+		CloudflareAPIKey:          os.Getenv("CLOUDFLARE_API_TOKEN"),            // Set via environment variable
+		YandexCloudIAMToken:       os.Getenv("YANDEX_CLOUD_IAM_TOKEN"),          // Set via environment variable
+		YandexCloudAccountID:      os.Getenv("YANDEX_CLOUD_BILLING_ACCOUNT_ID"), // Set via environment variable
+		TimewebAPIKey:             os.Getenv("TIMEWEB_API_TOKEN"),               // Set via environment variable
+		BuyVMAPIKey:               os.Getenv("BUYVM_API_KEY"),                   // Set via environment variable
+		OracleCloudTenancyOCID:    os.Getenv("OCI_TENANCY_OCID"),                // Set via environment variable
+		OracleCloudUserOCID:       os.Getenv("OCI_USER_OCID"),                   // Set via environment variable
+		OracleCloudKeyFingerprint: os.Getenv("OCI_KEY_FINGERPRINT"),             // Set via environment variable
+		OracleCloudPrivateKey:     os.Getenv("OCI_PRIVATE_KEY"),                 // Set via environment variable
+		OracleCloudRegion:         os.Getenv("OCI_REGION"),                      // Set via environment variable
+		GcoreAPIKey:               os.Getenv("GCORE_API_TOKEN"),                 // Set via environment variable
+		NetcupCustomerNumber:      os.Getenv("NETCUP_CUSTOMER_NUMBER"),          // Set via environment variable
+		NetcupAPIKey:              os.Getenv("NETCUP_API_KEY"),                  // Set via environment variable
+		NetcupAPIPassword:         os.Getenv("NETCUP_API_PASSWORD"),             // Set via environment variable
+		HostingerAPIKey:           os.Getenv("HOSTINGER_API_TOKEN"),             // Set via environment variable
+		RegRuUsername:             os.Getenv("REGRU_USERNAME"),                  // Set via environment variable
+		RegRuPassword:             os.Getenv("REGRU_PASSWORD"),                  // Set via environment variable
+		KamateraClientID:          os.Getenv("KAMATERA_CLIENT_ID"),              // Set via environment variable
+		KamateraSecret:            os.Getenv("KAMATERA_SECRET"),                 // Set via environment variable
+		CheckInterval:             1 * time.Minute,                              // Check every hour
 	}
 
 	// Create VPSMonitor instance with your typed channel and converter function
@@ -47,20 +66,12 @@ func main() {
 		}
 	})
 
-	// Start monitoring
-	if err := monitor.Start(); err != nil {
-		fmt.Printf("Failed to start monitor: %v\n", err)
-		return
-	}
-
 	fmt.Println("VPSMonitor started. Press Ctrl+C to stop...")
 
-	// Wait for interrupt signal
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-	<-sigChan
-
-	fmt.Println("\nStopping monitor...")
-	monitor.Stop()
+	// Monitor until interrupted, then stop
+	if err := monitor.Run(ctx); err != nil {
+		fmt.Printf("Monitor failed: %v\n", err)
+		return
+	}
 	fmt.Println("Monitor stopped")
 }
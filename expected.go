@@ -0,0 +1,68 @@
+package neverforgetvps
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultExpectedDateTolerance is the allowed difference from an expected date when ExpectedDateTolerance is not set
+const DefaultExpectedDateTolerance = 24 * time.Hour
+
+// checkExpectedDate compares the provider's next payment date with the configured expected date
+// and returns a mismatch warning when they differ by more than the tolerance
+// The warning is sent once per mismatching date: again only after the reported date changes,
+// or if the previous warning was not delivered (see recordMismatch)
+// Providers without an expected date and checks without a payment date are skipped
+func (m *vpsMonitor[T]) checkExpectedDate(status ProviderStatus) (pendingMessage, bool) {
+	expected, found := m.expectedDates[status.Provider]
+	if !found || status.Outcome() == OutcomeFailed {
+		return pendingMessage{}, false
+	}
+
+	if status.Outcome() != OutcomePaymentDue || status.NextDate.Sub(expected).Abs() <= m.expectedDateTolerance {
+		m.mu.Lock()
+		delete(m.mismatches, status.Provider)
+		m.mu.Unlock()
+		return pendingMessage{}, false
+	}
+	if m.mismatchReported(status.Provider, *status.NextDate) {
+		return pendingMessage{}, false
+	}
+
+	diff := status.NextDate.Sub(expected)
+
+	direction := "later"
+	if diff < 0 {
+		direction = "earlier"
+	}
+	days := daysBetween(expected, *status.NextDate, m.dayRounding)
+	if days < 0 {
+		days = -days
+	}
+
+	status.Severity = SeverityWarning
+	text := fmt.Sprintf("⚠️ MISMATCH: Provider %s - Next payment date %s is %d day(s) %s than the expected %s", status.Provider, status.displayDate(), days, direction, formatDate(expected, status.Location))
+	return pendingMessage{status, text, messageExpected}, true
+}
+
+// mismatchReported checks whether a warning about the mismatching date was already delivered
+func (m *vpsMonitor[T]) mismatchReported(name string, date time.Time) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	previous, found := m.mismatches[name]
+	return found && previous.Equal(date)
+}
+
+// recordMismatch records the mismatching date of a delivered warning
+// A warning dropped on the way (pause, acknowledgement, rate limit, maintenance) is not recorded,
+// so a later check sends it again
+func (m *vpsMonitor[T]) recordMismatch(name string, date time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.mismatches == nil {
+		m.mismatches = make(map[string]time.Time)
+	}
+	m.mismatches[name] = date
+}
@@ -0,0 +1,67 @@
+package neverforgetvps
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestExpectedDateMismatch(t *testing.T) {
+	const mismatchText = "MISMATCH"
+	reported := testNow.AddDate(0, 0, 40)
+
+	tests := []struct {
+		name     string
+		expected time.Time
+		want     int
+	}{
+		{name: "within tolerance", expected: reported.Add(12 * time.Hour), want: 0},
+		{name: "outside tolerance", expected: reported.AddDate(0, 0, -5), want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newStubProvider("vdsina", &reported)
+			m, sent := newTestMonitor(t, Config{ExpectedNextDates: map[string]time.Time{"vdsina": tt.expected}}, newTestClock(), p)
+
+			for range 3 {
+				m.CheckNow(context.Background(), 0)
+			}
+			if got := countContaining(sent.texts(), mismatchText); got != tt.want {
+				t.Fatalf("%d mismatch warnings, want %d: %q", got, tt.want, sent.texts())
+			}
+		})
+	}
+
+	t.Run("changed date is reported again", func(t *testing.T) {
+		p := newStubProvider("vdsina", &reported)
+		m, sent := newTestMonitor(t, Config{ExpectedNextDates: map[string]time.Time{"vdsina": reported.AddDate(0, 0, -5)}}, newTestClock(), p)
+
+		m.CheckNow(context.Background(), 0)
+		p.set(daysFrom(reported, 1), nil)
+		m.CheckNow(context.Background(), 0)
+		if got := countContaining(sent.texts(), mismatchText); got != 2 {
+			t.Fatalf("%d mismatch warnings, want 2: %q", got, sent.texts())
+		}
+	})
+
+	t.Run("warning dropped while paused is reported later", func(t *testing.T) {
+		clock := newTestClock()
+		p := newStubProvider("vdsina", &reported)
+		m, sent := newTestMonitor(t, Config{ExpectedNextDates: map[string]time.Time{"vdsina": reported.AddDate(0, 0, -5)}}, clock, p)
+
+		if err := m.PauseProviderUntil("vdsina", clock.Now().Add(time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+		m.CheckNow(context.Background(), 0)
+		if got := countContaining(sent.texts(), mismatchText); got != 0 {
+			t.Fatalf("%d mismatch warnings while paused, want 0: %q", got, sent.texts())
+		}
+
+		clock.Advance(2 * time.Hour)
+		m.CheckNow(context.Background(), 0)
+		m.CheckNow(context.Background(), 0)
+		if got := countContaining(sent.texts(), mismatchText); got != 1 {
+			t.Fatalf("%d mismatch warnings after the pause, want 1: %q", got, sent.texts())
+		}
+	})
+}
@@ -0,0 +1,64 @@
+package neverforgetvps
+
+import (
+	"fmt"
+)
+
+// Formatter formats the message of a provider with a payment due
+// Format is called for statuses with OutcomePaymentDue only; labels and pay links are added afterwards
+type Formatter interface {
+	// Format returns the message text for the status
+	Format(status ProviderStatus) string
+}
+
+// GroupFormatter is an optional extension of Formatter for payment messages merged by Config.GroupPayments
+// FormatGroup is called with two or more statuses due on the same date with the same severity;
+// provider labels are prefixed and pay links appended afterwards
+// Formatters without it have merged messages worded like EmojiFormatter
+type GroupFormatter interface {
+	// FormatGroup returns the message text for the statuses
+	FormatGroup(statuses []ProviderStatus) string
+}
+
+// EmojiFormatter is the built-in Formatter producing messages like
+// "⚠️ ATTENTION: Provider vdsina - Payment due soon! Payment date: 2025-06-01 (3 days left)"
+type EmojiFormatter struct {
+	OverdueTiers   []OverdueTier // Overdue escalation tiers sorted by MinDaysOverdue (default: DefaultOverdueTiers)
+	MaxOverdueDays int           // Maximum overdue days displayed (default: DefaultMaxOverdueDays)
+}
+
+// Format formats a payment notification message based on days until payment
+func (f EmojiFormatter) Format(status ProviderStatus) string {
+	providerName := status.Provider
+	daysUntil := status.DaysUntil
+	dateStr := status.displayDate()
+
+	switch status.Severity {
+	case SeverityCritical:
+		// Critical before the due date - only possible with a custom SeverityFunc
+		if daysUntil >= 0 {
+			return fmt.Sprintf("🚨🚨🚨 CRITICAL: Provider %s - Payment required! Payment due date: %s (%d day(s) left)", providerName, dateStr, daysUntil)
+		}
+
+		// Payment overdue - escalate the longer it stays unpaid
+		tiers := f.OverdueTiers
+		if len(tiers) == 0 {
+			tiers = DefaultOverdueTiers
+		}
+		maxOverdueDays := f.MaxOverdueDays
+		if maxOverdueDays <= 0 {
+			maxOverdueDays = DefaultMaxOverdueDays
+		}
+		tier := overdueTier(tiers, -daysUntil)
+		return fmt.Sprintf("%s: Provider %s - Payment overdue! Payment date was %s (%s days ago). %s", tier.Label, providerName, dateStr, clampDays(-daysUntil, maxOverdueDays), tier.Action)
+	case SeverityWarning:
+		// 0-2 days left - urgent warning
+		return fmt.Sprintf("🚨 WARNING: Provider %s - Urgent payment required! Payment due date: %s (%d day(s) left)", providerName, dateStr, daysUntil)
+	case SeverityAttention:
+		// 3-5 days left - attention
+		return fmt.Sprintf("⚠️ ATTENTION: Provider %s - Payment due soon! Payment date: %s (%d days left)", providerName, dateStr, daysUntil)
+	default:
+		// More than 5 days left - informational
+		return fmt.Sprintf("ℹ️ INFO: Provider %s - Next payment date: %s (%d days left)", providerName, dateStr, daysUntil)
+	}
+}
@@ -0,0 +1,86 @@
+package neverforgetvps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// ticketFormatter formats payment messages with the ticket tracking each provider
+type ticketFormatter struct {
+	tickets map[string]string
+}
+
+func (f ticketFormatter) Format(status ProviderStatus) string {
+	return fmt.Sprintf("%s due %s [%s] in %d days, ticket %s", status.Provider, status.displayDate(), status.Severity, status.DaysUntil, f.tickets[status.Provider])
+}
+
+// groupTicketFormatter is a ticketFormatter also formatting grouped payments
+type groupTicketFormatter struct {
+	ticketFormatter
+}
+
+func (f groupTicketFormatter) FormatGroup(statuses []ProviderStatus) string {
+	names := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		names = append(names, status.Provider)
+	}
+	return fmt.Sprintf("%s all due %s", strings.Join(names, "+"), statuses[0].displayDate())
+}
+
+func TestCustomFormatter(t *testing.T) {
+	clock := newTestClock()
+	failing := newStubProvider("cloudflare", nil)
+	failing.set(nil, errors.New("boom"))
+	config := Config{
+		Formatter: ticketFormatter{tickets: map[string]string{"vdsina": "OPS-1", "oneprovider": "OPS-2"}},
+		Labels:    map[string]string{"vdsina": "prod"},
+	}
+	m, sent := newTestMonitor(t, config, clock,
+		newStubProvider("vdsina", daysFrom(clock.Now(), 4)),
+		newStubProvider("oneprovider", daysFrom(clock.Now(), 20)),
+		newStubProvider("timeweb", nil),
+		failing)
+
+	m.CheckNow(context.Background(), 0)
+
+	// Labels are still added; failures keep the built-in error message
+	want := []string{
+		"Error checking payment date for provider cloudflare: boom",
+		"oneprovider due 2026-11-05 [info] in 20 days, ticket OPS-2",
+		"[prod] vdsina due 2026-10-20 [attention] in 4 days, ticket OPS-1",
+	}
+	if texts := sent.texts(); !slices.Equal(texts, want) {
+		t.Errorf("messages %q, want %q", texts, want)
+	}
+}
+
+func TestCustomGroupFormatter(t *testing.T) {
+	tests := []struct {
+		name      string
+		formatter Formatter
+		want      string
+	}{
+		{name: "group formatter", formatter: groupTicketFormatter{}, want: "oneprovider+vdsina all due 2026-10-20"},
+		// Without FormatGroup merged messages keep the built-in wording
+		{name: "plain formatter", formatter: ticketFormatter{}, want: "⚠️ ATTENTION: Providers oneprovider & vdsina - Payments due soon! Both due 2026-10-20 (4 days left)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newTestClock()
+			m, sent := newTestMonitor(t, Config{Formatter: tt.formatter, GroupSameDayPayments: true}, clock,
+				newStubProvider("vdsina", daysFrom(clock.Now(), 4)),
+				newStubProvider("oneprovider", daysFrom(clock.Now(), 4)))
+
+			m.CheckNow(context.Background(), 0)
+
+			if texts := sent.texts(); len(texts) != 1 || texts[0] != tt.want {
+				t.Errorf("messages %q, want %q", texts, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package neverforgetvps
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// paymentGroupKey identifies payment messages that are merged into one
+type paymentGroupKey struct {
+	date     string
+	severity Severity
+}
+
+// sendGroupedPayments sends payment messages, merging providers due on the same date with the same severity
+// Messages must have passed notification filters already; groups keep the order of their first message
+func (m *vpsMonitor[T]) sendGroupedPayments(messages []pendingMessage) {
+	var keys []paymentGroupKey
+	groups := make(map[paymentGroupKey][]pendingMessage)
+	for _, message := range messages {
+		key := paymentGroupKey{date: message.status.displayDate(), severity: message.status.Severity}
+		if _, found := groups[key]; !found {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], message)
+	}
+
+	for _, key := range keys {
+		group := groups[key]
+		var delivered bool
+		if len(group) == 1 {
+			delivered = m.sendMessage(statusMessage(group[0].status, group[0].text))
+		} else {
+			delivered = m.sendMessage(monitorMessage(key.severity, m.formatGroupedPaymentMessage(group)))
+		}
+		if delivered {
+			for _, message := range group {
+				m.messageDelivered(message)
+			}
+		}
+	}
+}
+
+// formatGroupedPaymentMessage formats a single message for providers due on the same date with the same severity,
+// e.g. "⚠️ ATTENTION: Providers vdsina & oneprovider - Payments due soon! Both due 2025-06-01 (3 days left)"
+// A Formatter implementing GroupFormatter replaces the wording
+func (m *vpsMonitor[T]) formatGroupedPaymentMessage(group []pendingMessage) string {
+	var text string
+	if formatter, ok := m.formatter.(GroupFormatter); ok {
+		statuses := make([]ProviderStatus, 0, len(group))
+		var labels []string
+		for _, message := range group {
+			statuses = append(statuses, message.status)
+			if label := m.label(message.status.Provider); label != "" && !slices.Contains(labels, label) {
+				labels = append(labels, label)
+			}
+		}
+		text = formatter.FormatGroup(statuses)
+		if len(labels) > 0 {
+			text = "[" + strings.Join(labels, ", ") + "] " + text
+		}
+	} else {
+		text = m.formatGroupedPaymentText(group)
+	}
+
+	// Pay links are listed per provider
+	for _, message := range group {
+		if url := m.payURL(message.status); url != "" {
+			text += fmt.Sprintf("\nPay %s: %s", message.status.Provider, url)
+		}
+	}
+
+	return text
+}
+
+// formatGroupedPaymentText words a merged payment message like EmojiFormatter, with labels next to provider names
+func (m *vpsMonitor[T]) formatGroupedPaymentText(group []pendingMessage) string {
+	first := group[0].status
+	daysUntil := first.DaysUntil
+	dateStr := first.displayDate()
+
+	names := make([]string, 0, len(group))
+	for _, message := range group {
+		name := message.status.Provider
+		if label := m.label(name); label != "" {
+			name += " [" + label + "]"
+		}
+		names = append(names, name)
+	}
+	providers := strings.Join(names, " & ")
+
+	all := "All"
+	if len(group) == 2 {
+		all = "Both"
+	}
+
+	switch {
+	case first.Severity == SeverityCritical && daysUntil >= 0:
+		// Critical before the due date - only possible with a custom SeverityFunc
+		return fmt.Sprintf("🚨🚨🚨 CRITICAL: Providers %s - Payments required! %s due %s (%d day(s) left)", providers, all, dateStr, daysUntil)
+	case first.Severity == SeverityCritical:
+		tier := overdueTier(m.overdueTiers, -daysUntil)
+		return fmt.Sprintf("%s: Providers %s - Payments overdue! %s were due %s (%s days ago). %s", tier.Label, providers, all, dateStr, clampDays(-daysUntil, m.maxOverdueDays), tier.Action)
+	case first.Severity == SeverityWarning:
+		return fmt.Sprintf("🚨 WARNING: Providers %s - Urgent payments required! %s due %s (%d day(s) left)", providers, all, dateStr, daysUntil)
+	case first.Severity == SeverityAttention:
+		return fmt.Sprintf("⚠️ ATTENTION: Providers %s - Payments due soon! %s due %s (%d days left)", providers, all, dateStr, daysUntil)
+	default:
+		return fmt.Sprintf("ℹ️ INFO: Providers %s - Next payment date: %s (%d days left)", providers, dateStr, daysUntil)
+	}
+}
@@ -0,0 +1,48 @@
+package neverforgetvps
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestGroupSameDayPayments(t *testing.T) {
+	tests := []struct {
+		name  string
+		group bool
+		want  []string
+	}{
+		{
+			name:  "grouped",
+			group: true,
+			want: []string{
+				"⚠️ ATTENTION: Providers oneprovider & vdsina - Payments due soon! Both due 2026-10-20 (4 days left)",
+				"⚠️ ATTENTION: Provider timeweb - Payment due soon! Payment date: 2026-10-21 (5 days left)",
+			},
+		},
+		{
+			name: "separate by default",
+			want: []string{
+				"⚠️ ATTENTION: Provider oneprovider - Payment due soon! Payment date: 2026-10-20 (4 days left)",
+				"⚠️ ATTENTION: Provider timeweb - Payment due soon! Payment date: 2026-10-21 (5 days left)",
+				"⚠️ ATTENTION: Provider vdsina - Payment due soon! Payment date: 2026-10-20 (4 days left)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newTestClock()
+			m, sent := newTestMonitor(t, Config{GroupSameDayPayments: tt.group}, clock,
+				newStubProvider("vdsina", daysFrom(clock.Now(), 4)),
+				newStubProvider("oneprovider", daysFrom(clock.Now(), 4)),
+				newStubProvider("timeweb", daysFrom(clock.Now(), 5)))
+
+			m.CheckNow(context.Background(), 0)
+
+			if texts := sent.texts(); !slices.Equal(texts, tt.want) {
+				t.Fatalf("messages %q, want %q", texts, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package neverforgetvps
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// ErrNotChecked is reported by Healthy for providers that have not been checked yet
+var ErrNotChecked = errors.New("provider has not been checked yet")
+
+// Healthy reports whether the last check of every enabled provider succeeded
+// The returned map contains errors of failed providers only and is empty when healthy
+// Providers that have not been checked yet are reported with ErrNotChecked
+func (m *vpsMonitor[T]) Healthy() (bool, map[string]error) {
+	entries := m.enabledProviders()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	failures := make(map[string]error)
+	for _, entry := range entries {
+		name := entry.Provider.GetName()
+		status, found := m.statuses[name]
+		switch {
+		case !found:
+			failures[name] = ErrNotChecked
+		case status.Outcome() == OutcomeFailed:
+			failures[name] = status.Err
+		}
+	}
+
+	return len(failures) == 0, failures
+}
+
+// LastError returns the error of the provider's most recent check and when it occurred
+// The error is cleared by the next successful check; false means the last check succeeded
+// or the provider was not checked yet
+func (m *vpsMonitor[T]) LastError(name string) (error, time.Time, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	status, found := m.statuses[name]
+	if !found || status.Outcome() != OutcomeFailed {
+		return nil, time.Time{}, false
+	}
+	return status.Err, status.CheckedAt, true
+}
+
+// EverSucceeded reports whether any check of the provider succeeded since the monitor was created
+// A provider that never succeeded usually has wrong credentials, while one that did has regressed
+func (m *vpsMonitor[T]) EverSucceeded(name string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, found := m.lastSuccess[name]
+	return found
+}
+
+// LastSuccessAge returns how long ago the last successful check of the provider ran
+// The age keeps growing while checks fail; a provider that never succeeded is reported
+// with the maximum duration, so it is older than any staleness limit
+func (m *vpsMonitor[T]) LastSuccessAge(name string) time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	last, found := m.lastSuccess[name]
+	if !found {
+		return time.Duration(math.MaxInt64)
+	}
+	return m.now().Sub(last)
+}
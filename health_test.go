@@ -0,0 +1,124 @@
+package neverforgetvps
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestHealthy(t *testing.T) {
+	clock := newTestClock()
+	vdsina := newStubProvider("vdsina", daysFrom(clock.Now(), 20))
+	oneprovider := newStubProvider("oneprovider", nil)
+	m, _ := newTestMonitor(t, Config{}, clock, vdsina, oneprovider)
+
+	healthy, failures := m.Healthy()
+	if healthy || failures["vdsina"] != ErrNotChecked || failures["oneprovider"] != ErrNotChecked {
+		t.Fatalf("before the first check: healthy %v, failures %v, want every provider not checked", healthy, failures)
+	}
+
+	m.CheckNow(context.Background(), 0)
+	if healthy, failures := m.Healthy(); !healthy || len(failures) != 0 {
+		t.Fatalf("all checks succeeded: healthy %v, failures %v, want healthy", healthy, failures)
+	}
+
+	oneprovider.set(nil, errors.New("boom"))
+	m.CheckNow(context.Background(), 0)
+	healthy, failures = m.Healthy()
+	if healthy || len(failures) != 1 || failures["oneprovider"] == nil {
+		t.Fatalf("oneprovider failed: healthy %v, failures %v, want only oneprovider failing", healthy, failures)
+	}
+}
+
+func TestLastError(t *testing.T) {
+	clock := newTestClock()
+	p := newStubProvider("vdsina", daysFrom(clock.Now(), 20))
+	m, _ := newTestMonitor(t, Config{}, clock, p)
+
+	if err, _, found := m.LastError("vdsina"); found || err != nil {
+		t.Fatalf("before the first check: %v, %v, want no error", err, found)
+	}
+
+	boom := errors.New("boom")
+	p.set(nil, boom)
+	m.CheckNow(context.Background(), 0)
+	err, at, found := m.LastError("vdsina")
+	if !found || !errors.Is(err, boom) || !at.Equal(clock.Now()) {
+		t.Fatalf("after a failure: %v at %v (found %v), want boom at %v", err, at, found, clock.Now())
+	}
+
+	clock.Advance(time.Hour)
+	p.set(daysFrom(clock.Now(), 20), nil)
+	m.CheckNow(context.Background(), 0)
+	if err, at, found := m.LastError("vdsina"); found || err != nil || !at.IsZero() {
+		t.Fatalf("after a success: %v at %v (found %v), want the error cleared", err, at, found)
+	}
+
+	if _, _, found := m.LastError("unknown"); found {
+		t.Error("unknown provider reported an error")
+	}
+}
+
+func TestEverSucceeded(t *testing.T) {
+	clock := newTestClock()
+	p := newStubProvider("vdsina", nil)
+	p.set(nil, errors.New("invalid token"))
+	m, _ := newTestMonitor(t, Config{}, clock, p)
+
+	if m.EverSucceeded("vdsina") {
+		t.Fatal("succeeded before the first check")
+	}
+	m.CheckNow(context.Background(), 0)
+	if m.EverSucceeded("vdsina") {
+		t.Fatal("succeeded after a failed check")
+	}
+
+	p.set(daysFrom(clock.Now(), 20), nil)
+	m.CheckNow(context.Background(), 0)
+	if !m.EverSucceeded("vdsina") {
+		t.Fatal("not succeeded after a successful check")
+	}
+
+	// A later failure is a regression, not a setup error
+	p.set(nil, errors.New("boom"))
+	m.CheckNow(context.Background(), 0)
+	if !m.EverSucceeded("vdsina") {
+		t.Error("success forgotten after a later failure")
+	}
+}
+
+func TestLastSuccessAge(t *testing.T) {
+	clock := newTestClock()
+	p := newStubProvider("vdsina", daysFrom(clock.Now(), 20))
+	m, _ := newTestMonitor(t, Config{}, clock, p)
+
+	if age := m.LastSuccessAge("vdsina"); age != time.Duration(math.MaxInt64) {
+		t.Fatalf("age %v before the first check, want the maximum duration", age)
+	}
+
+	m.CheckNow(context.Background(), 0)
+	if age := m.LastSuccessAge("vdsina"); age != 0 {
+		t.Fatalf("age %v right after a success, want 0", age)
+	}
+
+	// The age keeps growing while checks fail
+	p.set(nil, errors.New("boom"))
+	clock.Advance(6 * time.Hour)
+	m.CheckNow(context.Background(), 0)
+	if age := m.LastSuccessAge("vdsina"); age != 6*time.Hour {
+		t.Fatalf("age %v after a failure 6 hours later, want 6h", age)
+	}
+	clock.Advance(18 * time.Hour)
+	m.CheckNow(context.Background(), 0)
+	if age := m.LastSuccessAge("vdsina"); age != 24*time.Hour {
+		t.Fatalf("age %v after another failure, want 24h", age)
+	}
+
+	p.set(daysFrom(clock.Now(), 20), nil)
+	m.CheckNow(context.Background(), 0)
+	if age := m.LastSuccessAge("vdsina"); age != 0 {
+		t.Errorf("age %v after a new success, want 0", age)
+	}
+}
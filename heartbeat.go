@@ -0,0 +1,94 @@
+package neverforgetvps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// runHeartbeat periodically sends a heartbeat message until the monitor stops
+func (m *vpsMonitor[T]) runHeartbeat(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m.sendHeartbeat(m.ctx)
+		case <-m.ctx.Done():
+			return
+		}
+	}
+}
+
+// sendHeartbeat sends a summary of all providers with their next payment dates
+// Heartbeats bypass notification filters: their purpose is to show that the monitor is alive
+func (m *vpsMonitor[T]) sendHeartbeat(ctx context.Context) {
+	statuses, err := m.freshStatuses(ctx)
+	if err != nil {
+		return
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Provider < statuses[j].Provider
+	})
+
+	// A configured template replaces the built-in layout
+	if m.digestTemplate != nil {
+		if text, ok := m.renderDigest(statuses); ok {
+			m.sendMessage(monitorMessage(SeverityInfo, text))
+			return
+		}
+	}
+
+	var payments []string
+	for _, status := range statuses {
+		if status.Outcome() == OutcomePaymentDue {
+			payments = append(payments, fmt.Sprintf("%s %s", status.Provider, status.displayDate()))
+		}
+	}
+
+	text := "💓 Monitor is running: " + summarize(statuses)
+	if len(payments) > 0 {
+		text += ". Next payments: " + strings.Join(payments, ", ")
+	}
+	m.sendMessage(monitorMessage(SeverityInfo, text))
+}
+
+// sendStartSummary sends the state of every provider after the initial check
+// Like heartbeats, it bypasses notification filters
+func (m *vpsMonitor[T]) sendStartSummary() {
+	entries := m.enabledProviders()
+
+	m.mu.Lock()
+	statuses := make([]ProviderStatus, 0, len(entries))
+	for _, entry := range entries {
+		if status, found := m.statuses[entry.Provider.GetName()]; found {
+			statuses = append(statuses, status)
+		}
+	}
+	m.mu.Unlock()
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Provider < statuses[j].Provider
+	})
+
+	lines := []string{"🟢 Monitor started: " + summarize(statuses)}
+	for _, status := range statuses {
+		switch outcome := status.Outcome(); {
+		case outcome == OutcomeFailed:
+			lines = append(lines, fmt.Sprintf("%s: check failed (%v)", status.Provider, errors.Unwrap(status.Err)))
+		case outcome == OutcomePaymentDue && status.DaysUntil < 0:
+			lines = append(lines, fmt.Sprintf("%s: payment overdue since %s (%d days ago)", status.Provider, status.displayDate(), -status.DaysUntil))
+		case outcome == OutcomePaymentDue:
+			lines = append(lines, fmt.Sprintf("%s: next payment %s (%d days left)", status.Provider, status.displayDate(), status.DaysUntil))
+		default:
+			lines = append(lines, fmt.Sprintf("%s: no payment due", status.Provider))
+		}
+	}
+
+	m.sendMessage(monitorMessage(SeverityInfo, strings.Join(lines, "\n")))
+}
@@ -0,0 +1,123 @@
+package neverforgetvps
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestHeartbeat(t *testing.T) {
+	const interval = 100 * time.Millisecond
+
+	clock := newTestClock()
+	p := newStubProvider("vdsina", daysFrom(clock.Now(), 10))
+	// Filters that drop every payment message do not stop heartbeats
+	config := Config{CheckInterval: time.Hour, HeartbeatInterval: interval, MinNotifySeverity: SeverityCritical}
+	m, _ := newTestMonitor(t, config, clock, p)
+
+	var mu sync.Mutex
+	var heartbeats []time.Time
+	done := make(chan struct{})
+	m.sendFunc = func(message Message) error {
+		if !strings.HasPrefix(message.Text, "💓 Monitor is running") {
+			t.Errorf("message %q, want only heartbeats", message.Text)
+			return nil
+		}
+		if !strings.Contains(message.Text, "Next payments: vdsina 2026-10-26") {
+			t.Errorf("heartbeat %q, want the next payment of vdsina", message.Text)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		heartbeats = append(heartbeats, time.Now())
+		if len(heartbeats) == 3 {
+			close(done)
+		}
+		return nil
+	}
+
+	start := time.Now()
+	if err := m.Start(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("fewer than 3 heartbeats within 5 seconds")
+	}
+	m.Stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+	for i, sentAt := range heartbeats[:3] {
+		// Ticks may be late, but never early
+		if elapsed, due := sentAt.Sub(start), time.Duration(i+1)*interval; elapsed < due*9/10 {
+			t.Errorf("heartbeat %d sent %v after Start, want it after %v", i+1, elapsed, due)
+		}
+	}
+}
+
+func TestNotifyOnStart(t *testing.T) {
+	// startMonitor starts a monitor with a mix of provider states and returns the messages of the initial check
+	startMonitor := func(t *testing.T, notifyOnStart bool) []string {
+		clock := newTestClock()
+		failing := newStubProvider("yandexcloud", nil)
+		failing.set(nil, errors.New("boom"))
+		providers := []*stubProvider{
+			newStubProvider("cloudflare", nil),
+			newStubProvider("oneprovider", daysFrom(clock.Now(), 20)),
+			newStubProvider("vdsina", daysFrom(clock.Now(), 3)),
+			newStubProvider("timeweb", daysFrom(clock.Now(), -2)),
+			failing,
+		}
+		config := Config{CheckInterval: time.Hour, NotifyOnStart: notifyOnStart}
+		m, sent := newTestMonitor(t, config, clock, providers[0], providers[1], providers[2], providers[3], providers[4])
+
+		if err := m.Start(); err != nil {
+			t.Fatal(err)
+		}
+		// The initial check is complete once every provider was called
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			called := 0
+			for _, p := range providers {
+				called += min(p.callCount(), 1)
+			}
+			if called == len(providers) && (!notifyOnStart || countContaining(sent.texts(), "Monitor started") > 0) {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("initial check incomplete after 5 seconds, messages %q", sent.texts())
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		m.Stop()
+		return sent.texts()
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+		texts := startMonitor(t, true)
+		if n := countContaining(texts, "Monitor started"); n != 1 {
+			t.Fatalf("%d start summaries in %q, want 1", n, texts)
+		}
+
+		want := strings.Join([]string{
+			"🟢 Monitor started: 2 providers OK, 1 due in 3 day(s), 1 overdue, 1 failed",
+			"cloudflare: no payment due",
+			"oneprovider: next payment 2026-11-05 (20 days left)",
+			"timeweb: payment overdue since 2026-10-14 (2 days ago)",
+			"vdsina: next payment 2026-10-19 (3 days left)",
+			"yandexcloud: check failed (boom)",
+		}, "\n")
+		if summary := texts[len(texts)-1]; summary != want {
+			t.Errorf("start summary\n%s\nwant\n%s", summary, want)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		if texts := startMonitor(t, false); countContaining(texts, "Monitor started") != 0 {
+			t.Errorf("messages %q, want no start summary", texts)
+		}
+	})
+}
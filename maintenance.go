@@ -0,0 +1,64 @@
+package neverforgetvps
+
+import (
+	"fmt"
+	"time"
+)
+
+// EnterMaintenance suppresses all notifications, Critical ones included, until the given time
+// Checks keep running and statuses and metrics are still updated
+// A message is sent when maintenance starts and when it ends, either at the given time or with ExitMaintenance
+// Entering again while in maintenance moves the end of the window
+// A time in the past ends maintenance immediately
+func (m *vpsMonitor[T]) EnterMaintenance(until time.Time) {
+	if !until.After(m.now()) {
+		m.ExitMaintenance()
+		return
+	}
+
+	m.mu.Lock()
+	m.maintenanceUntil = until
+	m.mu.Unlock()
+
+	text := fmt.Sprintf("🛠️ Maintenance until %s: notifications are muted", until.In(m.timezone).Format("2006-01-02 15:04 MST"))
+	m.dispatchMessage(monitorMessage(SeverityInfo, text))
+}
+
+// ExitMaintenance ends maintenance started with EnterMaintenance, resuming notifications
+// Does nothing if the monitor is not in maintenance
+func (m *vpsMonitor[T]) ExitMaintenance() {
+	m.mu.Lock()
+	active := !m.maintenanceUntil.IsZero()
+	m.maintenanceUntil = time.Time{}
+	m.mu.Unlock()
+
+	if active {
+		m.dispatchMessage(monitorMessage(SeverityInfo, "✅ Maintenance ended: notifications resumed"))
+	}
+}
+
+// maintenanceActive checks whether a maintenance window mutes notifications right now, without ending an expired one
+// Notification state (throttling, backoff, deduplication) is left untouched during maintenance,
+// so messages muted by it are not treated as sent afterwards
+// Must be called with m.mu held
+func (m *vpsMonitor[T]) maintenanceActive() bool {
+	return !m.maintenanceUntil.IsZero() && m.now().Before(m.maintenanceUntil)
+}
+
+// inMaintenance checks whether notifications are muted by a maintenance window
+// An expired window ends maintenance with its message
+func (m *vpsMonitor[T]) inMaintenance() bool {
+	m.mu.Lock()
+	until := m.maintenanceUntil
+	m.mu.Unlock()
+
+	if until.IsZero() {
+		return false
+	}
+	if m.now().Before(until) {
+		return true
+	}
+
+	m.ExitMaintenance()
+	return false
+}
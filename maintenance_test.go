@@ -0,0 +1,68 @@
+package neverforgetvps
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMaintenance(t *testing.T) {
+	clock := newTestClock()
+	var results []string
+	config := Config{OnResult: func(status ProviderStatus) { results = append(results, status.Provider) }}
+	m, sent := newTestMonitor(t, config, clock,
+		newStubProvider("vdsina", daysFrom(clock.Now(), -1)),
+		newStubProvider("oneprovider", daysFrom(clock.Now(), 2)))
+
+	m.EnterMaintenance(clock.Now().Add(2 * time.Hour))
+	if texts := sent.texts(); !slices.Equal(texts, []string{"🛠️ Maintenance until 2026-10-16 14:00 UTC: notifications are muted"}) {
+		t.Fatalf("messages %q, want the maintenance start", texts)
+	}
+
+	// Nothing is sent during the window, Critical messages and heartbeats included, but checks still run
+	sent.reset()
+	m.CheckNow(context.Background(), 0)
+	m.sendHeartbeat(context.Background())
+	if texts := sent.texts(); len(texts) != 0 {
+		t.Fatalf("messages %q during maintenance, want none", texts)
+	}
+	if len(results) != 2 {
+		t.Errorf("results of %v during maintenance, want every provider checked", results)
+	}
+	var metrics strings.Builder
+	if err := m.WriteMetrics(&metrics); err != nil || !strings.Contains(metrics.String(), `neverforgetvps_days_until{provider="vdsina"} -1`) {
+		t.Errorf("metrics %s (%v), want the statuses updated during maintenance", metrics.String(), err)
+	}
+
+	m.ExitMaintenance()
+	m.CheckNow(context.Background(), 0)
+	texts := sent.texts()
+	if len(texts) != 3 || texts[0] != "✅ Maintenance ended: notifications resumed" || countContaining(texts, "CRITICAL") != 1 {
+		t.Errorf("messages %q after maintenance, want the end and both payment messages", texts)
+	}
+
+	// Exiting again sends nothing
+	sent.reset()
+	m.ExitMaintenance()
+	if texts := sent.texts(); len(texts) != 0 {
+		t.Errorf("messages %q, want none outside maintenance", texts)
+	}
+}
+
+func TestMaintenanceExpires(t *testing.T) {
+	clock := newTestClock()
+	m, sent := newTestMonitor(t, Config{}, clock, newStubProvider("vdsina", daysFrom(clock.Now(), -1)))
+
+	m.EnterMaintenance(clock.Now().Add(time.Hour))
+	m.CheckNow(context.Background(), 0)
+	sent.reset()
+
+	clock.Advance(time.Hour)
+	m.CheckNow(context.Background(), 0)
+	texts := sent.texts()
+	if len(texts) != 2 || texts[0] != "✅ Maintenance ended: notifications resumed" || !strings.Contains(texts[1], "CRITICAL") {
+		t.Errorf("messages %q once the window ended, want the end and the critical message", texts)
+	}
+}
@@ -0,0 +1,81 @@
+package neverforgetvps
+
+import (
+	"context"
+)
+
+// Priority is the delivery priority of a message, derived from its severity
+type Priority string
+
+const (
+	PriorityLow    Priority = "low"    // Informational messages
+	PriorityNormal Priority = "normal" // Payments due soon
+	PriorityHigh   Priority = "high"   // Urgent payments, failed checks and configuration warnings
+	PriorityUrgent Priority = "urgent" // Overdue payments
+)
+
+// PriorityForSeverity maps a severity to a message priority:
+// Info → low, Attention → normal, Warning → high, Critical → urgent
+func PriorityForSeverity(s Severity) Priority {
+	switch s {
+	case SeverityCritical:
+		return PriorityUrgent
+	case SeverityWarning:
+		return PriorityHigh
+	case SeverityAttention:
+		return PriorityNormal
+	default:
+		return PriorityLow
+	}
+}
+
+// Message is a notification together with metadata for routing it downstream
+type Message struct {
+	Text     string   // Message text
+	Provider string   // Provider the message is about, empty for monitor-wide messages (warnings, heartbeats, grouped payments)
+	Severity Severity // Severity of the reported situation
+	Priority Priority // Delivery priority derived from Severity
+}
+
+// NewVPSMonitorWithMessages creates a new instance of VPSMonitor whose converter receives
+// the message metadata (provider, severity, priority) in addition to the text
+// messageChan is required - panic if nil
+// messageConverter is a function that converts a Message to message type T
+func NewVPSMonitorWithMessages[T any](ctx context.Context, config Config, messageChan chan T, messageConverter func(Message) T) VPSMonitor {
+	if messageChan == nil {
+		panic("messageChan is required")
+	}
+
+	m := newVPSMonitor(ctx, config, messageConverter)
+	m.messageChan = messageChan
+	return m
+}
+
+// textConverter adapts a text-only converter to the Message converter used internally
+func textConverter[T any](convert func(string) T) func(Message) T {
+	if convert == nil {
+		return nil
+	}
+	return func(msg Message) T {
+		return convert(msg.Text)
+	}
+}
+
+// statusMessage builds a message about a provider status
+func statusMessage(status ProviderStatus, text string) Message {
+	return Message{
+		Text:     text,
+		Provider: status.Provider,
+		Severity: status.Severity,
+		Priority: PriorityForSeverity(status.Severity),
+	}
+}
+
+// monitorMessage builds a monitor-wide message not tied to a single provider
+func monitorMessage(severity Severity, text string) Message {
+	return Message{
+		Text:     text,
+		Severity: severity,
+		Priority: PriorityForSeverity(severity),
+	}
+}
@@ -0,0 +1,42 @@
+package neverforgetvps
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPriority(t *testing.T) {
+	tests := []struct {
+		name         string
+		days         int
+		wantSeverity Severity
+		want         Priority
+	}{
+		{name: "info", days: 20, wantSeverity: SeverityInfo, want: PriorityLow},
+		{name: "attention", days: 4, wantSeverity: SeverityAttention, want: PriorityNormal},
+		{name: "warning", days: 1, wantSeverity: SeverityWarning, want: PriorityHigh},
+		{name: "critical", days: -1, wantSeverity: SeverityCritical, want: PriorityUrgent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PriorityForSeverity(tt.wantSeverity); got != tt.want {
+				t.Errorf("PriorityForSeverity(%v) = %q, want %q", tt.wantSeverity, got, tt.want)
+			}
+
+			// The priority reaches the channel with the message
+			clock := newTestClock()
+			m, sent := newTestMonitor(t, Config{}, clock, newStubProvider("vdsina", daysFrom(clock.Now(), tt.days)))
+			m.CheckNow(context.Background(), 0)
+
+			sent.mu.Lock()
+			defer sent.mu.Unlock()
+			if len(sent.messages) != 1 {
+				t.Fatalf("%d messages, want 1", len(sent.messages))
+			}
+			if message := sent.messages[0]; message.Severity != tt.wantSeverity || message.Priority != tt.want {
+				t.Errorf("message with severity %v and priority %q, want %v and %q", message.Severity, message.Priority, tt.wantSeverity, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package neverforgetvps
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// WriteMetrics writes the recorded state of every enabled provider in the Prometheus text exposition format
+// The output is also valid OpenMetrics text. Providers not checked yet are omitted,
+// days_until is only written for providers with a payment date
+func (m *vpsMonitor[T]) WriteMetrics(w io.Writer) error {
+	entries := m.enabledProviders()
+
+	m.mu.Lock()
+	statuses := make([]ProviderStatus, 0, len(entries))
+	for _, entry := range entries {
+		if status, found := m.statuses[entry.Provider.GetName()]; found {
+			statuses = append(statuses, status)
+		}
+	}
+	m.mu.Unlock()
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Provider < statuses[j].Provider
+	})
+
+	var b strings.Builder
+
+	b.WriteString("# HELP neverforgetvps_up Whether the last check of the provider succeeded (1) or failed (0)\n")
+	b.WriteString("# TYPE neverforgetvps_up gauge\n")
+	for _, status := range statuses {
+		up := 1
+		if status.Outcome() == OutcomeFailed {
+			up = 0
+		}
+		fmt.Fprintf(&b, "neverforgetvps_up{provider=%s} %d\n", metricLabel(status.Provider), up)
+	}
+
+	b.WriteString("# HELP neverforgetvps_last_check_timestamp_seconds Unix time of the last check of the provider\n")
+	b.WriteString("# TYPE neverforgetvps_last_check_timestamp_seconds gauge\n")
+	for _, status := range statuses {
+		fmt.Fprintf(&b, "neverforgetvps_last_check_timestamp_seconds{provider=%s} %d\n", metricLabel(status.Provider), status.CheckedAt.Unix())
+	}
+
+	b.WriteString("# HELP neverforgetvps_days_until Days until the next payment date of the provider, negative when overdue\n")
+	b.WriteString("# TYPE neverforgetvps_days_until gauge\n")
+	for _, status := range statuses {
+		if status.Outcome() != OutcomePaymentDue {
+			continue
+		}
+		fmt.Fprintf(&b, "neverforgetvps_days_until{provider=%s} %d\n", metricLabel(status.Provider), status.DaysUntil)
+	}
+
+	b.WriteString("# EOF\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// metricLabel quotes a label value, escaping backslashes, quotes and newlines
+func metricLabel(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + replacer.Replace(value) + `"`
+}
@@ -0,0 +1,82 @@
+package neverforgetvps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestWriteMetrics(t *testing.T) {
+	clock := newTestClock()
+	failing := newStubProvider("cloudflare", nil)
+	failing.set(nil, errors.New("boom"))
+	m, _ := newTestMonitor(t, Config{}, clock,
+		newStubProvider("vdsina", daysFrom(clock.Now(), -2)),
+		newStubProvider("oneprovider", nil),
+		failing)
+
+	m.CheckNow(context.Background(), 0)
+	var b strings.Builder
+	if err := m.WriteMetrics(&b); err != nil {
+		t.Fatalf("WriteMetrics: %v", err)
+	}
+
+	checkedAt := clock.Now().Unix()
+	want := fmt.Sprintf(`# HELP neverforgetvps_up Whether the last check of the provider succeeded (1) or failed (0)
+# TYPE neverforgetvps_up gauge
+neverforgetvps_up{provider="cloudflare"} 0
+neverforgetvps_up{provider="oneprovider"} 1
+neverforgetvps_up{provider="vdsina"} 1
+# HELP neverforgetvps_last_check_timestamp_seconds Unix time of the last check of the provider
+# TYPE neverforgetvps_last_check_timestamp_seconds gauge
+neverforgetvps_last_check_timestamp_seconds{provider="cloudflare"} %[1]d
+neverforgetvps_last_check_timestamp_seconds{provider="oneprovider"} %[1]d
+neverforgetvps_last_check_timestamp_seconds{provider="vdsina"} %[1]d
+# HELP neverforgetvps_days_until Days until the next payment date of the provider, negative when overdue
+# TYPE neverforgetvps_days_until gauge
+neverforgetvps_days_until{provider="vdsina"} -2
+# EOF
+`, checkedAt)
+	if b.String() != want {
+		t.Errorf("metrics\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestWriteMetricsBeforeCheck(t *testing.T) {
+	clock := newTestClock()
+	m, _ := newTestMonitor(t, Config{}, clock, newStubProvider("vdsina", nil))
+
+	var b strings.Builder
+	if err := m.WriteMetrics(&b); err != nil {
+		t.Fatalf("WriteMetrics: %v", err)
+	}
+	// Only metric families and the terminating EOF, no samples
+	for _, line := range strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n") {
+		if !strings.HasPrefix(line, "# ") {
+			t.Errorf("sample %q before the first check, want none", line)
+		}
+	}
+	if !strings.HasSuffix(b.String(), "# EOF\n") {
+		t.Errorf("metrics %q, want them terminated by # EOF", b.String())
+	}
+}
+
+func TestMetricLabel(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "vdsina", want: `"vdsina"`},
+		{value: `say "hi"`, want: `"say \"hi\""`},
+		{value: `back\slash`, want: `"back\\slash"`},
+		{value: "two\nlines", want: `"two\nlines"`},
+	}
+
+	for _, tt := range tests {
+		if got := metricLabel(tt.value); got != tt.want {
+			t.Errorf("metricLabel(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
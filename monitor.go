@@ -2,12 +2,32 @@ package neverforgetvps
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
 	"time"
 
 	"github.com/custom-app/NeverForgetVPS/provider"
+	"github.com/custom-app/NeverForgetVPS/provider/buyvm"
+	"github.com/custom-app/NeverForgetVPS/provider/cloudflare"
+	"github.com/custom-app/NeverForgetVPS/provider/gcore"
+	"github.com/custom-app/NeverForgetVPS/provider/hostinger"
+	"github.com/custom-app/NeverForgetVPS/provider/kamatera"
+	"github.com/custom-app/NeverForgetVPS/provider/netcup"
 	"github.com/custom-app/NeverForgetVPS/provider/oneprovider"
+	"github.com/custom-app/NeverForgetVPS/provider/oraclecloud"
+	"github.com/custom-app/NeverForgetVPS/provider/regru"
+	"github.com/custom-app/NeverForgetVPS/provider/timeweb"
 	"github.com/custom-app/NeverForgetVPS/provider/vdsina"
+	"github.com/custom-app/NeverForgetVPS/provider/yandexcloud"
 )
 
 const (
@@ -18,31 +38,345 @@ const (
 // VPSMonitor defines the interface for VPS monitoring
 type VPSMonitor interface {
 	// Start starts VPS monitoring
+	// Returns an error if the configuration could not be applied, e.g. an unreadable credentials file
 	Start() error
 	// Stop stops the monitoring goroutine
 	Stop()
+	// Run starts monitoring and blocks until ctx is done, then stops the monitor
+	Run(ctx context.Context) error
+	// StopContext stops monitoring and waits for the background goroutines until ctx is done
+	StopContext(ctx context.Context) error
+	// CheckNow runs a check cycle immediately, reusing cached provider results if fresh
+	// A positive timeout replaces every provider's configured timeout for this call
+	CheckNow(ctx context.Context, timeout time.Duration)
+	// CheckProvider checks a single provider immediately without sending messages and returns its status
+	// A positive timeout replaces the provider's configured timeout for this call
+	CheckProvider(ctx context.Context, name string, timeout time.Duration) (ProviderStatus, error)
+	// ForceRefresh runs a check cycle immediately, bypassing the provider cache
+	ForceRefresh(ctx context.Context)
+	// Healthy reports whether the last check of every enabled provider succeeded
+	Healthy() (bool, map[string]error)
+	// Summary returns a one-line overview of all enabled providers
+	Summary(ctx context.Context) (string, error)
+	// Acknowledge silences notifications for a provider until the cooldown ends or severity worsens
+	Acknowledge(providerName string) error
+	// ProviderCapabilities returns the capabilities of every enabled provider keyed by provider name
+	ProviderCapabilities() map[string][]string
+	// PauseProviderUntil suppresses all notifications for a provider until the given time
+	PauseProviderUntil(name string, until time.Time) error
+	// EnterMaintenance suppresses all notifications until the given time while checks keep running
+	EnterMaintenance(until time.Time)
+	// ExitMaintenance ends maintenance early and resumes notifications
+	ExitMaintenance()
+	// Snapshot returns the persistable part of the monitor state
+	Snapshot() Snapshot
+	// RestoreSnapshot loads state saved with Snapshot
+	RestoreSnapshot(snapshot Snapshot)
+	// DebugFetch returns the raw response of a provider's primary API call (requires Config.EnableDebugFetch)
+	DebugFetch(ctx context.Context, providerName string) ([]byte, error)
+	// NextCheckTime returns when the next automatic check is scheduled, zero before Start
+	NextCheckTime() time.Time
+	// TimeUntilNextCheck returns the time left until the next automatic check, zero if none is scheduled
+	TimeUntilNextCheck() time.Duration
+	// UpcomingWithin returns all payments due within the window across providers, sorted by due date
+	UpcomingWithin(ctx context.Context, window time.Duration) ([]UpcomingPayment, error)
+	// FirstToDeplete returns the balance provider whose balance runs out first and its forecast date
+	FirstToDeplete(ctx context.Context) (string, *time.Time, error)
+	// RemoveProvider stops monitoring a provider and forgets its state
+	RemoveProvider(name string) error
+	// SetProviderEnabled temporarily stops or resumes checking a provider
+	SetProviderEnabled(name string, enabled bool) error
+	// Merge imports the providers and labels of another monitor with the same message type
+	Merge(other VPSMonitor) error
+	// RiskScore returns a 0-100 score summarizing how close any provider is to losing service
+	RiskScore(ctx context.Context) (int, error)
+	// LastError returns the error of the provider's most recent check and when it occurred, cleared on success
+	LastError(name string) (error, time.Time, bool)
+	// EverSucceeded reports whether any check of the provider succeeded since the monitor was created
+	EverSucceeded(name string) bool
+	// LastSuccessAge returns how long ago the last successful check of the provider ran
+	LastSuccessAge(name string) time.Duration
+	// ValidateCredentials checks every enabled provider once and returns the error of each, nil on success
+	ValidateCredentials(ctx context.Context) (map[string]error, error)
+	// WriteMetrics writes the recorded state of every enabled provider in the Prometheus text exposition format
+	WriteMetrics(w io.Writer) error
+	// EffectiveConfig returns the settings in effect after defaults were applied
+	EffectiveConfig() EffectiveConfig
+	// Simulate replays scripted provider states at scripted times and returns the messages that would be sent
+	Simulate(statuses []ProviderStatus, clock []time.Time) []string
+	// TotalOutstanding returns the amount owed across invoice-based providers in the target currency
+	TotalOutstanding(ctx context.Context, targetCurrency string) (provider.Money, error)
+	// AddProvider starts monitoring an additional provider at runtime
+	AddProvider(p provider.Provider, timeout time.Duration) error
+	// Subscribe returns a channel of provider status changes
+	Subscribe() <-chan ProviderStatusChange
+	// Unsubscribe closes a channel returned by Subscribe
+	Unsubscribe(ch <-chan ProviderStatusChange)
 }
 
 // vpsMonitor represents the main monitor for VPS providers
 // T is the type of messages sent to the channel
 type vpsMonitor[T any] struct {
-	// Providers are optional - if nil, they are not configured
-	Vdsina      provider.Provider
-	OneProvider provider.Provider
+	// Providers are optional - only providers with credentials are added
+	providersMu sync.RWMutex // Protects providers, labels and disabled, which can change at runtime
+	providers   []providerEntry
+	cacheTTL    time.Duration // Cache TTL applied to every added provider, 0 disables caching
 
-	ctx              context.Context
-	cancel           context.CancelFunc
-	checkInterval    time.Duration
-	messageChan      chan T         // Channel for sending messages to Telegram
-	messageConverter func(string) T // Function to convert text string to message type T
+	ctx                   context.Context
+	cancel                context.CancelFunc
+	checkInterval         time.Duration
+	schedule              *schedule                // Fixed check times, overrides checkInterval when set
+	intervalTiers         []IntervalTier           // Adaptive check intervals sorted by WithinDays, empty means a fixed interval
+	checkOffsets          map[string]time.Duration // Delay of each provider's automatic checks from the cycle start
+	messageChan           chan T                   // Channel for sending messages to Telegram
+	messageConverter      func(Message) T          // Function to convert a message to message type T
+	sendFunc              func(T) error            // Synchronous sender used instead of messageChan when set
+	sendRetries           int                      // Retries of a failed sendFunc call
+	sendRetryDelay        time.Duration            // Delay before the first sendFunc retry
+	checkRetries          int                      // Retries of a provider check failing with provider.ErrTransient
+	checkRetryDelay       time.Duration            // Delay between provider check retries
+	logger                *slog.Logger
+	onResult              func(ProviderStatus)
+	onStateChange         func(StateChange)
+	warnings              []string                                      // Configuration warnings sent when monitoring starts
+	startErr              error                                         // Configuration error returned by Start, e.g. an unreadable credentials file
+	overdueTiers          []OverdueTier                                 // Overdue escalation tiers sorted by MinDaysOverdue
+	maxOverdueDays        int                                           // Maximum overdue days displayed in messages
+	formatter             Formatter                                     // Formats payment messages, EmojiFormatter by default
+	dayRounding           DayRounding                                   // Conversion of the time until a payment to whole days
+	timezone              *time.Location                                // Time zone dates are displayed in
+	accountTimezones      bool                                          // Display dates in the time zone of the provider account when known
+	displayLocation       *time.Location                                // Zone every provider's dates are displayed in, nil to use timezone and accountTimezones
+	severityFunc          func(daysUntil int, provider string) Severity // Severity of a payment date, the built-in day buckets by default
+	balanceThresholds     map[string]float64                            // Low balance thresholds keyed by provider name
+	rateProvider          RateProvider                                  // Exchange rates for TotalOutstanding, nil if not configured
+	expectedDates         map[string]time.Time                          // Expected next payment dates keyed by provider name
+	expectedDateTolerance time.Duration                                 // Allowed difference from an expected date
+	labels                map[string]string                             // Message labels keyed by provider name, protected by providersMu
+	disabled              map[string]bool                               // Providers disabled with SetProviderEnabled, protected by providersMu
+	payURLs               map[string]string                             // Pay links keyed by provider name, defaults merged with Config.PayURLs
+	payURLAlways          bool                                          // Add pay links to every payment message, not only urgent ones
+	concurrentChecks      bool                                          // Check providers in parallel
+	maxConcurrentChecks   int                                           // Maximum provider checks in flight with concurrentChecks, 0 means unlimited
+	checkOrder            CheckOrder                                    // Order of providers in sequential cycles
+	orderMu               sync.Mutex                                    // Protects orderRand and rotation, cycles can overlap
+	orderRand             *rand.Rand                                    // Random source of CheckOrderShuffle
+	rotation              int                                           // Start offset of the next CheckOrderRotate cycle
+	strictProviders       bool                                          // Check unconfigured providers too, failing with ErrMissingCredentials
+	sendRequestID         bool                                          // Send request IDs to provider APIs
+	enableDebugFetch      bool                                          // Allow DebugFetch
+	eventWriter           io.Writer                                     // Destination of NDJSON events, nil disables them
+	eventMu               sync.Mutex                                    // Serializes event lines
+	severityRateLimits    map[Severity]int                              // Maximum messages per minute per severity, from SeverityRateLimits
+	now                   func() time.Time
+	ackCooldown           time.Duration      // How long an acknowledgement suppresses notifications
+	infoNotifyInterval    time.Duration      // Minimum time between Info-level messages per provider
+	repeatBackoff         time.Duration      // Delay before the first repeat of an unchanged notification, 0 disables backoff
+	repeatBackoffMax      time.Duration      // Longest delay between repeats of an unchanged notification
+	heartbeatInterval     time.Duration      // Interval between heartbeat messages, 0 disables them
+	watchdogMultiple      int                // Alert when no cycle completed within this many check intervals, 0 disables the watchdog
+	digestTemplate        *template.Template // Layout of heartbeat messages, nil means the built-in one
+	groupSameDayPayments  bool               // Merge payment messages of providers due on the same date
+	notifyNoPaymentDue    bool               // Send a message when a provider has no payment due
+	dedupeErrors          bool               // Report repeated errors of a provider once and notify on recovery
+	notifyOnRemove        bool               // Confirm removing, disabling and re-enabling providers
+	notifyOnStart         bool               // Send a start summary after the initial check
+	notifyOnValidation    bool               // Send a summary after ValidateCredentials
+	startOnce             sync.Once          // Guards the start summary
+	minNotifySeverity     Severity           // Minimum severity of messages sent to the channel
+	notifyProviders       map[string]bool    // Providers whose messages are sent, empty means every provider
+
+	loops sync.WaitGroup // Background goroutines started by Start
+
+	rateMu            sync.Mutex              // Protects the fields below
+	rateWindows       map[Severity]rateWindow // Current rate limit window per severity
+	criticalDelivered bool                    // A Critical message was delivered, so later ones may be rate limited
+
+	mu                      sync.Mutex                                                // Protects the fields below
+	stopped                 bool                                                      // Set by Stop
+	statuses                map[string]ProviderStatus                                 // Latest status per provider name
+	lastSuccess             map[string]time.Time                                      // Time of the last successful check per provider name
+	acks                    map[string]acknowledgement                                // Active acknowledgements per provider name
+	lastInfoSent            map[string]time.Time                                      // Time of the last Info-level message per provider name
+	pausedUntil             map[string]time.Time                                      // Notification pause end per provider name
+	maintenanceUntil        time.Time                                                 // End of the maintenance window muting all notifications, zero if none
+	errorCategories         map[string]ErrorCategory                                  // Category of the ongoing failure per provider name, with DedupeErrors
+	mismatches              map[string]time.Time                                      // Reported date of the ongoing mismatch with the expected date per provider name
+	repeats                 map[repeatKey]repeatState                                 // Backoff of repeated notifications per provider name and message kind
+	accountLocations        map[string]*time.Location                                 // Display time zones reported by provider accounts
+	accountLocationFailures map[string]time.Time                                      // When the account time zone lookup last failed per provider name
+	onboarding              map[string]bool                                           // Providers added at runtime awaiting their first successful check
+	nextCheck               time.Time                                                 // When the next automatic check is scheduled, zero before Start
+	lastCycleEnd            time.Time                                                 // When the last check cycle completed, used by the watchdog
+	watchdogFired           bool                                                      // The current stall was reported by the watchdog
+	subscribers             map[<-chan ProviderStatusChange]chan ProviderStatusChange // Status change subscribers
+	cycleSeq                uint64                                                    // Sequence number of the last started check cycle
+	runningCycles           map[uint64]bool                                           // Check cycles in progress
+	cycleNotified           map[notificationKey]uint64                                // Cycle that sent each notification while cycles were running
+}
+
+// providerEntry is a monitored provider together with its check timeout
+// Keeping both in one struct guarantees that a provider is always checked with its own timeout
+type providerEntry struct {
+	Provider provider.Provider
+	Timeout  time.Duration
 }
 
 // Config contains configuration for Monitor initialization
 type Config struct {
-	VdsinaAPIKey         string        // API key for VDSina (optional)
-	OneProviderAPIKey    string        // API key for OneProvider (optional)
-	OneProviderClientKey string        // Client key for OneProvider (optional)
-	CheckInterval        time.Duration // Interval for checking payment dates (optional, default: 1 hour)
+	VdsinaAPIKey                  string                                        // API key for VDSina (optional)
+	VdsinaAPIKeyFile              string                                        // File to read VdsinaAPIKey from instead, e.g. a Docker secret (optional)
+	OneProviderAPIKey             string                                        // API key for OneProvider (optional)
+	OneProviderAPIKeyFile         string                                        // File to read OneProviderAPIKey from instead, e.g. a Docker secret (optional)
+	OneProviderClientKey          string                                        // Client key for OneProvider (optional)
+	OneProviderClientKeyFile      string                                        // File to read OneProviderClientKey from instead, e.g. a Docker secret (optional)
+	CloudflareAPIKey              string                                        // API token for Cloudflare paid plans (optional)
+	CloudflareAPIKeyFile          string                                        // File to read CloudflareAPIKey from instead, e.g. a Docker secret (optional)
+	YandexCloudIAMToken           string                                        // IAM token for Yandex Cloud (optional)
+	YandexCloudIAMTokenFile       string                                        // File to read YandexCloudIAMToken from instead, e.g. a Docker secret (optional)
+	YandexCloudAccountID          string                                        // Billing account ID for Yandex Cloud (optional)
+	YandexCloudAccountIDFile      string                                        // File to read YandexCloudAccountID from instead, e.g. a Docker secret (optional)
+	TimewebAPIKey                 string                                        // API token for Timeweb Cloud (optional)
+	TimewebAPIKeyFile             string                                        // File to read TimewebAPIKey from instead, e.g. a Docker secret (optional)
+	BuyVMAPIKey                   string                                        // API key for BuyVM Stallion (optional)
+	BuyVMAPIKeyFile               string                                        // File to read BuyVMAPIKey from instead, e.g. a Docker secret (optional)
+	OracleCloudTenancyOCID        string                                        // Tenancy OCID for Oracle Cloud (optional)
+	OracleCloudTenancyOCIDFile    string                                        // File to read OracleCloudTenancyOCID from instead, e.g. a Docker secret (optional)
+	OracleCloudUserOCID           string                                        // User OCID for Oracle Cloud (optional)
+	OracleCloudUserOCIDFile       string                                        // File to read OracleCloudUserOCID from instead, e.g. a Docker secret (optional)
+	OracleCloudKeyFingerprint     string                                        // API signing key fingerprint for Oracle Cloud (optional)
+	OracleCloudKeyFingerprintFile string                                        // File to read OracleCloudKeyFingerprint from instead, e.g. a Docker secret (optional)
+	OracleCloudPrivateKey         string                                        // API signing private key in PEM format for Oracle Cloud (optional)
+	OracleCloudPrivateKeyFile     string                                        // File to read OracleCloudPrivateKey from instead, e.g. a Docker secret (optional)
+	OracleCloudRegion             string                                        // Region identifier for Oracle Cloud, e.g. "eu-frankfurt-1" (optional)
+	GcoreAPIKey                   string                                        // API token for Gcore (optional)
+	GcoreAPIKeyFile               string                                        // File to read GcoreAPIKey from instead, e.g. a Docker secret (optional)
+	NetcupCustomerNumber          string                                        // Customer number for Netcup (optional)
+	NetcupCustomerNumberFile      string                                        // File to read NetcupCustomerNumber from instead, e.g. a Docker secret (optional)
+	NetcupAPIKey                  string                                        // API key for Netcup (optional)
+	NetcupAPIKeyFile              string                                        // File to read NetcupAPIKey from instead, e.g. a Docker secret (optional)
+	NetcupAPIPassword             string                                        // API password for Netcup (optional)
+	NetcupAPIPasswordFile         string                                        // File to read NetcupAPIPassword from instead, e.g. a Docker secret (optional)
+	HostingerAPIKey               string                                        // API token for Hostinger (optional)
+	HostingerAPIKeyFile           string                                        // File to read HostingerAPIKey from instead, e.g. a Docker secret (optional)
+	RegRuUsername                 string                                        // Username for Reg.ru (optional)
+	RegRuUsernameFile             string                                        // File to read RegRuUsername from instead, e.g. a Docker secret (optional)
+	RegRuPassword                 string                                        // Password for Reg.ru (optional)
+	RegRuPasswordFile             string                                        // File to read RegRuPassword from instead, e.g. a Docker secret (optional)
+	KamateraClientID              string                                        // API client ID for Kamatera (optional)
+	KamateraClientIDFile          string                                        // File to read KamateraClientID from instead, e.g. a Docker secret (optional)
+	KamateraSecret                string                                        // API secret for Kamatera (optional)
+	KamateraSecretFile            string                                        // File to read KamateraSecret from instead, e.g. a Docker secret (optional)
+	CheckInterval                 time.Duration                                 // Interval for checking payment dates (optional, default: 1 hour)
+	CheckIntervalStr              string                                        // Check interval as a duration string, e.g. "6h" or "30m", used if CheckInterval is zero (optional)
+	Schedule                      *Schedule                                     // Fixed check times, used instead of CheckInterval when set (optional)
+	IntervalTiers                 []IntervalTier                                // Shorter check intervals as the nearest payment approaches, CheckInterval applies otherwise, ignored with Schedule (optional, e.g. DefaultIntervalTiers)
+	CacheTTL                      time.Duration                                 // How long successful provider results are reused (optional, default: no caching)
+	ConcurrentChecks              bool                                          // Check providers in parallel instead of one by one (optional)
+	MaxConcurrentChecks           int                                           // Maximum provider checks in flight with ConcurrentChecks (optional, default: unlimited, e.g. DefaultMaxConcurrentChecks)
+	ProviderTimeouts              map[string]time.Duration                      // Check timeout per built-in provider name, e.g. {"vdsina": time.Minute} (optional, default: DefaultProviderTimeout, 10 seconds more for VDSina and Timeweb)
+	CheckOrder                    CheckOrder                                    // Order of providers in sequential check cycles (optional, default: CheckOrderFixed)
+	OrderRand                     *rand.Rand                                    // Random source of CheckOrderShuffle, set a seeded one for reproducible orders (optional, default: randomly seeded)
+	StrictProviders               bool                                          // Report providers that become unconfigured at check time as failed checks instead of skipping them (optional)
+	SendRequestID                 bool                                          // Send the per-check request ID to provider APIs in the X-Request-ID header (optional, it is always logged)
+	EnableDebugFetch              bool                                          // Allow DebugFetch to return raw provider responses (optional, default: disabled)
+	OverdueTiers                  []OverdueTier                                 // Escalation tiers for overdue payments (optional, default: DefaultOverdueTiers)
+	AckCooldown                   time.Duration                                 // How long Acknowledge silences a provider (optional, default: 24 hours)
+	MaxOverdueDays                int                                           // Overdue days above this are displayed as "N+" (optional, default: 999)
+	DayRounding                   DayRounding                                   // How partial days until a payment are counted (optional, default: DayRoundingFloor)
+	Timezone                      *time.Location                                // Time zone dates are displayed and counted in; date-only provider dates keep their calendar day in it (optional, default: UTC)
+	AccountTimezones              bool                                          // Display dates in the time zone of the provider account (e.g. Moscow time for VDSina) when the provider reports one, Config.Timezone otherwise (optional)
+	DisplayLocation               *time.Location                                // Time zone every provider's payment dates are displayed and counted in, overriding Timezone and AccountTimezones for them; date-only dates keep their calendar day (optional)
+	InfoNotifyInterval            time.Duration                                 // Minimum time between Info-level messages per provider (optional, default: every check)
+	RepeatBackoff                 time.Duration                                 // Delay before repeating a notification of a provider whose severity did not change, doubled after every repeat (optional, default: disabled)
+	RepeatBackoffMax              time.Duration                                 // Longest delay between repeats with RepeatBackoff (optional, default: 24 hours)
+	MinNotifySeverity             Severity                                      // Minimum severity of messages sent to the channel (optional, default: SeverityInfo - everything)
+	NotifyProviders               []string                                      // Only these providers' messages reach the channel, all providers are still checked and recorded (optional, default: every provider)
+	SeverityFunc                  func(daysUntil int, provider string) Severity // Decides the severity of a payment date, replacing the built-in day buckets (optional)
+	NotifyNoPaymentDue            bool                                          // Send "Provider X: no payment due" on every check of a provider with nothing due (optional, default: suppressed)
+	DedupeErrors                  bool                                          // Report a provider error only when it first occurs or its category changes, and notify when the provider recovers (optional, default: every failed check)
+	NotifyOnRemove                bool                                          // Send a confirmation when a provider is removed, disabled or re-enabled at runtime (optional)
+	NotifyOnStart                 bool                                          // Send the current state of every provider once the initial check after Start completes (optional)
+	NotifyOnValidation            bool                                          // Send which providers passed and failed after ValidateCredentials (optional)
+	GroupSameDayPayments          bool                                          // Merge payment messages of providers due on the same date with the same severity into one message (optional)
+	HeartbeatInterval             time.Duration                                 // Interval between "monitor is alive" summary messages, sent regardless of notification filters (optional, default: disabled)
+	WatchdogMultiple              int                                           // Alert when no check cycle has completed within this many check intervals, e.g. 3; ignored with Schedule (optional, default: disabled)
+	DigestTemplate                string                                        // text/template for heartbeat messages executed with DigestData, e.g. "{{range .Providers}}{{.Name}}: {{.Date}}\n{{end}}" (optional, default: built-in layout)
+
+	// CheckOffsets delay the automatic checks of a provider from the start of each cycle, keyed by provider name (optional)
+	// They stagger API load, e.g. {"vdsina": 0, "oneprovider": 30 * time.Minute} with a 1 hour interval
+	// Offsets should be shorter than the check interval; CheckNow and ForceRefresh ignore them
+	CheckOffsets map[string]time.Duration
+
+	// Labels are prepended to every message of a provider as "[label] ", keyed by provider name (optional)
+	// Useful to tell environments or accounts apart in a shared channel, e.g. {"vdsina": "prod"}
+	Labels map[string]string
+
+	// PayURLs are billing page links appended to urgent payment messages, keyed by provider name (optional)
+	// They override DefaultPayURLs, an empty link disables the default one, e.g. {"vdsina": ""}
+	PayURLs map[string]string
+
+	// PayURLAlways adds the pay link to every payment message instead of Warning and Critical ones only (optional)
+	PayURLAlways bool
+
+	// BalanceThresholds trigger a low balance warning when a balance provider's balance
+	// drops below the amount (in the provider's currency), keyed by provider name (optional)
+	// For example: {"vdsina": 500}
+	BalanceThresholds map[string]float64
+
+	// ExpectedNextDates are the next payment dates the user expects, keyed by provider name (optional)
+	// A mismatch warning is sent when the reported date differs by more than ExpectedDateTolerance,
+	// once per mismatching date
+	// For example: {"vdsina": time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)}
+	ExpectedNextDates map[string]time.Time
+
+	// ExpectedDateTolerance is the allowed difference from an expected date (optional, default: 24 hours)
+	ExpectedDateTolerance time.Duration
+
+	// Transport is an HTTP transport shared by all built-in providers, e.g. with tuned MaxIdleConns
+	// and IdleConnTimeout for connection reuse (optional, default: a client per provider with the default transport)
+	Transport *http.Transport
+
+	// RateProvider converts amounts between currencies for TotalOutstanding (optional)
+	RateProvider RateProvider
+
+	// SeverityRateLimits limits the messages per minute reaching the channel per severity (optional, default: unlimited)
+	// Messages beyond the limit are dropped and counted in a "+N more suppressed" note on the next delivered message
+	// of that severity; the first Critical message is always delivered
+	SeverityRateLimits map[Severity]int
+
+	// EventWriter receives every notification and provider status change as a line of JSON (optional)
+	// Lines are written independently of the message channel; buffered writers are flushed after every line
+	// Messages dropped by SeverityRateLimits are written as "suppressed" events instead of notifications
+	EventWriter io.Writer
+
+	// Formatter formats payment messages, e.g. to add context from other systems (optional, default: EmojiFormatter
+	// with OverdueTiers and MaxOverdueDays)
+	// Messages merged by GroupPayments are formatted by it only if it implements GroupFormatter
+	Formatter Formatter
+
+	// ProviderOptions are passed to provider constructors, keyed by provider name (optional)
+	// For example: {"vdsina": {provider.WithLocation(moscow), provider.WithBaseURL("https://sandbox.example.com/v1")}}
+	ProviderOptions map[string][]provider.Option
+
+	// OnResult is called with the raw status of every provider on every check cycle (optional)
+	// It is called synchronously from the check goroutine for both successful and failed checks,
+	// before any message is sent, so it must return quickly and must not block
+	OnResult func(ProviderStatus)
+
+	// OnStateChange is called with a before/after record whenever a provider's severity, payment date
+	// or error state transitions between checks (optional)
+	// It is called synchronously from the check goroutine, so it must return quickly and must not block
+	OnStateChange func(StateChange)
+
+	// Logger receives diagnostic messages of the monitor (optional, default: discard)
+	Logger *slog.Logger
+
+	SendRetries    int           // Retries of a failed send function call (optional, default: 3, negative disables retries)
+	SendRetryDelay time.Duration // Delay before the first send retry, doubled on each attempt (optional, default: 1 second)
+
+	CheckRetries    int           // Retries of a provider check failing with provider.ErrTransient, permanent failures are never retried (optional, default: no retries)
+	CheckRetryDelay time.Duration // Delay between provider check retries (optional, default: 5 seconds)
 }
 
 // NewVPSMonitor creates a new instance of VPSMonitor
@@ -53,39 +387,246 @@ type Config struct {
 // T is the type of messages (e.g., domain.MessageToSend, string, etc.)
 // Returns VPSMonitor interface instead of concrete type
 func NewVPSMonitor[T any](ctx context.Context, config Config, messageChan chan T, messageConverter func(string) T) VPSMonitor {
-	m := &vpsMonitor[T]{}
-
 	if messageChan == nil {
 		panic("messageChan is required")
 	}
 
+	m := newVPSMonitor(ctx, config, textConverter(messageConverter))
+	m.messageChan = messageChan
+	return m
+}
+
+// newVPSMonitor creates a monitor without a message destination
+// Callers must set either messageChan or sendFunc
+func newVPSMonitor[T any](ctx context.Context, config Config, messageConverter func(Message) T) *vpsMonitor[T] {
+	m := &vpsMonitor[T]{
+		now: func() time.Time { return time.Now().UTC() },
+	}
+
 	if messageConverter == nil {
 		panic("messageConverter is required")
 	}
 
-	if (config.OneProviderAPIKey == "" || config.OneProviderClientKey == "") && config.VdsinaAPIKey == "" {
-		panic("OneProviderAPIKey and OneProviderClientKey or VdsinaAPIKey are required")
+	// Read credentials stored in files
+	// A missing secret is an environment problem rather than a programming error, so it is
+	// reported by Start instead of panicking; providers lacking the credential are not created
+	if err := resolveCredentialFiles(&config); err != nil {
+		m.startErr = fmt.Errorf("failed to read credentials file: %w", err)
 	}
 
+	// Detect a half-configured OneProvider, which is most likely a typo rather than intent
+	switch {
+	case config.OneProviderAPIKey != "" && config.OneProviderClientKey == "":
+		m.warnings = append(m.warnings, "OneProvider partially configured: missing ClientKey")
+	case config.OneProviderAPIKey == "" && config.OneProviderClientKey != "":
+		m.warnings = append(m.warnings, "OneProvider partially configured: missing APIKey")
+	}
+
+	// Providers are wrapped with a cache if requested
+	m.cacheTTL = config.CacheTTL
+
 	// Initialize providers only if credentials are provided
 	if config.VdsinaAPIKey != "" {
-		m.Vdsina = vdsina.New(config.VdsinaAPIKey)
+		m.addConfiguredProvider(mustProvider(vdsina.New(config.VdsinaAPIKey, providerOptions(config, "vdsina")...)), providerTimeout(config, "vdsina"))
 	}
 
 	if config.OneProviderAPIKey != "" && config.OneProviderClientKey != "" {
-		m.OneProvider = oneprovider.New(config.OneProviderAPIKey, config.OneProviderClientKey)
+		m.addConfiguredProvider(mustProvider(oneprovider.New(config.OneProviderAPIKey, config.OneProviderClientKey, providerOptions(config, "oneprovider")...)), providerTimeout(config, "oneprovider"))
+	}
+
+	if config.CloudflareAPIKey != "" {
+		m.addConfiguredProvider(mustProvider(cloudflare.New(config.CloudflareAPIKey, providerOptions(config, "cloudflare")...)), providerTimeout(config, "cloudflare"))
+	}
+
+	if config.YandexCloudIAMToken != "" && config.YandexCloudAccountID != "" {
+		m.addConfiguredProvider(mustProvider(yandexcloud.New(config.YandexCloudIAMToken, config.YandexCloudAccountID, providerOptions(config, "yandexcloud")...)), providerTimeout(config, "yandexcloud"))
+	}
+
+	if config.TimewebAPIKey != "" {
+		m.addConfiguredProvider(mustProvider(timeweb.New(config.TimewebAPIKey, providerOptions(config, "timeweb")...)), providerTimeout(config, "timeweb"))
+	}
+
+	if config.BuyVMAPIKey != "" {
+		m.addConfiguredProvider(mustProvider(buyvm.New(config.BuyVMAPIKey, providerOptions(config, "buyvm")...)), providerTimeout(config, "buyvm"))
+	}
+
+	if config.OracleCloudTenancyOCID != "" && config.OracleCloudUserOCID != "" && config.OracleCloudKeyFingerprint != "" && config.OracleCloudPrivateKey != "" && config.OracleCloudRegion != "" {
+		m.addConfiguredProvider(mustProvider(oraclecloud.New(config.OracleCloudTenancyOCID, config.OracleCloudUserOCID, config.OracleCloudKeyFingerprint, config.OracleCloudPrivateKey, config.OracleCloudRegion, providerOptions(config, "oraclecloud")...)), providerTimeout(config, "oraclecloud"))
+	}
+
+	if config.GcoreAPIKey != "" {
+		m.addConfiguredProvider(mustProvider(gcore.New(config.GcoreAPIKey, providerOptions(config, "gcore")...)), providerTimeout(config, "gcore"))
+	}
+
+	if config.NetcupCustomerNumber != "" && config.NetcupAPIKey != "" && config.NetcupAPIPassword != "" {
+		m.addConfiguredProvider(mustProvider(netcup.New(config.NetcupCustomerNumber, config.NetcupAPIKey, config.NetcupAPIPassword, providerOptions(config, "netcup")...)), providerTimeout(config, "netcup"))
+	}
+
+	if config.HostingerAPIKey != "" {
+		m.addConfiguredProvider(mustProvider(hostinger.New(config.HostingerAPIKey, providerOptions(config, "hostinger")...)), providerTimeout(config, "hostinger"))
+	}
+
+	if config.RegRuUsername != "" && config.RegRuPassword != "" {
+		m.addConfiguredProvider(mustProvider(regru.New(config.RegRuUsername, config.RegRuPassword, providerOptions(config, "regru")...)), providerTimeout(config, "regru"))
+	}
+
+	if config.KamateraClientID != "" && config.KamateraSecret != "" {
+		m.addConfiguredProvider(mustProvider(kamatera.New(config.KamateraClientID, config.KamateraSecret, providerOptions(config, "kamatera")...)), providerTimeout(config, "kamatera"))
+	}
+
+	if len(m.providers) == 0 {
+		const required = "OneProviderAPIKey and OneProviderClientKey, VdsinaAPIKey, CloudflareAPIKey, YandexCloudIAMToken and YandexCloudAccountID, TimewebAPIKey, BuyVMAPIKey, the OracleCloud credentials, GcoreAPIKey, the Netcup credentials, HostingerAPIKey, RegRuUsername and RegRuPassword or KamateraClientID and KamateraSecret are required"
+		if len(m.warnings) > 0 {
+			panic(fmt.Sprintf("%s (%s)", required, strings.Join(m.warnings, "; ")))
+		}
+		panic(required)
 	}
 
 	// Set check interval (default: 12 hours)
 	checkInterval := config.CheckInterval
+	if checkInterval == 0 && config.CheckIntervalStr != "" {
+		parsed, err := parseCheckInterval(config.CheckIntervalStr)
+		if err != nil {
+			panic(fmt.Sprintf("invalid CheckIntervalStr: %v", err))
+		}
+		checkInterval = parsed
+	}
 	if checkInterval == 0 {
 		checkInterval = DefaultCheckInterval
 	}
 	m.checkInterval = checkInterval
 
-	// Set message channel and converter function
-	m.messageChan = messageChan
+	// Validate check offsets if configured
+	if err := validateCheckOffsets(config.CheckOffsets); err != nil {
+		panic(fmt.Sprintf("invalid CheckOffsets: %v", err))
+	}
+	m.checkOffsets = config.CheckOffsets
+
+	// Parse adaptive interval tiers if configured
+	if len(config.IntervalTiers) > 0 {
+		tiers, err := sortIntervalTiers(config.IntervalTiers)
+		if err != nil {
+			panic(fmt.Sprintf("invalid IntervalTiers: %v", err))
+		}
+		m.intervalTiers = tiers
+	}
+
+	// Parse schedule if provided - it takes precedence over the check interval
+	if config.Schedule != nil {
+		parsed, err := parseSchedule(*config.Schedule)
+		if err != nil {
+			panic(fmt.Sprintf("invalid Schedule: %v", err))
+		}
+		m.schedule = parsed
+	}
+
+	// Set converter function
 	m.messageConverter = messageConverter
+	m.onResult = config.OnResult
+	m.onStateChange = config.OnStateChange
+	m.overdueTiers = sortOverdueTiers(config.OverdueTiers)
+	m.balanceThresholds = config.BalanceThresholds
+	m.rateProvider = config.RateProvider
+	m.expectedDates = config.ExpectedNextDates
+	m.expectedDateTolerance = config.ExpectedDateTolerance
+	if m.expectedDateTolerance <= 0 {
+		m.expectedDateTolerance = DefaultExpectedDateTolerance
+	}
+	m.labels = make(map[string]string, len(config.Labels))
+	for name, label := range config.Labels {
+		m.labels[name] = label
+	}
+	m.dayRounding = config.DayRounding
+	m.timezone = config.Timezone
+	if m.timezone == nil {
+		m.timezone = time.UTC
+	}
+	m.accountTimezones = config.AccountTimezones
+	m.displayLocation = config.DisplayLocation
+
+	// Set severity function (default: built-in day buckets)
+	m.severityFunc = config.SeverityFunc
+	if m.severityFunc == nil {
+		m.severityFunc = func(daysUntil int, _ string) Severity { return severityForDays(daysUntil) }
+	}
+	m.heartbeatInterval = config.HeartbeatInterval
+	m.watchdogMultiple = config.WatchdogMultiple
+	m.digestTemplate = mustDigestTemplate(config.DigestTemplate)
+	m.groupSameDayPayments = config.GroupSameDayPayments
+	m.notifyNoPaymentDue = config.NotifyNoPaymentDue
+	m.dedupeErrors = config.DedupeErrors
+	m.notifyOnRemove = config.NotifyOnRemove
+	m.notifyOnStart = config.NotifyOnStart
+	m.notifyOnValidation = config.NotifyOnValidation
+	m.payURLs = payURLs(config.PayURLs)
+	m.payURLAlways = config.PayURLAlways
+	m.concurrentChecks = config.ConcurrentChecks
+	if config.MaxConcurrentChecks < 0 {
+		panic(fmt.Sprintf("invalid MaxConcurrentChecks: %d", config.MaxConcurrentChecks))
+	}
+	m.maxConcurrentChecks = config.MaxConcurrentChecks
+	m.checkOrder = config.CheckOrder
+	m.orderRand = config.OrderRand
+	if m.orderRand == nil {
+		m.orderRand = defaultOrderRand()
+	}
+	m.strictProviders = config.StrictProviders
+	m.sendRequestID = config.SendRequestID
+	m.enableDebugFetch = config.EnableDebugFetch
+	m.eventWriter = config.EventWriter
+
+	// Validate per-severity rate limits
+	if err := validateSeverityRateLimits(config.SeverityRateLimits); err != nil {
+		panic(fmt.Sprintf("invalid SeverityRateLimits: %v", err))
+	}
+	m.severityRateLimits = config.SeverityRateLimits
+	m.rateWindows = make(map[Severity]rateWindow)
+
+	// Set maximum displayed overdue days (default: 999)
+	m.maxOverdueDays = config.MaxOverdueDays
+	if m.maxOverdueDays <= 0 {
+		m.maxOverdueDays = DefaultMaxOverdueDays
+	}
+
+	// Set payment message formatter (default: built-in emoji messages)
+	m.formatter = config.Formatter
+	if m.formatter == nil {
+		m.formatter = EmojiFormatter{OverdueTiers: m.overdueTiers, MaxOverdueDays: m.maxOverdueDays}
+	}
+
+	// Set acknowledgement cooldown (default: 24 hours)
+	m.ackCooldown = config.AckCooldown
+	if m.ackCooldown == 0 {
+		m.ackCooldown = DefaultAckCooldown
+	}
+	m.infoNotifyInterval = config.InfoNotifyInterval
+
+	// Set repeat backoff (default: disabled, capped at 24 hours)
+	m.repeatBackoff = config.RepeatBackoff
+	m.repeatBackoffMax = config.RepeatBackoffMax
+	if m.repeatBackoffMax <= 0 {
+		m.repeatBackoffMax = DefaultRepeatBackoffMax
+	}
+	m.repeatBackoffMax = max(m.repeatBackoffMax, m.repeatBackoff)
+	m.minNotifySeverity = config.MinNotifySeverity
+	if len(config.NotifyProviders) > 0 {
+		m.notifyProviders = make(map[string]bool, len(config.NotifyProviders))
+		for _, name := range config.NotifyProviders {
+			m.notifyProviders[name] = true
+		}
+	}
+
+	// Set logger (default: discard everything)
+	m.logger = config.Logger
+	if m.logger == nil {
+		m.logger = slog.New(slog.DiscardHandler)
+	}
+	m.sendRetries, m.sendRetryDelay = retrySettings(config)
+	m.checkRetries = max(config.CheckRetries, 0)
+	m.checkRetryDelay = config.CheckRetryDelay
+	if m.checkRetryDelay <= 0 {
+		m.checkRetryDelay = DefaultCheckRetryDelay
+	}
 
 	// Create cancel context from provided context
 	m.ctx, m.cancel = context.WithCancel(ctx)
@@ -93,11 +634,117 @@ func NewVPSMonitor[T any](ctx context.Context, config Config, messageChan chan T
 	return m
 }
 
+// parseCheckInterval parses a human-readable check interval such as "6h" or "30m"
+func parseCheckInterval(value string) (time.Duration, error) {
+	interval, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil {
+		return 0, err
+	}
+	if interval <= 0 {
+		return 0, fmt.Errorf("interval must be positive, got %s", value)
+	}
+	return interval, nil
+}
+
+// addProvider registers a provider with the timeout used for its checks
+// Returns ErrDuplicateProvider if the same account is already registered, and an error if the name is taken
+// Both are checked in the same critical section as the insert, so concurrent registrations cannot both succeed
+func (m *vpsMonitor[T]) addProvider(p provider.Provider, timeout time.Duration) error {
+	name := p.GetName()
+	fingerprint := provider.Fingerprint(p)
+	if m.cacheTTL > 0 {
+		p = provider.NewCached(p, m.cacheTTL)
+	}
+
+	m.providersMu.Lock()
+	defer m.providersMu.Unlock()
+
+	for _, entry := range m.providers {
+		if fingerprint != "" && provider.Fingerprint(entry.Provider) == fingerprint {
+			return fmt.Errorf("%s: %w", name, ErrDuplicateProvider)
+		}
+		if entry.Provider.GetName() == name {
+			return fmt.Errorf("provider %s is already monitored", name)
+		}
+	}
+	m.providers = append(m.providers, providerEntry{Provider: p, Timeout: timeout})
+	return nil
+}
+
+// addConfiguredProvider registers a provider created from Config during construction
+// A provider already registered for the same account is skipped with a warning instead of being polled twice
+func (m *vpsMonitor[T]) addConfiguredProvider(p provider.Provider, timeout time.Duration) {
+	if err := m.addProvider(p, timeout); err != nil {
+		m.warnings = append(m.warnings, fmt.Sprintf("Provider %s skipped: %v", p.GetName(), err))
+	}
+}
+
+// providerOptions returns the constructor options of a provider: the logger and the shared transport
+// if configured, followed by the provider's own options from Config.ProviderOptions
+func providerOptions(config Config, name string) []provider.Option {
+	var opts []provider.Option
+	if config.Logger != nil {
+		opts = append(opts, provider.WithLogger(config.Logger.With(slog.String("provider", name))))
+	}
+	if config.Transport != nil {
+		opts = append(opts, provider.WithTransport(config.Transport))
+	}
+	return append(opts, config.ProviderOptions[name]...)
+}
+
+// providerTimeout returns the check timeout of a built-in provider: its Config.ProviderTimeouts entry if set,
+// otherwise DefaultProviderTimeout, extended by slowProviderMargin for providers with slow billing APIs
+func providerTimeout(config Config, name string) time.Duration {
+	if timeout := config.ProviderTimeouts[name]; timeout > 0 {
+		return timeout
+	}
+	if name == "vdsina" || name == "timeweb" {
+		return DefaultProviderTimeout + slowProviderMargin
+	}
+	return DefaultProviderTimeout
+}
+
+// mustProvider returns the constructed provider or panics if construction failed
+func mustProvider(p provider.Provider, err error) provider.Provider {
+	if err != nil {
+		panic(fmt.Sprintf("failed to create provider: %v", err))
+	}
+	return p
+}
+
 // Start starts VPS monitoring
 // Starts a goroutine for periodic payment date checking
+// Returns an error without starting if a credentials file could not be read
 func (m *vpsMonitor[T]) Start() error {
+	if m.startErr != nil {
+		return m.startErr
+	}
+
 	// Start periodic checking goroutine
-	go m.runPaymentDateCheck(m.checkInterval)
+	m.loops.Add(1)
+	go func() {
+		defer m.loops.Done()
+		m.runPaymentDateCheck(m.checkInterval)
+	}()
+
+	// Start heartbeat goroutine if requested
+	if m.heartbeatInterval > 0 {
+		m.loops.Add(1)
+		go func() {
+			defer m.loops.Done()
+			m.runHeartbeat(m.heartbeatInterval)
+		}()
+	}
+
+	// Start watchdog goroutine if requested, the start counts as the first completed cycle
+	if m.watchdogMultiple > 0 && m.schedule == nil {
+		m.markCycleComplete()
+		m.loops.Add(1)
+		go func() {
+			defer m.loops.Done()
+			m.runWatchdog(time.Duration(m.watchdogMultiple) * m.checkInterval)
+		}()
+	}
 	return nil
 }
 
@@ -106,96 +753,613 @@ func (m *vpsMonitor[T]) Stop() {
 	if m.cancel != nil {
 		m.cancel()
 	}
+
+	m.mu.Lock()
+	m.stopped = true
+	m.nextCheck = time.Time{}
+	m.closeSubscribers()
+	m.mu.Unlock()
+}
+
+// StopContext stops monitoring and waits until the background goroutines have finished
+// or ctx is done, whichever comes first
+// A provider call ignoring cancellation can keep a goroutine running; StopContext then
+// returns ctx.Err() after logging a warning, leaving the goroutine behind
+func (m *vpsMonitor[T]) StopContext(ctx context.Context) error {
+	m.Stop()
+
+	done := make(chan struct{})
+	go func() {
+		m.loops.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		m.logger.Warn("monitor goroutines did not finish before the stop deadline", slog.Any("error", ctx.Err()))
+		return ctx.Err()
+	}
+}
+
+// DefaultShutdownTimeout is how long Run waits for the monitor goroutines to finish after stopping
+const DefaultShutdownTimeout = 30 * time.Second
+
+// Run starts monitoring, blocks until ctx is done (or the context the monitor was created with),
+// then stops the monitor and waits for its goroutines for at most DefaultShutdownTimeout
+// Returns an error if the monitor could not start or did not shut down in time
+func (m *vpsMonitor[T]) Run(ctx context.Context) error {
+	if err := m.Start(); err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-m.ctx.Done():
+	}
+
+	stopCtx, cancel := context.WithTimeout(context.Background(), DefaultShutdownTimeout)
+	defer cancel()
+	return m.StopContext(stopCtx)
 }
 
 // runPaymentDateCheck runs periodic checks of provider payment dates
 func (m *vpsMonitor[T]) runPaymentDateCheck(interval time.Duration) {
+	// Report configuration problems that did not prevent the monitor from starting
+	for _, warning := range m.warnings {
+		m.sendMessage(monitorMessage(SeverityWarning, "⚠️ "+warning))
+	}
+
+	if m.schedule != nil {
+		m.runScheduledCheck()
+		return
+	}
+
+	if len(m.intervalTiers) > 0 {
+		m.runAdaptiveCheck()
+		return
+	}
+
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
+	m.setNextCheck(m.now().Add(interval))
 
 	// Perform initial check immediately
-	m.checkPaymentDates()
+	m.automaticCheck()
 
 	// Then check periodically
 	for {
 		select {
-		case <-ticker.C:
-			m.checkPaymentDates()
+		case tick := <-ticker.C:
+			m.setNextCheck(tick.UTC().Add(interval))
+			m.automaticCheck()
 		case <-m.ctx.Done():
 			return
 		}
 	}
 }
 
-// checkPaymentDates checks payment dates for all configured providers
-func (m *vpsMonitor[T]) checkPaymentDates() {
-	providers := []provider.Provider{}
-	timeouts := []time.Duration{}
-	if m.Vdsina != nil && m.Vdsina.IsConfigured() {
-		providers = append(providers, m.Vdsina)
-		timeouts = append(timeouts, 40*time.Second)
+// runScheduledCheck runs checks at the times defined by the schedule
+func (m *vpsMonitor[T]) runScheduledCheck() {
+	// Perform initial check immediately
+	m.automaticCheck()
+
+	for {
+		// Recompute the next fire time after every check
+		now := m.now()
+		next := m.schedule.next(now)
+		m.setNextCheck(next)
+		timer := time.NewTimer(next.Sub(now))
+		select {
+		case <-timer.C:
+			m.automaticCheck()
+		case <-m.ctx.Done():
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// CheckNow runs a check cycle immediately and returns when it is complete
+// Cached provider results are reused if they are still fresh
+// A positive timeout replaces every provider's configured timeout for this call only
+func (m *vpsMonitor[T]) CheckNow(ctx context.Context, timeout time.Duration) {
+	m.checkPaymentDates(withCheckTimeout(ctx, timeout))
+}
+
+// ForceRefresh runs a check cycle immediately, bypassing the provider cache
+func (m *vpsMonitor[T]) ForceRefresh(ctx context.Context) {
+	m.checkPaymentDates(provider.WithForceRefresh(ctx))
+}
+
+// enabledProviders returns configured providers with their check timeouts
+// Disabled providers are skipped; in strict mode unconfigured providers are returned too, so their checks fail visibly
+func (m *vpsMonitor[T]) enabledProviders() []providerEntry {
+	m.providersMu.RLock()
+	defer m.providersMu.RUnlock()
+
+	entries := make([]providerEntry, 0, len(m.providers))
+	for _, entry := range m.providers {
+		if entry.Provider == nil {
+			continue
+		}
+		// A provider without a name cannot be checked, reported or disabled, so it is skipped
+		name, ok := safeProviderName(entry.Provider)
+		if !ok || m.disabled[name] {
+			continue
+		}
+		if m.strictProviders || safeIsConfigured(entry.Provider) {
+			entries = append(entries, entry)
+		}
 	}
-	if m.OneProvider != nil && m.OneProvider.IsConfigured() {
-		providers = append(providers, m.OneProvider)
-		timeouts = append(timeouts, 30*time.Second)
+	return entries
+}
+
+// safeIsConfigured returns whether the provider is configured
+// A panicking IsConfigured counts as configured, so the check runs and the panic fails it visibly
+func safeIsConfigured(p provider.Provider) (configured bool) {
+	defer func() {
+		if recover() != nil {
+			configured = true
+		}
+	}()
+	return p.IsConfigured()
+}
+
+// ProviderCapabilities returns the capabilities of every enabled provider keyed by provider name
+func (m *vpsMonitor[T]) ProviderCapabilities() map[string][]string {
+	capabilities := make(map[string][]string)
+	for _, entry := range m.enabledProviders() {
+		capabilities[entry.Provider.GetName()] = provider.Capabilities(entry.Provider)
 	}
+	return capabilities
+}
 
-	for i, p := range providers {
-		ctx, cancel := context.WithTimeout(m.ctx, timeouts[i])
-		defer cancel()
+// checkResult is the outcome of checking a single provider within a cycle
+type checkResult struct {
+	status   ProviderStatus
+	messages []pendingMessage // Messages to send, in order
+}
 
-		nextDate, err := p.GetNextPaymentDate(ctx)
-		if err != nil {
-			if m.messageChan != nil {
-				m.sendMessage(fmt.Sprintf("Error checking payment date for provider %s: %v", p.GetName(), err))
+// pendingMessage is a message waiting to be sent together with the status it describes
+type pendingMessage struct {
+	status ProviderStatus
+	text   string
+	kind   messageKind
+}
+
+// messageKind tells how a pending message is delivered
+type messageKind int
+
+const (
+	messagePayment      messageKind = iota // Payment date message, filtered by notify and optionally grouped by date
+	messageConfirmation                    // Sent regardless of notification filters (confirmations of user actions)
+	messageError                           // Failed payment date check, filtered by notify
+	messageRecovery                        // Payment date check succeeding again after failures, filtered by notify
+	messageNoPaymentDue                    // Provider without a payment due, filtered by notify
+	messageBalance                         // Low balance or balance check failure, filtered by notify
+	messageExpected                        // Mismatch with the expected payment date, filtered by notify
+	messageSuspension                      // Suspended service, filtered by notify
+)
+
+// checkPaymentDates checks payment dates for all configured providers
+// Overlapping calls (e.g. CheckNow during a scheduled check) each run their own cycle with their own
+// context, so ForceRefresh always bypasses the cache and CheckNow never waits for a staggered cycle
+// A notification already sent by an overlapping cycle is not sent again (see isOverlapDuplicate)
+func (m *vpsMonitor[T]) checkPaymentDates(ctx context.Context) {
+	cycle := m.beginCycle()
+	defer m.endCycle(cycle)
+
+	m.runCheckCycle(ctx, cycle)
+}
+
+// runCheckCycle performs a single check cycle over all enabled providers
+// Results are collected first and then reported sorted by provider name,
+// so the message order is stable regardless of check order or concurrency
+func (m *vpsMonitor[T]) runCheckCycle(ctx context.Context, cycle *checkCycle) {
+	entries := m.enabledProviders()
+	results := make([]checkResult, len(entries))
+	cycleStart := m.now()
+
+	// End an expired maintenance window, so its message is sent even if the cycle sends nothing
+	m.inMaintenance()
+
+	if m.concurrentChecks {
+		// Bound the number of checks in flight, a nil semaphore means unlimited
+		var slots chan struct{}
+		if m.maxConcurrentChecks > 0 {
+			slots = make(chan struct{}, m.maxConcurrentChecks)
+		}
+
+		var wg sync.WaitGroup
+		for i, entry := range entries {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				m.waitForOffset(ctx, entry.Provider.GetName(), cycleStart)
+				if slots != nil {
+					slots <- struct{}{}
+					defer func() { <-slots }()
+				}
+				results[i] = m.safeEvaluateProvider(ctx, entry)
+			}()
+		}
+		wg.Wait()
+	} else {
+		m.orderEntries(entries)
+		m.staggerEntries(ctx, entries)
+		for i, entry := range entries {
+			m.waitForOffset(ctx, entry.Provider.GetName(), cycleStart)
+			results[i] = m.safeEvaluateProvider(ctx, entry)
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].status.Provider < results[j].status.Provider
+	})
+
+	var payments []pendingMessage
+	for _, result := range results {
+		// Report the raw result before any message filtering
+		if m.onResult != nil {
+			m.onResult(result.status)
+		}
+
+		// Send notifications via Telegram channel if configured
+		for _, message := range result.messages {
+			switch {
+			case message.kind == messageConfirmation:
+				if m.sendMessage(statusMessage(message.status, message.text)) {
+					m.messageDelivered(message)
+				}
+			case message.kind == messagePayment && m.groupSameDayPayments:
+				if m.shouldNotify(message, cycle) {
+					payments = append(payments, message)
+				}
+			default:
+				m.notify(message, cycle)
 			}
-			continue
 		}
+	}
+
+	// Payment messages held back for grouping are sent after all other messages
+	m.sendGroupedPayments(payments)
 
-		if nextDate != nil {
-			message := m.formatPaymentMessage(p.GetName(), *nextDate)
-			// Send notification via Telegram channel if configured
-			m.sendMessage(message)
+	m.markCycleComplete()
+}
+
+// safeEvaluateProvider calls evaluateProvider, recovering from panics of any provider method
+// (e.g. a panicking BalanceProvider or IsConfigured), which fail the provider's check instead of the cycle
+func (m *vpsMonitor[T]) safeEvaluateProvider(ctx context.Context, entry providerEntry) (result checkResult) {
+	defer func() {
+		if r := recover(); r != nil {
+			name, ok := safeProviderName(entry.Provider)
+			if !ok {
+				name = "unknown"
+			}
+			m.logger.Error("provider panicked",
+				slog.String("provider", name),
+				slog.Any("panic", r),
+				slog.String("stack", string(debug.Stack())))
 
-		} else {
-			m.sendMessage(fmt.Sprintf("Provider %s: no payment due", p.GetName()))
+			status := ProviderStatus{
+				Provider:  name,
+				CheckedAt: m.now(),
+				Err:       newProviderError(name, fmt.Errorf("provider panicked: %v", r)),
+				Severity:  SeverityWarning,
+			}
+			m.recordStatus(status)
+			result = checkResult{status: status, messages: m.statusMessages(status)}
+		}
+	}()
+
+	return m.evaluateProvider(ctx, entry)
+}
 
+// safeProviderName returns the provider name, or false if GetName panics
+func safeProviderName(p provider.Provider) (name string, ok bool) {
+	defer func() {
+		if recover() != nil {
+			name, ok = "", false
 		}
+	}()
+	return p.GetName(), true
+}
+
+// evaluateProvider checks a single provider and prepares the messages describing the result
+func (m *vpsMonitor[T]) evaluateProvider(ctx context.Context, entry providerEntry) checkResult {
+	status := m.checkProvider(ctx, entry.Provider, entry.Timeout)
+	m.recordStatus(status)
+
+	result := checkResult{status: status}
+
+	// Confirm that a provider added at runtime works once its first check succeeds
+	if status.Outcome() != OutcomeFailed && m.completeOnboarding(status.Provider) {
+		result.messages = append(result.messages, pendingMessage{status, onboardingMessage(status), messageConfirmation})
 	}
+
+	// Balance thresholds are independent of the payment date
+	if message, ok := m.checkBalance(ctx, entry); ok {
+		result.messages = append(result.messages, message)
+	}
+
+	// Suspension is reported separately from the payment date
+	if message, ok := m.checkSuspension(ctx, entry); ok {
+		result.messages = append(result.messages, message)
+	}
+
+	result.messages = append(result.messages, m.statusMessages(status)...)
+	m.tagLabel(status.Provider, result.messages)
+
+	return result
 }
 
-// formatPaymentMessage formats a payment notification message based on days until payment
-func (m *vpsMonitor[T]) formatPaymentMessage(providerName string, paymentDate time.Time) string {
-	now := time.Now().UTC()
-	daysUntil := int(paymentDate.Sub(now).Hours() / 24)
+// statusMessages prepares the messages derived from the status alone, without calling the provider
+func (m *vpsMonitor[T]) statusMessages(status ProviderStatus) []pendingMessage {
+	var messages []pendingMessage
 
-	dateStr := paymentDate.Format("2006-01-02")
+	// Reconcile the payment date with the date the user expects
+	if message, ok := m.checkExpectedDate(status); ok {
+		messages = append(messages, message)
+	}
 
-	switch {
-	case daysUntil < 0:
-		// Payment overdue - critical situation
-		return fmt.Sprintf("🚨🚨🚨 CRITICAL: Provider %s - Payment overdue! Payment date was %s (%d days ago). Urgent action required!", providerName, dateStr, -daysUntil)
-	case daysUntil <= 2:
-		// 0-2 days left - urgent warning
-		return fmt.Sprintf("🚨 WARNING: Provider %s - Urgent payment required! Payment due date: %s (%d day(s) left)", providerName, dateStr, daysUntil)
-	case daysUntil <= 5:
-		// 3-5 days left - attention
-		return fmt.Sprintf("⚠️ ATTENTION: Provider %s - Payment due soon! Payment date: %s (%d days left)", providerName, dateStr, daysUntil)
+	// Repeated errors of the same category are reported once, and their end is reported too
+	report, recovered := m.trackErrorState(status)
+	if recovered {
+		messages = append(messages, pendingMessage{status, fmt.Sprintf("✅ Provider %s recovered: payment date check succeeded again", status.Provider), messageRecovery})
+	}
+
+	switch status.Outcome() {
+	case OutcomeFailed:
+		if report {
+			messages = append(messages, pendingMessage{status, fmt.Sprintf("Error checking payment date for provider %s: %v", status.Provider, errors.Unwrap(status.Err)), messageError})
+		}
+	case OutcomePaymentDue:
+		messages = append(messages, pendingMessage{status, m.withPayURL(status, m.formatPaymentMessage(status)), messagePayment})
 	default:
-		// More than 5 days left - informational
-		return fmt.Sprintf("ℹ️ INFO: Provider %s - Next payment date: %s (%d days left)", providerName, dateStr, daysUntil)
+		if m.notifyNoPaymentDue {
+			messages = append(messages, pendingMessage{status, fmt.Sprintf("Provider %s: no payment due", status.Provider), messageNoPaymentDue})
+		}
+	}
+
+	return messages
+}
+
+// tagLabel prefixes every message with the provider label, e.g. "[prod] ℹ️ INFO: ..."
+func (m *vpsMonitor[T]) tagLabel(name string, messages []pendingMessage) {
+	label := m.label(name)
+	if label == "" {
+		return
+	}
+	for i := range messages {
+		messages[i].text = "[" + label + "] " + messages[i].text
 	}
 }
 
-// sendMessage sends a message to the channel using the converter function
-func (m *vpsMonitor[T]) sendMessage(text string) {
-	if m.messageChan == nil || m.messageConverter == nil {
+// notify sends a message about a provider status unless notifications for it are suppressed
+// Filtering only affects messages, statuses are recorded and reported to OnResult regardless
+func (m *vpsMonitor[T]) notify(message pendingMessage, cycle *checkCycle) {
+	if !m.shouldNotify(message, cycle) {
 		return
 	}
+	if m.sendMessage(statusMessage(message.status, message.text)) {
+		m.messageDelivered(message)
+	}
+}
+
+// messageDelivered records the state that depends on the user having received the message,
+// so a message dropped on the way is sent again by a later check
+func (m *vpsMonitor[T]) messageDelivered(message pendingMessage) {
+	switch message.kind {
+	case messageError, messageRecovery:
+		m.recordErrorState(message.status)
+	case messageExpected:
+		m.recordMismatch(message.status.Provider, *message.status.NextDate)
+	}
+}
+
+// shouldNotify decides whether a message about a provider status is sent
+// The whole decision, including recording throttling state, happens under a single lock,
+// so concurrent checks cannot both pass the same filter
+// cycle is the check cycle sending the message, nil outside check cycles
+func (m *vpsMonitor[T]) shouldNotify(message pendingMessage, cycle *checkCycle) bool {
+	status := message.status
+	if status.Severity < m.minNotifySeverity {
+		return false
+	}
+	if len(m.notifyProviders) > 0 && !m.notifyProviders[status.Provider] {
+		return false
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Maintenance is checked first, so a muted message leaves no throttling or backoff state behind
+	if m.maintenanceActive() {
+		return false
+	}
+	if m.isPaused(status.Provider) || m.isAcknowledged(status) || m.isOverlapDuplicate(cycle, message) ||
+		m.isInfoThrottled(status) || m.isRepeatBackedOff(status, message.kind) {
+		return false
+	}
+	m.recordOverlap(cycle, message)
+	return true
+}
+
+// checkProvider requests the next payment date from a single provider and builds its status
+func (m *vpsMonitor[T]) checkProvider(ctx context.Context, p provider.Provider, timeout time.Duration) ProviderStatus {
+	if override, ok := ctx.Value(checkTimeoutKey{}).(time.Duration); ok {
+		timeout = override
+	}
+	status := ProviderStatus{
+		Provider:  p.GetName(),
+		CheckedAt: m.now(),
+	}
+
+	// Tag the provider calls of this check for tracing
+	requestID := provider.NewRequestID()
+	ctx = provider.WithRequestID(ctx, requestID, m.sendRequestID)
+	m.logger.Debug("checking provider", slog.String("provider", status.Provider), slog.String("request_id", requestID))
+
+	var nextDate *time.Time
+	var candidates []time.Time
+	var account string
+	var err error
+	if p.IsConfigured() {
+		nextDate, candidates, account, err = m.fetchWithRetries(ctx, p, timeout)
+	} else {
+		// Only reachable in strict mode, e.g. after a failed credential rotation
+		err = fmt.Errorf("provider is no longer configured: %w", provider.ErrMissingCredentials)
+	}
+	if err != nil {
+		providerErr := newProviderError(status.Provider, err)
+		providerErr.RequestID = requestID
+		m.logger.Error("provider check failed",
+			slog.String("provider", providerErr.Provider),
+			slog.String("category", string(providerErr.Category)),
+			slog.String("request_id", requestID),
+			slog.Any("error", providerErr))
+		status.Err = providerErr
+		status.Severity = SeverityWarning
+		return status
+	}
+
+	locationCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	status.Location = m.displayLocationFor(locationCtx, p)
+	nextDate, candidates = normalizeDates(nextDate, candidates, status.Location)
+
+	if nextDate != nil {
+		status.NextDate = nextDate
+		status.CandidateDates = candidates
+		status.Account = account
+		status.DaysUntil = daysBetween(status.CheckedAt, *nextDate, m.dayRounding)
+		status.Severity = m.severityFunc(status.DaysUntil, status.Provider)
+	}
 
-	// Convert text to message type T using the converter function
-	msg := m.messageConverter(text)
+	return status
+}
+
+// safeFetchPaymentDates calls fetchPaymentDates, recovering from provider panics
+// A panicking provider fails its own check instead of stopping the checks of the others
+func (m *vpsMonitor[T]) safeFetchPaymentDates(ctx context.Context, p provider.Provider) (nextDate *time.Time, candidates []time.Time, account string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			m.logger.Error("provider panicked",
+				slog.String("provider", p.GetName()),
+				slog.Any("panic", r),
+				slog.String("stack", string(debug.Stack())))
+			nextDate, candidates, account = nil, nil, ""
+			err = fmt.Errorf("provider panicked: %v", r)
+		}
+	}()
+
+	return m.fetchPaymentDates(ctx, p)
+}
+
+// fetchPaymentDates requests the next payment date and, for providers supporting it, all candidate dates
+// and the account owning the next payment date
+func (m *vpsMonitor[T]) fetchPaymentDates(ctx context.Context, p provider.Provider) (*time.Time, []time.Time, string, error) {
+	// Wrappers always implement the optional interfaces, only use them when the wrapped provider does too
+	inner := provider.Unwrap(p)
+
+	if lister, ok := p.(provider.AccountDatesProvider); ok {
+		if _, supported := inner.(provider.AccountDatesProvider); supported {
+			accounts, err := lister.GetAccountDates(ctx)
+			if err != nil || len(accounts) == 0 {
+				return nil, nil, "", err
+			}
+			candidates := make([]time.Time, len(accounts))
+			for i, account := range accounts {
+				candidates[i] = account.Date
+			}
+			nextDate := candidates[0]
+			return &nextDate, candidates, accounts[0].Account, nil
+		}
+	}
+
+	if lister, ok := p.(provider.CandidateDatesProvider); ok {
+		if _, supported := inner.(provider.CandidateDatesProvider); supported {
+			candidates, err := lister.GetCandidateDates(ctx)
+			if err != nil || len(candidates) == 0 {
+				return nil, nil, "", err
+			}
+			nextDate := candidates[0]
+			return &nextDate, candidates, "", nil
+		}
+	}
+
+	nextDate, err := p.GetNextPaymentDate(ctx)
+	return nextDate, nil, "", err
+}
+
+// formatPaymentMessage formats a payment notification message with the configured formatter
+func (m *vpsMonitor[T]) formatPaymentMessage(status ProviderStatus) string {
+	// Every provider is rendered in DisplayLocation, whatever zone its status was built with
+	if m.displayLocation != nil && status.Location != m.displayLocation {
+		status.NextDate, status.CandidateDates = normalizeDates(status.NextDate, status.CandidateDates, m.displayLocation)
+		status.Location = m.displayLocation
+	}
+	return m.formatter.Format(status)
+}
+
+// convertMessage converts a message with the converter function, recovering from converter panics
+// A panicking converter drops the message instead of stopping the monitor
+func (m *vpsMonitor[T]) convertMessage(message Message) (msg T, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			m.logger.Error("message converter panicked, message dropped",
+				slog.String("provider", message.Provider),
+				slog.String("text", message.Text),
+				slog.Any("panic", r))
+			ok = false
+		}
+	}()
+
+	return m.messageConverter(message), true
+}
+
+// sendMessage sends a message to the channel or the send function using the converter function
+// Messages are dropped during maintenance
+// Returns whether the message was delivered
+func (m *vpsMonitor[T]) sendMessage(message Message) bool {
+	if m.inMaintenance() {
+		return false
+	}
+	return m.dispatchMessage(message)
+}
+
+// dispatchMessage sends a message regardless of maintenance
+// Returns whether the message was delivered; without a channel or send function it counts as delivered
+// once recorded in the event log
+func (m *vpsMonitor[T]) dispatchMessage(message Message) bool {
+	delivering := (m.messageChan != nil || m.sendFunc != nil) && m.messageConverter != nil
+
+	// Enforce per-severity rate limits on the channel before recording the message,
+	// so the event log shows what was actually delivered
+	if delivering && !m.allowRate(&message) {
+		m.writeEvent(m.notificationEvent(message, EventSuppressed))
+		return false
+	}
+	m.writeEvent(m.notificationEvent(message, EventNotification))
+
+	if !delivering {
+		return true
+	}
+
+	// Convert message to message type T using the converter function
+	msg, ok := m.convertMessage(message)
+	if !ok {
+		return false
+	}
+
+	// Deliver through the send function if configured
+	if m.sendFunc != nil {
+		return m.deliver(msg)
+	}
 
 	// Send the message to channel
 	m.messageChan <- msg
+	return true
 }
@@ -0,0 +1,896 @@
+package neverforgetvps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+// testNow is the fixed start time of test clocks
+var testNow = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+// testClock is a manually advanced clock for monitor tests
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// newTestClock returns a clock set to testNow
+func newTestClock() *testClock {
+	return &testClock{now: testNow}
+}
+
+// Now returns the current test time
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// stubProvider is a provider returning a scripted payment date or error
+type stubProvider struct {
+	name string
+
+	mu    sync.Mutex
+	date  *time.Time
+	err   error
+	calls int
+	hook  func(ctx context.Context) // Called on every check before returning, e.g. to block
+}
+
+// newStubProvider returns a provider reporting the payment date, nil for no payment due
+func newStubProvider(name string, date *time.Time) *stubProvider {
+	return &stubProvider{name: name, date: date}
+}
+
+func (p *stubProvider) GetName() string { return p.name }
+
+func (p *stubProvider) IsConfigured() bool { return true }
+
+func (p *stubProvider) GetNextPaymentDate(ctx context.Context) (*time.Time, error) {
+	p.mu.Lock()
+	p.calls++
+	date, err, hook := p.date, p.err, p.hook
+	p.mu.Unlock()
+
+	if hook != nil {
+		hook(ctx)
+	}
+	return date, err
+}
+
+// set replaces the scripted result
+func (p *stubProvider) set(date *time.Time, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.date, p.err = date, err
+}
+
+// callCount returns how often the provider was checked
+func (p *stubProvider) callCount() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.calls
+}
+
+// sentMessages records the messages delivered by a test monitor
+type sentMessages struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+func (s *sentMessages) send(message Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, message)
+	return nil
+}
+
+// texts returns the texts of the delivered messages in order
+func (s *sentMessages) texts() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	texts := make([]string, len(s.messages))
+	for i, message := range s.messages {
+		texts[i] = message.Text
+	}
+	return texts
+}
+
+// reset forgets the delivered messages
+func (s *sentMessages) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = nil
+}
+
+// newTestMonitor creates a monitor checking the given providers instead of built-in ones,
+// delivering messages synchronously to the returned recorder and reading time from clock
+func newTestMonitor(t *testing.T, config Config, clock *testClock, providers ...provider.Provider) (*vpsMonitor[Message], *sentMessages) {
+	t.Helper()
+
+	if len(providers) > 0 {
+		config.VdsinaAPIKey = "test"
+	}
+	m := newVPSMonitor(context.Background(), config, func(message Message) Message { return message })
+	sent := &sentMessages{}
+	m.sendFunc = sent.send
+	if clock != nil {
+		m.now = clock.Now
+	}
+	if len(providers) > 0 {
+		m.providers = nil
+		for _, p := range providers {
+			if err := m.addProvider(p, time.Second); err != nil {
+				t.Fatalf("addProvider(%s): %v", p.GetName(), err)
+			}
+		}
+	}
+	t.Cleanup(m.Stop)
+	return m, sent
+}
+
+// daysFrom returns the time the given number of days after t
+func daysFrom(t time.Time, days int) *time.Time {
+	date := t.AddDate(0, 0, days)
+	return &date
+}
+
+func TestOverlappingChecksNotifyOnce(t *testing.T) {
+	tests := []struct {
+		name       string
+		concurrent bool
+		want       int
+	}{
+		{name: "overlapping checks", concurrent: true, want: 1},
+		{name: "consecutive checks", concurrent: false, want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newTestClock()
+			p := newStubProvider("vdsina", daysFrom(clock.Now(), 2))
+			m, sent := newTestMonitor(t, Config{}, clock, p)
+
+			if !tt.concurrent {
+				m.CheckNow(context.Background(), 0)
+				m.CheckNow(context.Background(), 0)
+			} else {
+				// Both cycles reach the provider before either of them finishes
+				arrived := make(chan struct{}, 2)
+				release := make(chan struct{})
+				p.hook = func(context.Context) {
+					arrived <- struct{}{}
+					<-release
+				}
+
+				var wg sync.WaitGroup
+				for range 2 {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						m.CheckNow(context.Background(), 0)
+					}()
+				}
+				<-arrived
+				<-arrived
+				close(release)
+				wg.Wait()
+			}
+
+			if got := sent.texts(); len(got) != tt.want {
+				t.Fatalf("sent %d messages, want %d: %q", len(got), tt.want, got)
+			}
+		})
+	}
+}
+
+func TestOnResultOncePerProviderPerCycle(t *testing.T) {
+	clock := newTestClock()
+	due := newStubProvider("vdsina", daysFrom(clock.Now(), 5))
+	failing := newStubProvider("oneprovider", nil)
+	failing.set(nil, errors.New("boom"))
+
+	var mu sync.Mutex
+	var results []ProviderStatus
+	config := Config{
+		// Deduplicated errors must still reach OnResult
+		DedupeErrors: true,
+		OnResult: func(status ProviderStatus) {
+			mu.Lock()
+			defer mu.Unlock()
+			results = append(results, status)
+		},
+	}
+	m, _ := newTestMonitor(t, config, clock, due, failing)
+
+	const cycles = 2
+	for range cycles {
+		m.CheckNow(context.Background(), 0)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(results) != cycles*2 {
+		t.Fatalf("%d results, want one per provider per cycle: %+v", len(results), results)
+	}
+	calls := make(map[string]int)
+	for _, status := range results {
+		calls[status.Provider]++
+		if !status.CheckedAt.Equal(testNow) {
+			t.Errorf("%s checked at %v, want %v", status.Provider, status.CheckedAt, testNow)
+		}
+		switch status.Provider {
+		case "vdsina":
+			if status.Outcome() != OutcomePaymentDue || status.DaysUntil != 5 {
+				t.Errorf("vdsina: outcome %v, %d days, want a payment due in 5 days", status.Outcome(), status.DaysUntil)
+			}
+		case "oneprovider":
+			if status.Outcome() != OutcomeFailed || status.Severity != SeverityWarning {
+				t.Errorf("oneprovider: outcome %v, severity %v, want a failed check with a warning", status.Outcome(), status.Severity)
+			}
+		}
+	}
+	if calls["vdsina"] != cycles || calls["oneprovider"] != cycles {
+		t.Errorf("results per provider %v, want %d each", calls, cycles)
+	}
+}
+
+func TestOneProviderPartialConfiguration(t *testing.T) {
+	tests := []struct {
+		name      string
+		apiKey    string
+		clientKey string
+		want      string
+	}{
+		{name: "missing client key", apiKey: "key", want: "OneProvider partially configured: missing ClientKey"},
+		{name: "missing API key", clientKey: "client", want: "OneProvider partially configured: missing APIKey"},
+		{name: "fully configured", apiKey: "key", clientKey: "client"},
+		{name: "not configured"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := Config{VdsinaAPIKey: "test", OneProviderAPIKey: tt.apiKey, OneProviderClientKey: tt.clientKey}
+			m := newVPSMonitor(context.Background(), config, func(message Message) Message { return message })
+			defer m.Stop()
+
+			var want []string
+			if tt.want != "" {
+				want = []string{tt.want}
+			}
+			if !slices.Equal(m.warnings, want) {
+				t.Errorf("warnings %q, want %q", m.warnings, want)
+			}
+		})
+	}
+
+	t.Run("only provider", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), "missing ClientKey") {
+				t.Errorf("panic %v, want the partial configuration warning", r)
+			}
+		}()
+		newVPSMonitor(context.Background(), Config{OneProviderAPIKey: "key"}, func(message Message) Message { return message })
+	})
+}
+
+func TestProviderOptionsBaseURL(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		w.Write([]byte(`{"status":"ok","data":{"forecast":"2026-11-20","can":{"add_user":true,"add_service":true}}}`))
+	}))
+	defer server.Close()
+
+	config := Config{
+		VdsinaAPIKey:    "key",
+		ProviderOptions: map[string][]provider.Option{"vdsina": {provider.WithBaseURL(server.URL + "/v1")}},
+	}
+	m, _ := newTestMonitor(t, config, newTestClock())
+	m.CheckNow(context.Background(), 0)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !slices.Contains(paths, "/v1/account") {
+		t.Fatalf("requests to %q, want the account request sent to the custom base URL", paths)
+	}
+	if healthy, failures := m.Healthy(); !healthy {
+		t.Fatalf("check failed: %v, want the forecast read from the custom base URL", failures)
+	}
+}
+
+func TestProviderTimeouts(t *testing.T) {
+	config := Config{
+		VdsinaAPIKey:         "key",
+		OneProviderAPIKey:    "key",
+		OneProviderClientKey: "client",
+		CloudflareAPIKey:     "token",
+		ProviderTimeouts:     map[string]time.Duration{"oneprovider": 3 * time.Second},
+	}
+	m, _ := newTestMonitor(t, config, nil)
+
+	want := map[string]time.Duration{
+		"vdsina":      DefaultProviderTimeout + slowProviderMargin,
+		"oneprovider": 3 * time.Second,
+		"cloudflare":  DefaultProviderTimeout,
+	}
+	entries := m.enabledProviders()
+	if len(entries) != len(want) {
+		t.Fatalf("%d providers, want %d", len(entries), len(want))
+	}
+	for _, entry := range entries {
+		name := entry.Provider.GetName()
+		if entry.Timeout != want[name] {
+			t.Errorf("%s timeout %v, want %v", name, entry.Timeout, want[name])
+		}
+	}
+}
+
+func TestCheckUsesProviderTimeout(t *testing.T) {
+	fast := newStubProvider("vdsina", nil)
+	slow := newStubProvider("oneprovider", nil)
+	// newTestMonitor registers providers with a 1s timeout
+	m, _ := newTestMonitor(t, Config{}, nil, fast)
+	if err := m.addProvider(slow, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	deadlines := make(map[string]time.Duration)
+	var mu sync.Mutex
+	for _, p := range []*stubProvider{fast, slow} {
+		p.hook = func(ctx context.Context) {
+			deadline, _ := ctx.Deadline()
+			mu.Lock()
+			defer mu.Unlock()
+			deadlines[p.name] = time.Until(deadline)
+		}
+	}
+	m.CheckNow(context.Background(), 0)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if d := deadlines["vdsina"]; d <= 0 || d > time.Second {
+		t.Errorf("vdsina checked with %v left, want its 1s timeout", d)
+	}
+	if d := deadlines["oneprovider"]; d <= time.Second || d > time.Minute {
+		t.Errorf("oneprovider checked with %v left, want its 1m timeout", d)
+	}
+}
+
+func TestProviderCapabilities(t *testing.T) {
+	config := Config{
+		VdsinaAPIKey:         "key",
+		OneProviderAPIKey:    "key",
+		OneProviderClientKey: "client",
+		// Cached providers report the capabilities of the wrapped ones
+		CacheTTL: time.Hour,
+	}
+	m, _ := newTestMonitor(t, config, nil)
+
+	want := map[string][]string{
+		"vdsina": {
+			provider.CapabilityNextPaymentDate, provider.CapabilityBalance, provider.CapabilityRawFetch,
+			provider.CapabilityAccountLocation, provider.CapabilitySuspension,
+		},
+		"oneprovider": {
+			provider.CapabilityNextPaymentDate, provider.CapabilityCandidateDates, provider.CapabilityRawFetch,
+			provider.CapabilityAccountDates,
+		},
+	}
+	got := m.ProviderCapabilities()
+	if len(got) != len(want) {
+		t.Fatalf("capabilities %v, want %v", got, want)
+	}
+	for name, capabilities := range want {
+		if !slices.Equal(got[name], capabilities) {
+			t.Errorf("%s capabilities %q, want %q", name, got[name], capabilities)
+		}
+	}
+}
+
+func TestCheckIntervalStr(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "hours", config: Config{CheckIntervalStr: "6h"}, want: 6 * time.Hour},
+		{name: "compound with spaces", config: Config{CheckIntervalStr: " 1h30m "}, want: 90 * time.Minute},
+		{name: "CheckInterval takes precedence", config: Config{CheckInterval: time.Hour, CheckIntervalStr: "6h"}, want: time.Hour},
+		{name: "not a duration", config: Config{CheckIntervalStr: "daily"}, wantErr: true},
+		{name: "missing unit", config: Config{CheckIntervalStr: "30"}, wantErr: true},
+		{name: "negative", config: Config{CheckIntervalStr: "-1h"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); (r != nil) != tt.wantErr || (r != nil && !strings.Contains(fmt.Sprint(r), "invalid CheckIntervalStr")) {
+					t.Errorf("panic %v, want error %v", r, tt.wantErr)
+				}
+			}()
+			m, _ := newTestMonitor(t, tt.config, nil, newStubProvider("vdsina", nil))
+			if got := m.EffectiveConfig().CheckInterval; got != tt.want {
+				t.Errorf("check interval %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCandidateDates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var invoices string
+		if r.URL.Query().Get("status") == "Unpaid" {
+			invoices = `{"id":"1","status":"Unpaid","due_date":"2026-12-01","balance":"10.00"},` +
+				`{"id":"2","status":"Unpaid","due_date":"2026-10-25","balance":"5.00"},` +
+				`{"id":"3","status":"Unpaid","due_date":"2026-10-20","balance":"0.00"},` + // Paid despite its status
+				`{"id":"4","status":"Unpaid","due_date":"2026-11-10","balance":"7.50"}`
+		}
+		fmt.Fprintf(w, `{"result":"success","response":{"current_page":1,"total_pages":1,"invoices":[%s]}}`, invoices)
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	var statuses []ProviderStatus
+	config := Config{
+		OneProviderAPIKey:    "key",
+		OneProviderClientKey: "client",
+		ProviderOptions:      map[string][]provider.Option{"oneprovider": {provider.WithBaseURL(server.URL)}},
+		OnResult: func(status ProviderStatus) {
+			mu.Lock()
+			defer mu.Unlock()
+			statuses = append(statuses, status)
+		},
+	}
+	m, _ := newTestMonitor(t, config, newTestClock())
+	m.CheckNow(context.Background(), 0)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(statuses) != 1 || statuses[0].Err != nil || statuses[0].NextDate == nil {
+		t.Fatalf("results %+v, want one successful check", statuses)
+	}
+	var candidates []string
+	for _, date := range statuses[0].CandidateDates {
+		candidates = append(candidates, date.Format(time.DateOnly))
+	}
+	if want := []string{"2026-10-25", "2026-11-10", "2026-12-01"}; !slices.Equal(candidates, want) {
+		t.Errorf("candidate dates %q, want %q", candidates, want)
+	}
+	if got := statuses[0].NextDate.Format(time.DateOnly); got != "2026-10-25" {
+		t.Errorf("selected date %s, want the earliest candidate 2026-10-25", got)
+	}
+}
+
+func TestMinNotifySeverity(t *testing.T) {
+	tests := []struct {
+		name        string
+		minSeverity Severity
+		wantInfo    int
+	}{
+		{name: "default delivers everything", wantInfo: 1},
+		{name: "warning floor", minSeverity: SeverityWarning, wantInfo: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newTestClock()
+			relaxed := newStubProvider("oneprovider", daysFrom(clock.Now(), 20))
+			urgent := newStubProvider("vdsina", daysFrom(clock.Now(), 2))
+			var results []Severity
+			config := Config{
+				MinNotifySeverity: tt.minSeverity,
+				OnResult:          func(status ProviderStatus) { results = append(results, status.Severity) },
+			}
+			m, sent := newTestMonitor(t, config, clock, relaxed, urgent)
+
+			m.CheckNow(context.Background(), 0)
+
+			texts := sent.texts()
+			if countContaining(texts, "INFO") != tt.wantInfo || countContaining(texts, "WARNING") != 1 {
+				t.Fatalf("messages %q, want %d info messages and the warning", texts, tt.wantInfo)
+			}
+			if !slices.Equal(results, []Severity{SeverityInfo, SeverityWarning}) {
+				t.Errorf("results with severities %v, want OnResult to see every provider", results)
+			}
+		})
+	}
+}
+
+func TestLabels(t *testing.T) {
+	tests := []struct {
+		name       string
+		days       int
+		err        error
+		wantPrefix string
+	}{
+		{name: "info", days: 20, wantPrefix: "[prod] ℹ️ INFO: Provider vdsina"},
+		{name: "attention", days: 4, wantPrefix: "[prod] ⚠️ ATTENTION: Provider vdsina"},
+		{name: "warning", days: 1, wantPrefix: "[prod] 🚨 WARNING: Provider vdsina"},
+		{name: "critical", days: -2, wantPrefix: "[prod] 🚨🚨🚨 CRITICAL: Provider vdsina"},
+		{name: "error", err: errors.New("boom"), wantPrefix: "[prod] Error checking payment date for provider vdsina"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newTestClock()
+			labeled := newStubProvider("vdsina", nil)
+			labeled.set(daysFrom(clock.Now(), tt.days), tt.err)
+			unlabeled := newStubProvider("oneprovider", daysFrom(clock.Now(), tt.days))
+			m, sent := newTestMonitor(t, Config{Labels: map[string]string{"vdsina": "prod"}}, clock, labeled, unlabeled)
+
+			m.CheckNow(context.Background(), 0)
+
+			texts := sent.texts()
+			if len(texts) != 2 || !strings.HasPrefix(texts[1], tt.wantPrefix) {
+				t.Fatalf("messages %q, want vdsina's starting with %q", texts, tt.wantPrefix)
+			}
+			if strings.HasPrefix(texts[0], "[") {
+				t.Errorf("message %q of an unlabeled provider, want no prefix", texts[0])
+			}
+		})
+	}
+}
+
+func TestNotifyNoPaymentDue(t *testing.T) {
+	tests := []struct {
+		name   string
+		notify bool
+		want   []string
+	}{
+		{name: "suppressed by default"},
+		{name: "notified", notify: true, want: []string{"Provider vdsina: no payment due", "Provider vdsina: no payment due"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newTestClock()
+			m, sent := newTestMonitor(t, Config{NotifyNoPaymentDue: tt.notify}, clock, newStubProvider("vdsina", nil))
+
+			for range 2 {
+				m.CheckNow(context.Background(), 0)
+				clock.Advance(time.Hour)
+			}
+
+			if texts := sent.texts(); !slices.Equal(texts, tt.want) {
+				t.Fatalf("messages %q, want %q", texts, tt.want)
+			}
+		})
+	}
+}
+
+// revocableProvider is a stub provider whose configuration can be revoked, e.g. by a failed credential rotation
+type revocableProvider struct {
+	*stubProvider
+	revoked atomic.Bool
+}
+
+func (p *revocableProvider) IsConfigured() bool { return !p.revoked.Load() }
+
+func TestStrictProviders(t *testing.T) {
+	tests := []struct {
+		name   string
+		strict bool
+		want   []string
+	}{
+		{name: "skipped by default"},
+		{name: "strict", strict: true, want: []string{"Error checking payment date for provider vdsina: provider is no longer configured: missing credentials"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newTestClock()
+			p := &revocableProvider{stubProvider: newStubProvider("vdsina", daysFrom(clock.Now(), 20))}
+			m, sent := newTestMonitor(t, Config{StrictProviders: tt.strict}, clock, p)
+			m.CheckNow(context.Background(), 0)
+
+			p.revoked.Store(true)
+			sent.reset()
+			m.CheckNow(context.Background(), 0)
+
+			if texts := sent.texts(); !slices.Equal(texts, tt.want) {
+				t.Fatalf("messages %q, want %q", texts, tt.want)
+			}
+			if p.callCount() != 1 {
+				t.Errorf("unconfigured provider queried %d times, want only the first check", p.callCount())
+			}
+		})
+	}
+}
+
+func TestProviderPanic(t *testing.T) {
+	for _, concurrent := range []bool{false, true} {
+		t.Run(fmt.Sprintf("concurrent %v", concurrent), func(t *testing.T) {
+			clock := newTestClock()
+			panicking := newStubProvider("oneprovider", nil)
+			panicking.hook = func(context.Context) { panic("provider bug") }
+			healthy := newStubProvider("vdsina", daysFrom(clock.Now(), 2))
+			m, sent := newTestMonitor(t, Config{ConcurrentChecks: concurrent}, clock, panicking, healthy)
+
+			m.CheckNow(context.Background(), 0)
+
+			texts := sent.texts()
+			if countContaining(texts, "WARNING: Provider vdsina") != 1 {
+				t.Errorf("messages %q, want the warning of the healthy provider", texts)
+			}
+			if countContaining(texts, "Error checking payment date for provider oneprovider: provider panicked: provider bug") != 1 {
+				t.Errorf("messages %q, want an error notification for the panicking provider", texts)
+			}
+			if err, _, found := m.LastError("oneprovider"); !found || err == nil {
+				t.Errorf("last error of the panicking provider %v, want the panic", err)
+			}
+		})
+	}
+}
+
+func TestStopContext(t *testing.T) {
+	tests := []struct {
+		name    string
+		stuck   bool // The provider ignores cancellation
+		wantErr error
+	}{
+		{name: "goroutines finish", wantErr: nil},
+		{name: "provider ignores cancellation", stuck: true, wantErr: context.DeadlineExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newTestClock()
+			p := newStubProvider("vdsina", daysFrom(clock.Now(), 20))
+			checking := make(chan struct{}, 1)
+			release := make(chan struct{})
+			defer close(release)
+			p.hook = func(ctx context.Context) {
+				select {
+				case checking <- struct{}{}:
+				default:
+				}
+				if tt.stuck {
+					<-release
+					return
+				}
+				<-ctx.Done()
+			}
+
+			logs := &syncBuffer{}
+			config := Config{CheckInterval: time.Hour, Logger: slog.New(slog.NewTextHandler(logs, nil))}
+			m, _ := newTestMonitor(t, config, clock, p)
+			if err := m.Start(); err != nil {
+				t.Fatal(err)
+			}
+			select {
+			case <-checking:
+			case <-time.After(5 * time.Second):
+				t.Fatal("initial check did not start within 5 seconds")
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			start := time.Now()
+			err := m.StopContext(ctx)
+			if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+				t.Errorf("StopContext returned after %v, want it within the 100ms deadline", elapsed)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("StopContext: %v, want %v", err, tt.wantErr)
+			}
+			if warned := strings.Contains(logs.String(), "did not finish before the stop deadline"); warned != tt.stuck {
+				t.Errorf("logs %q, want a warning %v", logs.String(), tt.stuck)
+			}
+		})
+	}
+}
+
+func TestNotifyProviders(t *testing.T) {
+	clock := newTestClock()
+	listed := newStubProvider("vdsina", daysFrom(clock.Now(), 2))
+	unlisted := newStubProvider("oneprovider", daysFrom(clock.Now(), -1))
+	var results []string
+	config := Config{
+		NotifyProviders: []string{"vdsina"},
+		OnResult:        func(status ProviderStatus) { results = append(results, status.Provider) },
+	}
+	m, sent := newTestMonitor(t, config, clock, listed, unlisted)
+
+	m.CheckNow(context.Background(), 0)
+
+	texts := sent.texts()
+	if countContaining(texts, "vdsina") != 1 || countContaining(texts, "oneprovider") != 0 {
+		t.Fatalf("messages %q, want only the vdsina warning", texts)
+	}
+	// Monitoring still covers every provider
+	if !slices.Equal(results, []string{"oneprovider", "vdsina"}) && !slices.Equal(results, []string{"vdsina", "oneprovider"}) {
+		t.Errorf("results of %v, want every provider", results)
+	}
+	var metrics strings.Builder
+	if err := m.WriteMetrics(&metrics); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(metrics.String(), `neverforgetvps_days_until{provider="oneprovider"} -1`) {
+		t.Errorf("metrics %s, want the days until the unlisted provider's payment", metrics.String())
+	}
+}
+
+func TestRun(t *testing.T) {
+	p := newStubProvider("vdsina", nil)
+	checked := make(chan struct{}, 1)
+	p.hook = func(context.Context) {
+		select {
+		case checked <- struct{}{}:
+		default:
+		}
+	}
+	m, _ := newTestMonitor(t, Config{CheckInterval: time.Hour}, newTestClock(), p)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- m.Run(ctx) }()
+
+	select {
+	case <-checked:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no check within 5 seconds of Run")
+	}
+	select {
+	case err := <-done:
+		t.Fatalf("Run returned %v before the context was canceled", err)
+	default:
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return within 5 seconds of the cancellation")
+	}
+
+	// Every goroutine has finished once Run returns
+	finished := make(chan struct{})
+	go func() {
+		m.loops.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		t.Fatal("monitor goroutines still running after Run returned")
+	}
+}
+
+func TestRunStartError(t *testing.T) {
+	config := Config{VdsinaAPIKeyFile: filepath.Join(t.TempDir(), "missing"), TimewebAPIKey: "key"}
+	m, _ := newTestMonitor(t, config, nil)
+	if err := m.Run(context.Background()); err == nil {
+		t.Fatal("Run succeeded with an unreadable credentials file, want the Start error")
+	}
+}
+
+func TestSharedTransport(t *testing.T) {
+	transport, paths := cannedTransport(t, `{}`)
+	config := Config{
+		VdsinaAPIKey:         "key",
+		OneProviderAPIKey:    "key",
+		OneProviderClientKey: "client",
+		CloudflareAPIKey:     "key",
+		YandexCloudIAMToken:  "token",
+		YandexCloudAccountID: "acc",
+		TimewebAPIKey:        "key",
+		BuyVMAPIKey:          "key",
+		GcoreAPIKey:          "key",
+		HostingerAPIKey:      "key",
+		Transport:            transport,
+	}
+	m, _ := newTestMonitor(t, config, nil)
+
+	// The canned body fails most checks, only the requests matter
+	if _, err := m.ValidateCredentials(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// The first request of every provider, all sent through the injected transport
+	requested := paths()
+	for _, path := range []string{
+		"/v1/account",                     // VDSina
+		"/invoices",                       // OneProvider
+		"/client/v4/user/subscriptions",   // Cloudflare
+		"/billing/v1/billingAccounts/acc", // Yandex Cloud
+		"/api/v1/account/finances",        // Timeweb
+		"/api/v1/billing/invoices",        // BuyVM
+		"/billing/v1/balance",             // Gcore
+		"/api/billing/v1/subscriptions",   // Hostinger
+	} {
+		if !slices.Contains(requested, path) {
+			t.Errorf("no request to %s through the transport, got %q", path, requested)
+		}
+	}
+}
+
+func TestMaxConcurrentChecks(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit int
+		want  int // Expected peak of checks in flight
+	}{
+		{name: "limited", limit: 3, want: 3},
+		{name: "unlimited", limit: 0, want: 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var inFlight, peak atomic.Int32
+			// Each check waits until enough checks are in flight to reach the expected peak, or a timeout,
+			// so a missing limit shows up as a peak above it
+			reached := make(chan struct{})
+			var once sync.Once
+			hook := func(ctx context.Context) {
+				n := inFlight.Add(1)
+				defer inFlight.Add(-1)
+				for {
+					old := peak.Load()
+					if n <= old || peak.CompareAndSwap(old, n) {
+						break
+					}
+				}
+				if int(n) >= tt.want {
+					once.Do(func() { close(reached) })
+				}
+				select {
+				case <-reached:
+					time.Sleep(5 * time.Millisecond)
+				case <-time.After(200 * time.Millisecond):
+				}
+			}
+
+			clock := newTestClock()
+			var providers []provider.Provider
+			for i := range 20 {
+				p := newStubProvider(fmt.Sprintf("provider%02d", i), nil)
+				p.hook = hook
+				providers = append(providers, p)
+			}
+			m, _ := newTestMonitor(t, Config{ConcurrentChecks: true, MaxConcurrentChecks: tt.limit}, clock, providers...)
+
+			m.CheckNow(context.Background(), 0)
+
+			if got := int(peak.Load()); got != tt.want {
+				t.Errorf("peak of %d checks in flight, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInvalidMaxConcurrentChecks(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(r.(string), "invalid MaxConcurrentChecks") {
+			t.Errorf("panic %v, want an invalid MaxConcurrentChecks panic", r)
+		}
+	}()
+	newTestMonitor(t, Config{MaxConcurrentChecks: -1}, nil, newStubProvider("vdsina", nil))
+}
@@ -0,0 +1,44 @@
+package neverforgetvps
+
+import (
+	"math/rand/v2"
+)
+
+// CheckOrder selects the order providers are checked in during sequential check cycles
+type CheckOrder int
+
+const (
+	// CheckOrderFixed checks providers in registration order (default)
+	CheckOrderFixed CheckOrder = iota
+	// CheckOrderShuffle checks providers in a random order every cycle
+	CheckOrderShuffle
+	// CheckOrderRotate starts every cycle with the provider after the previous cycle's first one
+	CheckOrderRotate
+)
+
+// orderEntries reorders providers for a sequential cycle according to the check order
+func (m *vpsMonitor[T]) orderEntries(entries []providerEntry) {
+	if len(entries) < 2 {
+		return
+	}
+
+	m.orderMu.Lock()
+	defer m.orderMu.Unlock()
+
+	switch m.checkOrder {
+	case CheckOrderShuffle:
+		m.orderRand.Shuffle(len(entries), func(i, j int) {
+			entries[i], entries[j] = entries[j], entries[i]
+		})
+	case CheckOrderRotate:
+		start := m.rotation % len(entries)
+		m.rotation++
+		rotated := append(append([]providerEntry(nil), entries[start:]...), entries[:start]...)
+		copy(entries, rotated)
+	}
+}
+
+// defaultOrderRand returns a randomly seeded source for CheckOrderShuffle
+func defaultOrderRand() *rand.Rand {
+	return rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+}
@@ -0,0 +1,183 @@
+package neverforgetvps
+
+import (
+	"context"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// orderRecorder collects the order providers are checked in
+type orderRecorder struct {
+	mu    sync.Mutex
+	names []string
+}
+
+// providers returns stub providers recording their checks
+func (r *orderRecorder) providers(names ...string) []*stubProvider {
+	providers := make([]*stubProvider, len(names))
+	for i, name := range names {
+		providers[i] = newStubProvider(name, nil)
+		providers[i].hook = func(context.Context) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.names = append(r.names, name)
+		}
+	}
+	return providers
+}
+
+// take returns the recorded order and starts over
+func (r *orderRecorder) take() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	order := strings.Join(r.names, ",")
+	r.names = nil
+	return order
+}
+
+// checkOrders runs sequential cycles and returns the provider order of each
+func checkOrders(t *testing.T, config Config, cycles int) []string {
+	t.Helper()
+
+	recorder := &orderRecorder{}
+	stubs := recorder.providers("a", "b", "c", "d")
+	m, _ := newTestMonitor(t, config, newTestClock(), stubs[0], stubs[1], stubs[2], stubs[3])
+
+	orders := make([]string, cycles)
+	for i := range orders {
+		m.CheckNow(context.Background(), 0)
+		orders[i] = recorder.take()
+	}
+	return orders
+}
+
+func TestCheckOrder(t *testing.T) {
+	t.Run("fixed", func(t *testing.T) {
+		for _, order := range checkOrders(t, Config{}, 3) {
+			if order != "a,b,c,d" {
+				t.Fatalf("order %s, want registration order", order)
+			}
+		}
+	})
+
+	t.Run("rotate", func(t *testing.T) {
+		got := checkOrders(t, Config{CheckOrder: CheckOrderRotate}, 5)
+		want := []string{"a,b,c,d", "b,c,d,a", "c,d,a,b", "d,a,b,c", "a,b,c,d"}
+		if !slices.Equal(got, want) {
+			t.Fatalf("orders %q, want %q", got, want)
+		}
+	})
+
+	t.Run("shuffle", func(t *testing.T) {
+		seeded := func() Config {
+			return Config{CheckOrder: CheckOrderShuffle, OrderRand: rand.New(rand.NewPCG(1, 2))}
+		}
+		first := checkOrders(t, seeded(), 10)
+		second := checkOrders(t, seeded(), 10)
+		if !slices.Equal(first, second) {
+			t.Fatalf("orders differ with the same seed: %q and %q", first, second)
+		}
+
+		distinct := slices.Compact(slices.Sorted(slices.Values(first)))
+		if len(distinct) < 2 {
+			t.Fatalf("order never varied across cycles: %q", first)
+		}
+		for _, order := range first {
+			names := strings.Split(order, ",")
+			slices.Sort(names)
+			if !slices.Equal(names, []string{"a", "b", "c", "d"}) {
+				t.Fatalf("cycle checked %s, want every provider once", order)
+			}
+		}
+	})
+}
+
+func TestCheckOrderOverlappingCycles(t *testing.T) {
+	for _, order := range []CheckOrder{CheckOrderShuffle, CheckOrderRotate} {
+		const cycles = 8
+		recorder := &orderRecorder{}
+		stubs := recorder.providers("a", "b", "c")
+		// The real clock: the test clock's lock would order the cycles for the race detector
+		m, _ := newTestMonitor(t, Config{CheckOrder: order}, nil, stubs[0], stubs[1], stubs[2])
+
+		// Hold every cycle at its first check until all cycles are running, so they all order
+		// their providers concurrently; run with -race to catch unguarded shared state
+		var mu sync.Mutex
+		arrived := 0
+		allRunning := make(chan struct{})
+		for _, stub := range stubs {
+			record := stub.hook
+			stub.hook = func(ctx context.Context) {
+				record(ctx)
+				mu.Lock()
+				arrived++
+				if arrived == cycles {
+					close(allRunning)
+				}
+				mu.Unlock()
+				<-allRunning
+			}
+		}
+
+		var wg sync.WaitGroup
+		for range cycles {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				m.CheckNow(context.Background(), 0)
+			}()
+		}
+		wg.Wait()
+
+		if got := len(strings.Split(recorder.take(), ",")); got != cycles*3 {
+			t.Fatalf("order %d: %d checks, want %d", order, got, cycles*3)
+		}
+	}
+}
+
+func TestMessageOrder(t *testing.T) {
+	// messageOrder runs a check and returns the providers in the order of their messages
+	messageOrder := func(config Config) string {
+		clock := newTestClock()
+		names := []string{"zeta", "alpha", "mid"}
+		stubs := make([]*stubProvider, len(names))
+		for i, name := range names {
+			stubs[i] = newStubProvider(name, daysFrom(clock.Now(), i+1))
+			// Providers registered first finish last in concurrent checks
+			delay := time.Duration(len(names)-i) * time.Millisecond
+			stubs[i].hook = func(context.Context) { time.Sleep(delay) }
+		}
+		m, sent := newTestMonitor(t, config, clock, stubs[0], stubs[1], stubs[2])
+
+		m.CheckNow(context.Background(), 0)
+
+		var order []string
+		for _, text := range sent.texts() {
+			for _, name := range names {
+				if strings.Contains(text, "Provider "+name) {
+					order = append(order, name)
+				}
+			}
+		}
+		return strings.Join(order, ",")
+	}
+
+	configs := map[string]Config{
+		"sequential": {},
+		"shuffled":   {CheckOrder: CheckOrderShuffle},
+		"concurrent": {ConcurrentChecks: true},
+	}
+	for name, config := range configs {
+		t.Run(name, func(t *testing.T) {
+			for range 5 {
+				if order := messageOrder(config); order != "alpha,mid,zeta" {
+					t.Fatalf("messages of %s, want them sorted by provider name", order)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package neverforgetvps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+// RateProvider supplies exchange rates for TotalOutstanding
+type RateProvider interface {
+	// Rate returns how many units of the target currency one unit of the source currency is worth
+	Rate(ctx context.Context, from, to string) (float64, error)
+}
+
+// TotalOutstanding returns the amount owed across all enabled providers implementing
+// provider.AmountDueProvider, converted to targetCurrency with Config.RateProvider
+// Providers without amounts are skipped. Failed providers are skipped too, and their errors
+// are returned joined together with the total of the others
+func (m *vpsMonitor[T]) TotalOutstanding(ctx context.Context, targetCurrency string) (provider.Money, error) {
+	total := provider.Money{Currency: targetCurrency}
+
+	var errs []error
+	for _, entry := range m.enabledProviders() {
+		dueProvider, ok := provider.Unwrap(entry.Provider).(provider.AmountDueProvider)
+		if !ok {
+			continue
+		}
+		name := entry.Provider.GetName()
+
+		amount, err := m.amountDue(ctx, dueProvider, entry)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("provider %s: %w", name, err))
+			continue
+		}
+		if amount.Amount == 0 {
+			continue
+		}
+
+		converted, err := m.convertMoney(ctx, amount, targetCurrency)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("provider %s: %w", name, err))
+			continue
+		}
+		total.Amount += converted
+	}
+
+	return total, errors.Join(errs...)
+}
+
+// amountDue requests the amount owed to a provider, bounded by the provider's timeout
+func (m *vpsMonitor[T]) amountDue(ctx context.Context, dueProvider provider.AmountDueProvider, entry providerEntry) (provider.Money, error) {
+	ctx, cancel := context.WithTimeout(ctx, entry.Timeout)
+	defer cancel()
+	return dueProvider.GetAmountDue(ctx)
+}
+
+// convertMoney converts an amount to the target currency, amounts already in it are returned as is
+func (m *vpsMonitor[T]) convertMoney(ctx context.Context, amount provider.Money, targetCurrency string) (float64, error) {
+	if strings.EqualFold(amount.Currency, targetCurrency) {
+		return amount.Amount, nil
+	}
+	if m.rateProvider == nil {
+		return 0, fmt.Errorf("cannot convert %s to %s: no RateProvider configured", amount.Currency, targetCurrency)
+	}
+
+	rate, err := m.rateProvider.Rate(ctx, amount.Currency, targetCurrency)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get %s/%s rate: %w", amount.Currency, targetCurrency, err)
+	}
+	return amount.Amount * rate, nil
+}
@@ -0,0 +1,75 @@
+package neverforgetvps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+// amountDueStub is a stub provider that also reports the amount owed
+type amountDueStub struct {
+	*stubProvider
+	amount provider.Money
+	err    error
+}
+
+func (p *amountDueStub) GetAmountDue(ctx context.Context) (provider.Money, error) {
+	return p.amount, p.err
+}
+
+// stubRates converts currencies with fixed rates keyed by "FROM->TO"
+type stubRates map[string]float64
+
+func (r stubRates) Rate(ctx context.Context, from, to string) (float64, error) {
+	rate, found := r[from+"->"+to]
+	if !found {
+		return 0, fmt.Errorf("no rate from %s to %s", from, to)
+	}
+	return rate, nil
+}
+
+func TestTotalOutstanding(t *testing.T) {
+	rates := stubRates{"RUB->USD": 0.01, "EUR->USD": 1.1}
+	rub := &amountDueStub{stubProvider: newStubProvider("vdsina", nil), amount: provider.Money{Amount: 1500, Currency: "RUB"}}
+	usd := &amountDueStub{stubProvider: newStubProvider("buyvm", nil), amount: provider.Money{Amount: 3.5, Currency: "USD"}}
+	eur := &amountDueStub{stubProvider: newStubProvider("gcore", nil), amount: provider.Money{Amount: 10, Currency: "EUR"}}
+	settled := &amountDueStub{stubProvider: newStubProvider("oneprovider", nil), amount: provider.Money{Currency: "CHF"}}
+	failing := &amountDueStub{stubProvider: newStubProvider("timeweb", nil), err: errors.New("boom")}
+	withoutAmounts := newStubProvider("cloudflare", nil)
+
+	tests := []struct {
+		name      string
+		providers []provider.Provider
+		rates     RateProvider
+		want      float64
+		wantErr   string // Expected part of the error, "" for none
+	}{
+		{name: "two currencies", providers: []provider.Provider{rub, usd}, rates: rates, want: 18.5},
+		{name: "providers without amounts skipped", providers: []provider.Provider{rub, eur, withoutAmounts, settled}, rates: rates, want: 26},
+		{name: "target currency needs no rates", providers: []provider.Provider{usd}, want: 3.5},
+		{name: "no rate provider", providers: []provider.Provider{rub, usd}, want: 3.5, wantErr: "provider vdsina: cannot convert RUB to USD: no RateProvider configured"},
+		{name: "failed provider", providers: []provider.Provider{rub, failing}, rates: rates, want: 15, wantErr: "provider timeweb: boom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, _ := newTestMonitor(t, Config{RateProvider: tt.rates}, newTestClock(), tt.providers...)
+
+			total, err := m.TotalOutstanding(context.Background(), "USD")
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("TotalOutstanding: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("TotalOutstanding: %v, want an error containing %q", err, tt.wantErr)
+			}
+			if total.Currency != "USD" || math.Abs(total.Amount-tt.want) > 1e-9 {
+				t.Errorf("total %v, want %.2f USD", total, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package neverforgetvps
+
+// checkCycle identifies a running check cycle, so overlapping cycles (e.g. CheckNow during a
+// scheduled check) send each notification once
+type checkCycle struct {
+	id      uint64          // Sequence number, increasing with every started cycle
+	overlap map[uint64]bool // Cycles that were still running when this one started
+}
+
+// notificationKey identifies the same notification sent by different cycles
+type notificationKey struct {
+	provider string
+	kind     messageKind
+	severity Severity
+	date     string // Payment date as displayed, empty for statuses without one
+}
+
+// beginCycle registers a starting check cycle together with the cycles it overlaps
+func (m *vpsMonitor[T]) beginCycle() *checkCycle {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.cycleSeq++
+	cycle := &checkCycle{id: m.cycleSeq, overlap: make(map[uint64]bool, len(m.runningCycles))}
+	for id := range m.runningCycles {
+		cycle.overlap[id] = true
+	}
+	if m.runningCycles == nil {
+		m.runningCycles = make(map[uint64]bool)
+	}
+	m.runningCycles[cycle.id] = true
+	return cycle
+}
+
+// endCycle unregisters a completed check cycle
+// Once no cycle is running, no later cycle can overlap the recorded notifications, so they are forgotten
+func (m *vpsMonitor[T]) endCycle(cycle *checkCycle) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.runningCycles, cycle.id)
+	if len(m.runningCycles) == 0 {
+		m.cycleNotified = nil
+	}
+}
+
+// isOverlapDuplicate checks whether a cycle overlapping this one already sent the same notification
+// A nil cycle (messages outside check cycles, e.g. Simulate) is never a duplicate
+// Must be called with m.mu held
+func (m *vpsMonitor[T]) isOverlapDuplicate(cycle *checkCycle, message pendingMessage) bool {
+	if cycle == nil {
+		return false
+	}
+	sender, found := m.cycleNotified[overlapKey(message)]
+	return found && (sender > cycle.id || cycle.overlap[sender])
+}
+
+// recordOverlap records that the cycle sends the notification
+// Must be called with m.mu held
+func (m *vpsMonitor[T]) recordOverlap(cycle *checkCycle, message pendingMessage) {
+	if cycle == nil {
+		return
+	}
+	if m.cycleNotified == nil {
+		m.cycleNotified = make(map[notificationKey]uint64)
+	}
+	m.cycleNotified[overlapKey(message)] = cycle.id
+}
+
+// overlapKey returns the key identifying the notification across cycles
+func overlapKey(message pendingMessage) notificationKey {
+	key := notificationKey{provider: message.status.Provider, kind: message.kind, severity: message.status.Severity}
+	if message.status.NextDate != nil {
+		key.date = message.status.displayDate()
+	}
+	return key
+}
@@ -0,0 +1,89 @@
+package neverforgetvps
+
+import (
+	"fmt"
+	"time"
+)
+
+// Snapshot is the persistable part of the monitor state
+// Save it with Snapshot and load it into a new monitor with RestoreSnapshot to survive restarts
+type Snapshot struct {
+	PausedUntil map[string]time.Time `json:"paused_until,omitempty"` // Pause end per provider name
+}
+
+// PauseProviderUntil suppresses all notifications for the provider until the given time
+// Checks keep running, and notifications resume automatically once the time has passed
+// Pausing with a time in the past resumes the provider immediately
+func (m *vpsMonitor[T]) PauseProviderUntil(name string, until time.Time) error {
+	if !m.hasProvider(name) {
+		return fmt.Errorf("unknown provider: %s", name)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !until.After(m.now()) {
+		delete(m.pausedUntil, name)
+		return nil
+	}
+
+	if m.pausedUntil == nil {
+		m.pausedUntil = make(map[string]time.Time)
+	}
+	m.pausedUntil[name] = until
+	return nil
+}
+
+// isPaused checks whether notifications for the provider are paused, removing expired pauses
+// Must be called with m.mu held
+func (m *vpsMonitor[T]) isPaused(name string) bool {
+	until, found := m.pausedUntil[name]
+	if !found {
+		return false
+	}
+	if !m.now().Before(until) {
+		delete(m.pausedUntil, name)
+		return false
+	}
+	return true
+}
+
+// Snapshot returns the persistable part of the monitor state
+func (m *vpsMonitor[T]) Snapshot() Snapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := Snapshot{PausedUntil: make(map[string]time.Time, len(m.pausedUntil))}
+	for name, until := range m.pausedUntil {
+		snapshot.PausedUntil[name] = until
+	}
+	return snapshot
+}
+
+// RestoreSnapshot loads state saved with Snapshot, replacing the current state
+// Expired pauses are dropped
+func (m *vpsMonitor[T]) RestoreSnapshot(snapshot Snapshot) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.pausedUntil = make(map[string]time.Time, len(snapshot.PausedUntil))
+	for name, until := range snapshot.PausedUntil {
+		if until.After(now) {
+			m.pausedUntil[name] = until
+		}
+	}
+}
+
+// hasProvider checks whether a provider with the given name is registered
+func (m *vpsMonitor[T]) hasProvider(name string) bool {
+	m.providersMu.RLock()
+	defer m.providersMu.RUnlock()
+
+	for _, entry := range m.providers {
+		if entry.Provider.GetName() == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package neverforgetvps
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestPauseProviderUntil(t *testing.T) {
+	clock := newTestClock()
+	paused := newStubProvider("vdsina", daysFrom(clock.Now(), 2))
+	other := newStubProvider("oneprovider", daysFrom(clock.Now(), 3))
+	m, sent := newTestMonitor(t, Config{}, clock, paused, other)
+
+	if err := m.PauseProviderUntil("vdsina", clock.Now().Add(48*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.PauseProviderUntil("unknown", clock.Now().Add(time.Hour)); err == nil {
+		t.Error("pausing an unknown provider succeeded, want an error")
+	}
+
+	for range 2 {
+		m.CheckNow(context.Background(), 0)
+		clock.Advance(12 * time.Hour)
+	}
+	texts := sent.texts()
+	if countContaining(texts, "vdsina") != 0 || countContaining(texts, "oneprovider") != 2 {
+		t.Fatalf("messages while vdsina is paused %q, want only oneprovider's", texts)
+	}
+	if paused.callCount() != 2 {
+		t.Errorf("paused provider checked %d times, want checks to keep running", paused.callCount())
+	}
+
+	sent.reset()
+	clock.Advance(24 * time.Hour)
+	m.CheckNow(context.Background(), 0)
+	if texts := sent.texts(); countContaining(texts, "vdsina") != 1 {
+		t.Fatalf("messages after the pause %q, want vdsina's to resume", texts)
+	}
+}
+
+func TestPauseSnapshot(t *testing.T) {
+	clock := newTestClock()
+	m, _ := newTestMonitor(t, Config{}, clock, newStubProvider("vdsina", nil), newStubProvider("oneprovider", nil))
+	until := clock.Now().Add(24 * time.Hour)
+	if err := m.PauseProviderUntil("vdsina", until); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.PauseProviderUntil("oneprovider", clock.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Persist the snapshot as JSON and restore it into a new monitor two hours later
+	data, err := json.Marshal(m.Snapshot())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(2 * time.Hour)
+	stub := newStubProvider("vdsina", daysFrom(clock.Now(), 2))
+	restored, sent := newTestMonitor(t, Config{}, clock, stub, newStubProvider("oneprovider", daysFrom(clock.Now(), 2)))
+	restored.RestoreSnapshot(snapshot)
+
+	if got := restored.Snapshot().PausedUntil; len(got) != 1 || !got["vdsina"].Equal(until) {
+		t.Fatalf("restored pauses %v, want only vdsina's until %v", got, until)
+	}
+	restored.CheckNow(context.Background(), 0)
+	if texts := sent.texts(); countContaining(texts, "vdsina") != 0 || countContaining(texts, "oneprovider") != 1 {
+		t.Fatalf("messages %q, want vdsina still paused and the expired oneprovider pause dropped", texts)
+	}
+}
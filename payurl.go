@@ -0,0 +1,53 @@
+package neverforgetvps
+
+// DefaultPayURLs are the billing pages linked in urgent messages, keyed by provider name
+// Override or disable them per provider with Config.PayURLs
+var DefaultPayURLs = map[string]string{
+	"vdsina":      "https://cp.vdsina.com/",
+	"oneprovider": "https://panel.op-net.com/",
+	"cloudflare":  "https://dash.cloudflare.com/?to=/:account/billing",
+	"yandexcloud": "https://console.yandex.cloud/billing",
+	"timeweb":     "https://timeweb.cloud/my/finances",
+	"buyvm":       "https://my.frantech.ca/clientarea.php?action=invoices",
+	"oraclecloud": "https://cloud.oracle.com/invoices-and-orders",
+	"gcore":       "https://accounts.gcore.com/billing",
+	"netcup":      "https://www.customercontrolpanel.de/rechnungen.php",
+	"hostinger":   "https://hpanel.hostinger.com/billing/subscriptions",
+	"regru":       "https://www.reg.ru/user/account/",
+	"kamatera":    "https://console.kamatera.com/billing",
+}
+
+// payURLs merges the configured pay links over the defaults
+// An empty configured link disables the default one
+func payURLs(configured map[string]string) map[string]string {
+	urls := make(map[string]string, len(DefaultPayURLs)+len(configured))
+	for name, url := range DefaultPayURLs {
+		urls[name] = url
+	}
+	for name, url := range configured {
+		if url == "" {
+			delete(urls, name)
+			continue
+		}
+		urls[name] = url
+	}
+	return urls
+}
+
+// withPayURL appends the provider's pay link to a payment message
+func (m *vpsMonitor[T]) withPayURL(status ProviderStatus, text string) string {
+	url := m.payURL(status)
+	if url == "" {
+		return text
+	}
+	return text + "\nPay: " + url
+}
+
+// payURL returns the pay link to include in a payment message about the status, empty if none
+// The link is added to Warning and Critical messages, or to every message if PayURLAlways is set
+func (m *vpsMonitor[T]) payURL(status ProviderStatus) string {
+	if status.Severity < SeverityWarning && !m.payURLAlways {
+		return ""
+	}
+	return m.payURLs[status.Provider]
+}
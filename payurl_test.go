@@ -0,0 +1,49 @@
+package neverforgetvps
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestPayURL(t *testing.T) {
+	const defaultLink = "\nPay: https://cp.vdsina.com/"
+
+	tests := []struct {
+		name     string
+		config   Config
+		days     int
+		wantLink string // Expected link suffix, "" for none
+	}{
+		{name: "warning", days: 1, wantLink: defaultLink},
+		{name: "critical", days: -3, wantLink: defaultLink},
+		{name: "attention", days: 4},
+		{name: "info", days: 20},
+		{name: "info with PayURLAlways", config: Config{PayURLAlways: true}, days: 20, wantLink: defaultLink},
+		{name: "overridden link", config: Config{PayURLs: map[string]string{"vdsina": "https://example.com/pay"}}, days: 1, wantLink: "\nPay: https://example.com/pay"},
+		{name: "disabled link", config: Config{PayURLs: map[string]string{"vdsina": ""}}, days: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newTestClock()
+			m, sent := newTestMonitor(t, tt.config, clock, newStubProvider("vdsina", daysFrom(clock.Now(), tt.days)))
+
+			m.CheckNow(context.Background(), 0)
+
+			texts := sent.texts()
+			if len(texts) != 1 {
+				t.Fatalf("messages %q, want one", texts)
+			}
+			if tt.wantLink == "" {
+				if strings.Contains(texts[0], "Pay:") {
+					t.Errorf("message %q, want no pay link", texts[0])
+				}
+				return
+			}
+			if !strings.HasSuffix(texts[0], tt.wantLink) {
+				t.Errorf("message %q, want it to end with %q", texts[0], tt.wantLink)
+			}
+		})
+	}
+}
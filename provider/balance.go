@@ -0,0 +1,29 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+)
+
+// Money is an amount in a specific currency
+type Money struct {
+	Amount   float64 // Amount in currency units
+	Currency string  // ISO 4217 currency code, e.g. "RUB"
+}
+
+// String formats the amount with its currency, e.g. "500.00 RUB"
+func (m Money) String() string {
+	return fmt.Sprintf("%.2f %s", m.Amount, m.Currency)
+}
+
+// BalanceProvider is implemented by prepaid providers that expose the account balance
+type BalanceProvider interface {
+	// GetBalance returns the current account balance
+	GetBalance(ctx context.Context) (Money, error)
+}
+
+// AmountDueProvider is implemented by invoice-based providers that expose the total amount owed
+type AmountDueProvider interface {
+	// GetAmountDue returns the total of all unpaid invoices, zero if nothing is owed
+	GetAmountDue(ctx context.Context) (Money, error)
+}
@@ -0,0 +1,303 @@
+package buyvm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+const (
+	buyVMAPIURL = "https://manage.buyvm.net/api/v1"
+)
+
+// BuyVMProvider implements the Provider interface for BuyVM (Stallion API)
+type BuyVMProvider struct {
+	apiKey      string
+	baseURL     string
+	client      *http.Client
+	location    *time.Location // Billing time zone of invoice due dates
+	outstanding []string       // Invoice statuses counted as unpaid
+}
+
+// New creates a new instance of BuyVMProvider
+// Returns provider.ErrMissingCredentials if apiKey is empty
+// Supported options: provider.WithLocation (default: UTC), provider.WithBaseURL, provider.WithTLSConfig, provider.WithCertificatePin,
+// provider.WithOutstandingStatuses (default: provider.DefaultOutstandingStatuses)
+func New(apiKey string, opts ...provider.Option) (provider.Provider, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("buyvm: api key is empty: %w", provider.ErrMissingCredentials)
+	}
+	options := provider.ApplyOptions(opts)
+	return &BuyVMProvider{
+		apiKey:      apiKey,
+		baseURL:     options.BaseURLOr(buyVMAPIURL),
+		client:      provider.NewHTTPClient(options),
+		location:    options.Location,
+		outstanding: options.OutstandingStatusesOr(provider.DefaultOutstandingStatuses),
+	}, nil
+}
+
+// GetName returns the provider name
+func (b *BuyVMProvider) GetName() string {
+	return "buyvm"
+}
+
+// IsConfigured checks if the provider is configured
+func (b *BuyVMProvider) IsConfigured() bool {
+	return b != nil && b.apiKey != ""
+}
+
+// Fingerprint returns a stable identifier of the provider account
+func (b *BuyVMProvider) Fingerprint() string {
+	return provider.CredentialFingerprint(b.GetName(), b.apiKey)
+}
+
+// invoiceResponse represents the API response from Stallion for invoice list
+type invoiceResponse struct {
+	Invoices []invoice `json:"invoices"`
+}
+
+// invoice represents an invoice from Stallion API
+type invoice struct {
+	ID       int64  `json:"id"`
+	Status   string `json:"status"`
+	Date     string `json:"date"`
+	DueDate  string `json:"duedate"`
+	Total    string `json:"total"`
+	Currency string `json:"currency"`
+}
+
+// creditResponse represents the API response from Stallion for account credit
+type creditResponse struct {
+	Credit   string `json:"credit"`
+	Currency string `json:"currency"`
+}
+
+// errorResponse represents an error response from Stallion API
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// GetBalance retrieves the account credit from BuyVM
+func (b *BuyVMProvider) GetBalance(ctx context.Context) (provider.Money, error) {
+	credit, err := b.fetchCredit(ctx)
+	if err != nil {
+		return provider.Money{}, fmt.Errorf("failed to fetch credit: %w", err)
+	}
+
+	return credit, nil
+}
+
+// GetNextPaymentDate retrieves the next payment due date from BuyVM
+// Returns the earliest due date from unpaid invoices, or nil if there are no unpaid invoices
+// or the account credit covers the earliest one (Stallion applies credit automatically)
+func (b *BuyVMProvider) GetNextPaymentDate(ctx context.Context) (*time.Time, error) {
+	invoices, err := b.fetchUnpaidInvoices(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch invoices: %w", err)
+	}
+
+	if len(invoices) == 0 {
+		return nil, nil
+	}
+
+	sort.Slice(invoices, func(i, j int) bool {
+		return invoices[i].dueDate.Before(invoices[j].dueDate)
+	})
+	next := invoices[0]
+
+	// Credit covering the next cycle - no payment due
+	credit, err := b.fetchCredit(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch credit: %w", err)
+	}
+	if credit.Amount >= next.total {
+		return nil, nil
+	}
+
+	return &next.dueDate, nil
+}
+
+// GetAmountDue retrieves the total of the unpaid invoices from BuyVM, reduced by the account credit
+// Stallion applies credit to invoices automatically, so only the remainder has to be paid
+func (b *BuyVMProvider) GetAmountDue(ctx context.Context) (provider.Money, error) {
+	invoices, err := b.fetchUnpaidInvoices(ctx)
+	if err != nil {
+		return provider.Money{}, fmt.Errorf("failed to fetch invoices: %w", err)
+	}
+
+	credit, err := b.fetchCredit(ctx)
+	if err != nil {
+		return provider.Money{}, fmt.Errorf("failed to fetch credit: %w", err)
+	}
+
+	var total float64
+	for _, invoice := range invoices {
+		total += invoice.total
+	}
+
+	return provider.Money{Amount: max(total-credit.Amount, 0), Currency: credit.Currency}, nil
+}
+
+// unpaidInvoice is an unpaid invoice with parsed due date and total
+type unpaidInvoice struct {
+	dueDate time.Time
+	total   float64
+}
+
+// fetchUnpaidInvoices fetches and parses the invoices of every outstanding status with a due date
+func (b *BuyVMProvider) fetchUnpaidInvoices(ctx context.Context) ([]unpaidInvoice, error) {
+	var all []invoice
+	for _, status := range b.outstanding {
+		body, err := b.fetchRawInvoices(ctx, status)
+		if err != nil {
+			return nil, err
+		}
+
+		// Parse JSON
+		var apiResponse invoiceResponse
+		if err := json.Unmarshal(body, &apiResponse); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON: %w", err)
+		}
+		all = append(all, apiResponse.Invoices...)
+	}
+
+	var invoices []unpaidInvoice
+	for _, invoice := range all {
+		if !provider.IsOutstanding(invoice.Status, b.outstanding) || invoice.DueDate == "" {
+			continue
+		}
+
+		dueDate, err := time.ParseInLocation("2006-01-02", invoice.DueDate, b.location)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse due date of invoice %d: %w", invoice.ID, err)
+		}
+		total, err := strconv.ParseFloat(invoice.Total, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse total of invoice %d: %w", invoice.ID, err)
+		}
+		invoices = append(invoices, unpaidInvoice{dueDate: dueDate, total: total})
+	}
+
+	return invoices, nil
+}
+
+// makeRequest creates an HTTP request to Stallion API
+// method - HTTP method (GET, POST, etc.)
+// path - API path (e.g., "/billing/invoices")
+// queryParams - query parameters, can be nil
+// body - request body for POST requests, can be nil
+func (b *BuyVMProvider) makeRequest(ctx context.Context, method, path string, queryParams map[string]string, body io.Reader) (*http.Request, error) {
+	// Build full URL
+	fullURL := b.baseURL + path
+	u, err := url.Parse(fullURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL: %w", err)
+	}
+
+	// Add query parameters if present
+	if len(queryParams) > 0 {
+		q := u.Query()
+		for key, value := range queryParams {
+			q.Set(key, value)
+		}
+		u.RawQuery = q.Encode()
+	}
+
+	// Create request with context
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set headers
+	req.Header.Set("Authorization", "Bearer "+b.apiKey)
+	req.Header.Set("Accept", "application/json")
+
+	// Tag request for tracing
+	provider.SetRequestIDHeader(req)
+
+	return req, nil
+}
+
+// executeRequest executes an HTTP request and returns the response body
+// Returns the response body as bytes or an error if the request fails
+func (b *BuyVMProvider) executeRequest(req *http.Request) ([]byte, error) {
+	// Execute request
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Check status code
+	if resp.StatusCode != http.StatusOK {
+		var apiErr errorResponse
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Error != "" {
+			// Report the API error message instead of the raw body
+			body = []byte("API error: " + apiErr.Error)
+		}
+		return nil, provider.StatusError("BuyVM", resp.StatusCode, "BUYVM_API_KEY", body)
+	}
+
+	return body, nil
+}
+
+// FetchRaw performs the primary API call (invoices with the first outstanding status) and returns the raw response body without parsing
+func (b *BuyVMProvider) FetchRaw(ctx context.Context) ([]byte, error) {
+	return b.fetchRawInvoices(ctx, b.outstanding[0])
+}
+
+// fetchRawInvoices fetches the invoices with the given status without parsing
+func (b *BuyVMProvider) fetchRawInvoices(ctx context.Context, status string) ([]byte, error) {
+	// Create request to list invoices with the status
+	req, err := b.makeRequest(ctx, "GET", "/billing/invoices", map[string]string{"status": status}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Execute request
+	return b.executeRequest(req)
+}
+
+// fetchCredit fetches the account credit from Stallion API
+func (b *BuyVMProvider) fetchCredit(ctx context.Context) (provider.Money, error) {
+	// Create request to get account credit
+	req, err := b.makeRequest(ctx, "GET", "/billing/credit", nil, nil)
+	if err != nil {
+		return provider.Money{}, err
+	}
+
+	// Execute request
+	body, err := b.executeRequest(req)
+	if err != nil {
+		return provider.Money{}, err
+	}
+
+	// Parse JSON
+	var apiResponse creditResponse
+	if err := json.Unmarshal(body, &apiResponse); err != nil {
+		return provider.Money{}, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	amount, err := strconv.ParseFloat(apiResponse.Credit, 64)
+	if err != nil {
+		return provider.Money{}, fmt.Errorf("failed to parse credit: %w", err)
+	}
+
+	return provider.Money{Amount: amount, Currency: apiResponse.Currency}, nil
+}
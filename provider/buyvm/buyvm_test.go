@@ -0,0 +1,133 @@
+package buyvm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+// newStallionServer returns a test API server listing the invoices per status filter and the account credit
+func newStallionServer(t *testing.T, invoices map[string]string, credit string) string {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer key" {
+			http.Error(w, `{"error":"invalid api key"}`, http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/billing/invoices":
+			fmt.Fprintf(w, `{"invoices":[%s]}`, invoices[r.URL.Query().Get("status")])
+		case "/billing/credit":
+			fmt.Fprintf(w, `{"credit":"%s","currency":"USD"}`, credit)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestGetNextPaymentDate(t *testing.T) {
+	invoices := map[string]string{
+		"Unpaid": `{"id":11,"status":"Unpaid","date":"2026-10-01","duedate":"2026-11-15","total":"15.00","currency":"USD"},` +
+			`{"id":12,"status":"Paid","date":"2026-09-01","duedate":"2026-10-01","total":"15.00","currency":"USD"}`,
+		"Overdue": `{"id":10,"status":"Overdue","date":"2026-09-20","duedate":"2026-10-10","total":"3.50","currency":"USD"}`,
+	}
+
+	tests := []struct {
+		name     string
+		invoices map[string]string
+		credit   string
+		want     string // Expected due date, "" for none
+	}{
+		{name: "earliest unpaid invoice", invoices: invoices, credit: "0.00", want: "2026-10-10"},
+		{name: "credit below the next invoice", invoices: invoices, credit: "2.00", want: "2026-10-10"},
+		{name: "credit covering the next invoice", invoices: invoices, credit: "3.50"},
+		{name: "no unpaid invoices", credit: "0.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := New("key", provider.WithBaseURL(newStallionServer(t, tt.invoices, tt.credit)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			date, err := p.GetNextPaymentDate(context.Background())
+			if err != nil {
+				t.Fatalf("GetNextPaymentDate: %v", err)
+			}
+
+			var got string
+			if date != nil {
+				got = date.Format(time.DateOnly)
+			}
+			if got != tt.want {
+				t.Errorf("date %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	p, err := New("wrong", provider.WithBaseURL(newStallionServer(t, nil, "0.00")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = p.GetNextPaymentDate(context.Background())
+	var statusErr *provider.HTTPStatusError
+	if !errors.As(err, &statusErr) || statusErr.Body != "API error: invalid api key" {
+		t.Fatalf("GetNextPaymentDate: %v, want the API error message", err)
+	}
+}
+
+func TestNewMissingCredentials(t *testing.T) {
+	if _, err := New(""); !errors.Is(err, provider.ErrMissingCredentials) {
+		t.Errorf("New with an empty API key: %v, want ErrMissingCredentials", err)
+	}
+}
+
+func TestOutstandingStatuses(t *testing.T) {
+	invoices := map[string]string{
+		"Unpaid":          `{"id":11,"status":"Unpaid","date":"2026-10-01","duedate":"2026-11-15","total":"15.00","currency":"USD"}`,
+		"Overdue":         `{"id":10,"status":"Overdue","date":"2026-09-20","duedate":"2026-10-10","total":"3.50","currency":"USD"}`,
+		"Payment Pending": `{"id":12,"status":"Payment Pending","date":"2026-09-25","duedate":"2026-10-05","total":"5.00","currency":"USD"}`,
+	}
+
+	tests := []struct {
+		name     string
+		statuses []string
+		want     string
+	}{
+		{name: "default statuses", want: "2026-10-10"},
+		{name: "non-standard status", statuses: []string{"Unpaid", "Payment Pending"}, want: "2026-10-05"},
+		{name: "single status", statuses: []string{"Unpaid"}, want: "2026-11-15"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []provider.Option{provider.WithBaseURL(newStallionServer(t, invoices, "0.00"))}
+			if tt.statuses != nil {
+				opts = append(opts, provider.WithOutstandingStatuses(tt.statuses...))
+			}
+			p, err := New("key", opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			date, err := p.GetNextPaymentDate(context.Background())
+			if err != nil {
+				t.Fatalf("GetNextPaymentDate: %v", err)
+			}
+			if date == nil || date.Format(time.DateOnly) != tt.want {
+				t.Errorf("date %v, want %s", date, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// forceRefreshKey is the context key used to bypass cached results
+type forceRefreshKey struct{}
+
+// WithForceRefresh returns a context that makes CachedProvider skip its cache
+func WithForceRefresh(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forceRefreshKey{}, true)
+}
+
+// isForceRefresh checks whether the context requests bypassing the cache
+func isForceRefresh(ctx context.Context) bool {
+	force, _ := ctx.Value(forceRefreshKey{}).(bool)
+	return force
+}
+
+// CachedProvider wraps a Provider and caches successful results for a TTL
+// Errors are never cached, so a failed fetch is retried on the next call
+type CachedProvider struct {
+	Provider
+
+	ttl time.Duration
+	now func() time.Time
+
+	mu        sync.Mutex
+	cached    bool          // Whether a successful result is stored
+	date      *time.Time    // Cached next payment date (can be nil - no payment due)
+	dates     []time.Time   // Cached candidate dates sorted ascending
+	accounts  []AccountDate // Cached candidate dates with their accounts, only for AccountDatesProvider
+	fetchedAt time.Time     // Time of the last successful fetch
+}
+
+// NewCached wraps a provider with a cache of the given TTL
+func NewCached(p Provider, ttl time.Duration) *CachedProvider {
+	return &CachedProvider{
+		Provider: p,
+		ttl:      ttl,
+		now:      time.Now,
+	}
+}
+
+// Unwrap returns the underlying provider
+func (c *CachedProvider) Unwrap() Provider {
+	return c.Provider
+}
+
+// GetNextPaymentDate returns the cached date if the last successful fetch is within the TTL,
+// otherwise it requests the date from the underlying provider
+// Use WithForceRefresh to bypass the cache
+func (c *CachedProvider) GetNextPaymentDate(ctx context.Context) (*time.Time, error) {
+	result, err := c.get(ctx)
+	return result.date, err
+}
+
+// GetCandidateDates returns the cached candidate dates if the last successful fetch is within the TTL
+// For providers without CandidateDatesProvider support the next payment date is the only candidate
+func (c *CachedProvider) GetCandidateDates(ctx context.Context) ([]time.Time, error) {
+	result, err := c.get(ctx)
+	return result.dates, err
+}
+
+// GetAccountDates returns the cached candidate dates with their accounts if the last successful fetch is within the TTL
+// For providers without AccountDatesProvider support every date belongs to the provider's own account
+func (c *CachedProvider) GetAccountDates(ctx context.Context) ([]AccountDate, error) {
+	result, err := c.get(ctx)
+	return result.accounts, err
+}
+
+// fetchResult is the result of a single provider fetch
+type fetchResult struct {
+	date     *time.Time
+	dates    []time.Time
+	accounts []AccountDate
+}
+
+// get returns the cached result or fetches a fresh one from the underlying provider
+func (c *CachedProvider) get(ctx context.Context) (fetchResult, error) {
+	c.mu.Lock()
+	if c.cached && !isForceRefresh(ctx) && c.now().Sub(c.fetchedAt) < c.ttl {
+		result := fetchResult{
+			date:     copyTime(c.date),
+			dates:    append([]time.Time(nil), c.dates...),
+			accounts: append([]AccountDate(nil), c.accounts...),
+		}
+		c.mu.Unlock()
+		return result, nil
+	}
+	c.mu.Unlock()
+
+	result, err := fetchDates(ctx, c.Provider)
+	if err != nil {
+		return fetchResult{}, err
+	}
+
+	c.mu.Lock()
+	c.cached = true
+	c.date = copyTime(result.date)
+	c.dates = append([]time.Time(nil), result.dates...)
+	c.accounts = append([]AccountDate(nil), result.accounts...)
+	c.fetchedAt = c.now()
+	c.mu.Unlock()
+
+	return result, nil
+}
+
+// fetchDates requests the next payment date and candidate dates with a single provider call
+func fetchDates(ctx context.Context, p Provider) (fetchResult, error) {
+	if lister, ok := p.(AccountDatesProvider); ok {
+		accounts, err := lister.GetAccountDates(ctx)
+		if err != nil || len(accounts) == 0 {
+			return fetchResult{}, err
+		}
+		dates := make([]time.Time, len(accounts))
+		for i, account := range accounts {
+			dates[i] = account.Date
+		}
+		return fetchResult{date: copyTime(&dates[0]), dates: dates, accounts: accounts}, nil
+	}
+
+	if lister, ok := p.(CandidateDatesProvider); ok {
+		dates, err := lister.GetCandidateDates(ctx)
+		if err != nil || len(dates) == 0 {
+			return fetchResult{}, err
+		}
+		return fetchResult{date: copyTime(&dates[0]), dates: dates, accounts: ownAccountDates(dates)}, nil
+	}
+
+	date, err := p.GetNextPaymentDate(ctx)
+	if err != nil || date == nil {
+		return fetchResult{}, err
+	}
+	dates := []time.Time{*date}
+	return fetchResult{date: date, dates: dates, accounts: ownAccountDates(dates)}, nil
+}
+
+// ownAccountDates assigns dates to the provider's own account
+func ownAccountDates(dates []time.Time) []AccountDate {
+	accounts := make([]AccountDate, len(dates))
+	for i, date := range dates {
+		accounts[i] = AccountDate{Date: date}
+	}
+	return accounts
+}
+
+// copyTime returns a copy of t so callers cannot modify the cached value
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	value := *t
+	return &value
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// countingProvider counts its calls and returns a scripted result
+type countingProvider struct {
+	calls int
+	date  time.Time
+	err   error
+}
+
+func (p *countingProvider) GetName() string { return "counting" }
+
+func (p *countingProvider) IsConfigured() bool { return true }
+
+func (p *countingProvider) GetNextPaymentDate(context.Context) (*time.Time, error) {
+	p.calls++
+	if p.err != nil {
+		return nil, p.err
+	}
+	date := p.date
+	return &date, nil
+}
+
+func TestCachedProvider(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	inner := &countingProvider{date: now.AddDate(0, 0, 10)}
+	cached := NewCached(inner, time.Hour)
+	cached.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	get := func(ctx context.Context, wantCalls int) {
+		t.Helper()
+		date, err := cached.GetNextPaymentDate(ctx)
+		if err != nil {
+			t.Fatalf("GetNextPaymentDate: %v", err)
+		}
+		if date == nil || !date.Equal(inner.date) {
+			t.Fatalf("date %v, want %v", date, inner.date)
+		}
+		if inner.calls != wantCalls {
+			t.Fatalf("%d provider calls, want %d", inner.calls, wantCalls)
+		}
+	}
+
+	get(ctx, 1)
+	// Within the TTL the cached result is returned
+	now = now.Add(59 * time.Minute)
+	get(ctx, 1)
+	// Forcing a refresh bypasses the cache
+	get(WithForceRefresh(ctx), 2)
+	// Once the TTL has passed the provider is called again
+	now = now.Add(time.Hour)
+	get(ctx, 3)
+
+	// Errors are not cached
+	now = now.Add(2 * time.Hour)
+	inner.err = errors.New("boom")
+	if _, err := cached.GetNextPaymentDate(ctx); err == nil {
+		t.Fatal("GetNextPaymentDate succeeded, want the provider error")
+	}
+	inner.err = nil
+	get(ctx, 5)
+}
@@ -0,0 +1,74 @@
+package provider
+
+// Capability names reported by Capabilities
+const (
+	// CapabilityNextPaymentDate - the provider reports the next payment date (every provider)
+	CapabilityNextPaymentDate = "next_payment_date"
+	// CapabilityBalance - the provider implements BalanceProvider
+	CapabilityBalance = "balance"
+	// CapabilityCandidateDates - the provider implements CandidateDatesProvider
+	CapabilityCandidateDates = "candidate_dates"
+	// CapabilityRawFetch - the provider implements RawFetcher
+	CapabilityRawFetch = "raw_fetch"
+	// CapabilityAccountDates - the provider implements AccountDatesProvider
+	CapabilityAccountDates = "account_dates"
+	// CapabilityAccountLocation - the provider implements LocationProvider
+	CapabilityAccountLocation = "account_location"
+	// CapabilitySuspension - the provider implements SuspensionReporter
+	CapabilitySuspension = "suspension"
+	// CapabilityAmountDue - the provider implements AmountDueProvider
+	CapabilityAmountDue = "amount_due"
+)
+
+// Unwrapper is implemented by providers that wrap another provider (e.g. CachedProvider)
+type Unwrapper interface {
+	// Unwrap returns the wrapped provider
+	Unwrap() Provider
+}
+
+// Unwrap returns the innermost provider by following Unwrapper implementations
+func Unwrap(p Provider) Provider {
+	for {
+		wrapper, ok := p.(Unwrapper)
+		if !ok {
+			return p
+		}
+		inner := wrapper.Unwrap()
+		if inner == nil {
+			return p
+		}
+		p = inner
+	}
+}
+
+// optionalCapabilities maps capability names to checks for the corresponding optional interfaces
+var optionalCapabilities = []struct {
+	name        string
+	implemented func(Provider) bool
+}{
+	{name: CapabilityBalance, implemented: func(p Provider) bool { _, ok := p.(BalanceProvider); return ok }},
+	{name: CapabilityCandidateDates, implemented: func(p Provider) bool { _, ok := p.(CandidateDatesProvider); return ok }},
+	{name: CapabilityRawFetch, implemented: func(p Provider) bool { _, ok := p.(RawFetcher); return ok }},
+	{name: CapabilityAccountDates, implemented: func(p Provider) bool { _, ok := p.(AccountDatesProvider); return ok }},
+	{name: CapabilityAccountLocation, implemented: func(p Provider) bool { _, ok := p.(LocationProvider); return ok }},
+	{name: CapabilitySuspension, implemented: func(p Provider) bool { _, ok := p.(SuspensionReporter); return ok }},
+	{name: CapabilityAmountDue, implemented: func(p Provider) bool { _, ok := p.(AmountDueProvider); return ok }},
+}
+
+// Capabilities returns the names of the optional interfaces implemented by the provider
+// Wrappers are looked through, so a cached provider reports the capabilities of the wrapped one
+func Capabilities(p Provider) []string {
+	if p == nil {
+		return nil
+	}
+
+	capabilities := []string{CapabilityNextPaymentDate}
+	inner := Unwrap(p)
+	for _, capability := range optionalCapabilities {
+		if capability.implemented(inner) {
+			capabilities = append(capabilities, capability.name)
+		}
+	}
+
+	return capabilities
+}
@@ -0,0 +1,225 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+const (
+	cloudflareAPIURL = "https://api.cloudflare.com/client/v4"
+)
+
+// CloudflareProvider implements the Provider interface for Cloudflare paid plans and add-ons
+type CloudflareProvider struct {
+	apiToken string
+	baseURL  string
+	client   *http.Client
+}
+
+// New creates a new instance of CloudflareProvider
+// Returns provider.ErrMissingCredentials if apiToken is empty
+// Supported options: provider.WithBaseURL, provider.WithTLSConfig, provider.WithCertificatePin
+func New(apiToken string, opts ...provider.Option) (provider.Provider, error) {
+	if apiToken == "" {
+		return nil, fmt.Errorf("cloudflare: api token is empty: %w", provider.ErrMissingCredentials)
+	}
+	options := provider.ApplyOptions(opts)
+	return &CloudflareProvider{
+		apiToken: apiToken,
+		baseURL:  options.BaseURLOr(cloudflareAPIURL),
+		client:   provider.NewHTTPClient(options),
+	}, nil
+}
+
+// NewOrNil creates a new instance of CloudflareProvider
+// If apiToken is empty, the provider is considered not configured and nil is returned
+//
+// Deprecated: use New, which reports missing credentials explicitly
+func NewOrNil(apiToken string) provider.Provider {
+	p, err := New(apiToken)
+	if err != nil {
+		return nil
+	}
+	return p
+}
+
+// GetName returns the provider name
+func (c *CloudflareProvider) GetName() string {
+	return "cloudflare"
+}
+
+// IsConfigured checks if the provider is configured
+func (c *CloudflareProvider) IsConfigured() bool {
+	return c != nil && c.apiToken != ""
+}
+
+// Fingerprint returns a stable identifier of the provider account
+func (c *CloudflareProvider) Fingerprint() string {
+	return provider.CredentialFingerprint(c.GetName(), c.apiToken)
+}
+
+// apiError represents an error entry in the Cloudflare API envelope
+type apiError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// subscriptionsResponse represents the API response from Cloudflare for user subscriptions
+type subscriptionsResponse struct {
+	Success bool           `json:"success"`
+	Errors  []apiError     `json:"errors"`
+	Result  []subscription `json:"result"`
+}
+
+// subscription represents a subscription (plan or add-on) from Cloudflare API
+type subscription struct {
+	ID                 string  `json:"id"`
+	State              string  `json:"state"`
+	Price              float64 `json:"price"`
+	Currency           string  `json:"currency"`
+	Frequency          string  `json:"frequency"`
+	CurrentPeriodStart string  `json:"current_period_start"`
+	CurrentPeriodEnd   string  `json:"current_period_end"`
+	RatePlan           struct {
+		ID         string `json:"id"`
+		PublicName string `json:"public_name"`
+	} `json:"rate_plan"`
+}
+
+// GetNextPaymentDate retrieves the next renewal date from Cloudflare
+// Returns the earliest period end among paid subscriptions, or nil for free accounts
+func (c *CloudflareProvider) GetNextPaymentDate(ctx context.Context) (*time.Time, error) {
+	subscriptions, err := c.fetchSubscriptions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch subscriptions: %w", err)
+	}
+
+	var earliestDate *time.Time
+	for _, s := range subscriptions {
+		// Free plans have no price and never renew for money
+		if s.Price <= 0 || s.RatePlan.ID == "free" || s.CurrentPeriodEnd == "" {
+			continue
+		}
+		if s.State == "Cancelled" || s.State == "Expired" {
+			continue
+		}
+
+		renewalDate, err := time.Parse(time.RFC3339, s.CurrentPeriodEnd)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse period end for subscription %s: %w", s.ID, err)
+		}
+		renewalDate = renewalDate.UTC()
+		if earliestDate == nil || renewalDate.Before(*earliestDate) {
+			earliestDate = &renewalDate
+		}
+	}
+
+	return earliestDate, nil
+}
+
+// makeRequest creates an HTTP request to Cloudflare API
+// method - HTTP method (GET, POST, etc.)
+// path - API path (e.g., "/user/subscriptions")
+// queryParams - query parameters, can be nil
+// body - request body for POST requests, can be nil
+func (c *CloudflareProvider) makeRequest(ctx context.Context, method, path string, queryParams map[string]string, body io.Reader) (*http.Request, error) {
+	// Build full URL
+	fullURL := c.baseURL + path
+	u, err := url.Parse(fullURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL: %w", err)
+	}
+
+	// Add query parameters if present
+	if len(queryParams) > 0 {
+		q := u.Query()
+		for key, value := range queryParams {
+			q.Set(key, value)
+		}
+		u.RawQuery = q.Encode()
+	}
+
+	// Create request with context
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set headers
+	req.Header.Set("Authorization", "Bearer "+c.apiToken)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	// Tag request for tracing
+	provider.SetRequestIDHeader(req)
+
+	return req, nil
+}
+
+// executeRequest executes an HTTP request and returns the response body
+// Returns the response body as bytes or an error if the request fails
+func (c *CloudflareProvider) executeRequest(req *http.Request) ([]byte, error) {
+	// Execute request
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Check status code
+	if resp.StatusCode != http.StatusOK {
+		return nil, provider.StatusError("Cloudflare", resp.StatusCode, "CLOUDFLARE_API_TOKEN", body)
+	}
+
+	return body, nil
+}
+
+// FetchRaw performs the primary API call (user subscriptions) and returns the raw response body without parsing
+func (c *CloudflareProvider) FetchRaw(ctx context.Context) ([]byte, error) {
+	// Create request to get subscriptions
+	req, err := c.makeRequest(ctx, "GET", "/user/subscriptions", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Execute request
+	return c.executeRequest(req)
+}
+
+// fetchSubscriptions fetches the list of user subscriptions from Cloudflare API
+func (c *CloudflareProvider) fetchSubscriptions(ctx context.Context) ([]subscription, error) {
+	// Fetch raw response
+	body, err := c.FetchRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse JSON
+	var apiResponse subscriptionsResponse
+	if err := json.Unmarshal(body, &apiResponse); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	// Check for API error
+	if !apiResponse.Success {
+		if len(apiResponse.Errors) > 0 {
+			return nil, fmt.Errorf("API error: %s (code: %d)", apiResponse.Errors[0].Message, apiResponse.Errors[0].Code)
+		}
+		return nil, fmt.Errorf("API error: request was not successful")
+	}
+
+	return apiResponse.Result, nil
+}
@@ -0,0 +1,103 @@
+package cloudflare
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+func TestGetNextPaymentDate(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string // RFC 3339, empty for no payment due
+	}{
+		{
+			name: "paid subscription renews",
+			body: `{"success":true,"result":[
+				{"id":"free","state":"Paid","price":0,"current_period_end":"2026-10-20T00:00:00Z","rate_plan":{"id":"free"}},
+				{"id":"pro","state":"Paid","price":25,"currency":"USD","frequency":"monthly","current_period_end":"2026-11-05T10:00:00Z","rate_plan":{"id":"pro"}},
+				{"id":"argo","state":"Paid","price":5,"currency":"USD","frequency":"monthly","current_period_end":"2026-11-01T10:00:00Z","rate_plan":{"id":"argo"}},
+				{"id":"old","state":"Cancelled","price":5,"current_period_end":"2026-10-18T10:00:00Z","rate_plan":{"id":"lb"}}
+			]}`,
+			want: "2026-11-01T10:00:00Z",
+		},
+		{
+			name: "free account",
+			body: `{"success":true,"result":[{"id":"free","state":"Paid","price":0,"rate_plan":{"id":"free"}}]}`,
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/user/subscriptions" || r.Header.Get("Authorization") != "Bearer token" {
+					http.Error(w, `{"success":false}`, http.StatusUnauthorized)
+					return
+				}
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			p, err := New("token", provider.WithBaseURL(server.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := p.GetNextPaymentDate(context.Background())
+			if err != nil {
+				t.Fatalf("GetNextPaymentDate: %v", err)
+			}
+			var gotText string
+			if got != nil {
+				gotText = got.Format(time.RFC3339)
+			}
+			if gotText != tt.want {
+				t.Fatalf("next payment date %q, want %q", gotText, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewMissingCredentials(t *testing.T) {
+	if _, err := New(""); !errors.Is(err, provider.ErrMissingCredentials) {
+		t.Errorf("New with an empty API token: %v, want ErrMissingCredentials", err)
+	}
+	if p := NewOrNil(""); p != nil {
+		t.Errorf("NewOrNil with an empty API token returned %v, want nil", p)
+	}
+}
+
+func TestStatusHints(t *testing.T) {
+	tests := []struct {
+		status   int
+		wantHint string
+	}{
+		{status: http.StatusUnauthorized, wantHint: "Cloudflare: 401 Unauthorized — check CLOUDFLARE_API_TOKEN"},
+		{status: http.StatusForbidden, wantHint: "Cloudflare: 403 Forbidden — CLOUDFLARE_API_TOKEN is valid but lacks access to billing, check its permissions"},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "denied", tt.status)
+			}))
+			defer server.Close()
+
+			p, err := New("token", provider.WithBaseURL(server.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = p.GetNextPaymentDate(context.Background())
+			var statusErr *provider.HTTPStatusError
+			if !errors.As(err, &statusErr) || statusErr.Hint != tt.wantHint {
+				t.Fatalf("GetNextPaymentDate: %v, want the hint %q", err, tt.wantHint)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Failure classes matched with errors.Is against provider errors
+var (
+	// ErrTransient marks failures that may go away on their own (timeouts, 429, 5xx) - retry later
+	ErrTransient = errors.New("transient provider failure")
+	// ErrPermanent marks failures that need a configuration fix (400, 401, 403) - check credentials and settings
+	ErrPermanent = errors.New("permanent provider failure")
+)
+
+// HTTPStatusError is returned when a provider API responds with an unexpected HTTP status
+type HTTPStatusError struct {
+	StatusCode int    // HTTP status code
+	Hint       string // Actionable hint for common status codes, can be empty
+	Body       string // Response body
+}
+
+// Error returns the error message, prefixed with the hint when available
+func (e *HTTPStatusError) Error() string {
+	if e.Hint != "" {
+		return fmt.Sprintf("%s (unexpected status code: %d, body: %s)", e.Hint, e.StatusCode, e.Body)
+	}
+	return fmt.Sprintf("unexpected status code: %d, body: %s", e.StatusCode, e.Body)
+}
+
+// Is reports whether the status belongs to the ErrTransient or ErrPermanent failure class
+func (e *HTTPStatusError) Is(target error) bool {
+	return target != nil && target == ClassifyStatus(e.StatusCode)
+}
+
+// ClassifyStatus returns the failure class of an HTTP error status:
+// ErrTransient for 408, 429 and 5xx, ErrPermanent for 400, 401 and 403, nil for anything else
+func ClassifyStatus(statusCode int) error {
+	switch {
+	case statusCode == http.StatusRequestTimeout, statusCode == http.StatusTooManyRequests, statusCode >= http.StatusInternalServerError:
+		return ErrTransient
+	case statusCode == http.StatusBadRequest, statusCode == http.StatusUnauthorized, statusCode == http.StatusForbidden:
+		return ErrPermanent
+	default:
+		return nil
+	}
+}
+
+// StatusHint returns an actionable hint for common HTTP error status codes, or "" if there is none
+// title is a human-readable provider name (e.g. "VDSina"),
+// credentials names the settings holding the provider credentials (e.g. "VDSINA_API_KEY")
+func StatusHint(title string, statusCode int, credentials string) string {
+	switch {
+	case statusCode == http.StatusUnauthorized:
+		return fmt.Sprintf("%s: 401 Unauthorized — check %s", title, credentials)
+	case statusCode == http.StatusForbidden:
+		return fmt.Sprintf("%s: 403 Forbidden — %s is valid but lacks access to billing, check its permissions", title, credentials)
+	case statusCode == http.StatusTooManyRequests:
+		return fmt.Sprintf("%s: 429 Too Many Requests — rate limited, consider a longer check interval", title)
+	case statusCode >= http.StatusInternalServerError:
+		return fmt.Sprintf("%s: %d %s — provider API is unavailable, will retry on the next check", title, statusCode, http.StatusText(statusCode))
+	default:
+		return ""
+	}
+}
+
+// StatusError builds the error for an unexpected HTTP status, with a hint when available
+func StatusError(title string, statusCode int, credentials string, body []byte) error {
+	return &HTTPStatusError{
+		StatusCode: statusCode,
+		Hint:       StatusHint(title, statusCode, credentials),
+		Body:       string(body),
+	}
+}
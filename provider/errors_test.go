@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestClassifyStatus(t *testing.T) {
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{status: http.StatusBadRequest, want: ErrPermanent},
+		{status: http.StatusUnauthorized, want: ErrPermanent},
+		{status: http.StatusForbidden, want: ErrPermanent},
+		{status: http.StatusNotFound, want: nil},
+		{status: http.StatusRequestTimeout, want: ErrTransient},
+		{status: http.StatusTooManyRequests, want: ErrTransient},
+		{status: http.StatusInternalServerError, want: ErrTransient},
+		{status: http.StatusBadGateway, want: ErrTransient},
+		{status: http.StatusServiceUnavailable, want: ErrTransient},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			if got := ClassifyStatus(tt.status); got != tt.want {
+				t.Errorf("ClassifyStatus(%d) = %v, want %v", tt.status, got, tt.want)
+			}
+
+			// Provider errors are matched by class, also when wrapped
+			err := fmt.Errorf("failed to fetch invoices: %w", StatusError("VDSina", tt.status, "VDSINA_API_KEY", nil))
+			if transient := errors.Is(err, ErrTransient); transient != (tt.want == ErrTransient) {
+				t.Errorf("status %d is transient: %v, want %v", tt.status, transient, tt.want == ErrTransient)
+			}
+			if permanent := errors.Is(err, ErrPermanent); permanent != (tt.want == ErrPermanent) {
+				t.Errorf("status %d is permanent: %v, want %v", tt.status, permanent, tt.want == ErrPermanent)
+			}
+		})
+	}
+}
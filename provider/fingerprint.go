@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// Fingerprinter is implemented by providers that can identify the account they are configured for
+// Two providers with the same fingerprint poll the same account
+type Fingerprinter interface {
+	// Fingerprint returns a stable identifier of the provider name and credentials
+	// The credentials themselves must not be recoverable from it
+	Fingerprint() string
+}
+
+// Fingerprint returns the fingerprint of the provider, or an empty string if it does not implement Fingerprinter
+// Wrappers are looked through, so a cached provider has the fingerprint of the wrapped one
+func Fingerprint(p Provider) string {
+	if p == nil {
+		return ""
+	}
+
+	fingerprinter, ok := Unwrap(p).(Fingerprinter)
+	if !ok {
+		return ""
+	}
+	return fingerprinter.Fingerprint()
+}
+
+// CredentialFingerprint hashes the provider name and credentials into a fingerprint
+// Intended for Fingerprinter implementations
+func CredentialFingerprint(name string, credentials ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(append([]string{name}, credentials...), "\x00")))
+	return name + ":" + hex.EncodeToString(sum[:8])
+}
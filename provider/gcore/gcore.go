@@ -0,0 +1,203 @@
+package gcore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+const (
+	gcoreAPIURL = "https://api.gcore.com"
+)
+
+// GcoreProvider implements the Provider interface for Gcore
+type GcoreProvider struct {
+	apiToken string
+	baseURL  string
+	client   *http.Client
+	location *time.Location // Billing time zone of forecast dates
+}
+
+// New creates a new instance of GcoreProvider
+// Returns provider.ErrMissingCredentials if apiToken is empty
+// Supported options: provider.WithLocation (default: UTC), provider.WithBaseURL, provider.WithTLSConfig, provider.WithCertificatePin
+func New(apiToken string, opts ...provider.Option) (provider.Provider, error) {
+	if apiToken == "" {
+		return nil, fmt.Errorf("gcore: api token is empty: %w", provider.ErrMissingCredentials)
+	}
+	options := provider.ApplyOptions(opts)
+	return &GcoreProvider{
+		apiToken: apiToken,
+		baseURL:  options.BaseURLOr(gcoreAPIURL),
+		client:   provider.NewHTTPClient(options),
+		location: options.Location,
+	}, nil
+}
+
+// GetName returns the provider name
+func (g *GcoreProvider) GetName() string {
+	return "gcore"
+}
+
+// IsConfigured checks if the provider is configured
+func (g *GcoreProvider) IsConfigured() bool {
+	return g != nil && g.apiToken != ""
+}
+
+// Fingerprint returns a stable identifier of the provider account
+func (g *GcoreProvider) Fingerprint() string {
+	return provider.CredentialFingerprint(g.GetName(), g.apiToken)
+}
+
+// balanceResponse represents the API response from Gcore for the account balance
+type balanceResponse struct {
+	Balance         float64 `json:"balance"`
+	CurrencyCode    string  `json:"currency_code"`
+	ForecastEndDate *string `json:"forecast_end_date"` // Date the balance runs out at the current spend (nullable)
+}
+
+// errorResponse represents an error response from Gcore API
+type errorResponse struct {
+	Message string `json:"message"`
+}
+
+// GetBalance retrieves the current account balance from Gcore
+func (g *GcoreProvider) GetBalance(ctx context.Context) (provider.Money, error) {
+	balance, err := g.fetchBalance(ctx)
+	if err != nil {
+		return provider.Money{}, fmt.Errorf("failed to fetch balance: %w", err)
+	}
+
+	return provider.Money{Amount: balance.Balance, Currency: balance.CurrencyCode}, nil
+}
+
+// GetNextPaymentDate retrieves the next payment due date from Gcore
+// Returns the forecast depletion date of the balance
+// Returns nil if there is no forecast (the balance covers the current spend), and a past date if the balance is depleted
+func (g *GcoreProvider) GetNextPaymentDate(ctx context.Context) (*time.Time, error) {
+	balance, err := g.fetchBalance(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch balance: %w", err)
+	}
+
+	// Depleted balance - consider payment as overdue (return past date)
+	if balance.Balance <= 0 {
+		pastDate := time.Now().AddDate(0, 0, -1) // Yesterday - overdue
+		return &pastDate, nil
+	}
+
+	// No forecast - the balance is sufficient
+	if balance.ForecastEndDate == nil || *balance.ForecastEndDate == "" {
+		return nil, nil
+	}
+
+	// Parse forecast date (format: "2026-02-20") as midnight in the billing time zone
+	forecastDate, err := time.ParseInLocation("2006-01-02", *balance.ForecastEndDate, g.location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse forecast date: %w", err)
+	}
+
+	return &forecastDate, nil
+}
+
+// makeRequest creates an HTTP request to Gcore API
+// method - HTTP method (GET, POST, etc.)
+// path - API path (e.g., "/billing/v1/balance")
+// queryParams - query parameters, can be nil
+// body - request body for POST requests, can be nil
+func (g *GcoreProvider) makeRequest(ctx context.Context, method, path string, queryParams map[string]string, body io.Reader) (*http.Request, error) {
+	// Build full URL
+	fullURL := g.baseURL + path
+	u, err := url.Parse(fullURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL: %w", err)
+	}
+
+	// Add query parameters if present
+	if len(queryParams) > 0 {
+		q := u.Query()
+		for key, value := range queryParams {
+			q.Set(key, value)
+		}
+		u.RawQuery = q.Encode()
+	}
+
+	// Create request with context
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set headers
+	req.Header.Set("Authorization", "APIKey "+g.apiToken)
+	req.Header.Set("Accept", "application/json")
+
+	// Tag request for tracing
+	provider.SetRequestIDHeader(req)
+
+	return req, nil
+}
+
+// executeRequest executes an HTTP request and returns the response body
+// Returns the response body as bytes or an error if the request fails
+func (g *GcoreProvider) executeRequest(req *http.Request) ([]byte, error) {
+	// Execute request
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Check status code
+	if resp.StatusCode != http.StatusOK {
+		var apiErr errorResponse
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Message != "" {
+			// Report the API error message instead of the raw body
+			body = []byte("API error: " + apiErr.Message)
+		}
+		return nil, provider.StatusError("Gcore", resp.StatusCode, "GCORE_API_TOKEN", body)
+	}
+
+	return body, nil
+}
+
+// FetchRaw performs the primary API call (account balance) and returns the raw response body without parsing
+func (g *GcoreProvider) FetchRaw(ctx context.Context) ([]byte, error) {
+	// Create request to get account balance
+	req, err := g.makeRequest(ctx, "GET", "/billing/v1/balance", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Execute request
+	return g.executeRequest(req)
+}
+
+// fetchBalance fetches the account balance from Gcore API
+func (g *GcoreProvider) fetchBalance(ctx context.Context) (*balanceResponse, error) {
+	// Fetch raw response
+	body, err := g.FetchRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse JSON
+	var apiResponse balanceResponse
+	if err := json.Unmarshal(body, &apiResponse); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	return &apiResponse, nil
+}
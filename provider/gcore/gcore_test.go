@@ -0,0 +1,69 @@
+package gcore
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+func TestGetNextPaymentDate(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    string // Expected date, "" for none
+		overdue bool
+	}{
+		{name: "forecast date", body: `{"balance":120.5,"currency_code":"EUR","forecast_end_date":"2026-12-03"}`, want: "2026-12-03"},
+		{name: "sufficient balance without a forecast", body: `{"balance":120.5,"currency_code":"EUR","forecast_end_date":null}`},
+		{name: "empty forecast", body: `{"balance":120.5,"currency_code":"EUR","forecast_end_date":""}`},
+		{name: "depleted balance", body: `{"balance":0,"currency_code":"EUR","forecast_end_date":null}`, overdue: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/billing/v1/balance" || r.Header.Get("Authorization") != "APIKey token" {
+					http.Error(w, `{"message":"unexpected request"}`, http.StatusNotFound)
+					return
+				}
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			p, err := New("token", provider.WithBaseURL(server.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			date, err := p.GetNextPaymentDate(context.Background())
+			if err != nil {
+				t.Fatalf("GetNextPaymentDate: %v", err)
+			}
+
+			switch {
+			case tt.overdue:
+				if date == nil || !date.Before(time.Now()) {
+					t.Errorf("date %v, want a past date", date)
+				}
+			case tt.want == "":
+				if date != nil {
+					t.Errorf("date %v, want none", date)
+				}
+			default:
+				if date == nil || date.Format(time.DateOnly) != tt.want {
+					t.Errorf("date %v, want %s", date, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewMissingCredentials(t *testing.T) {
+	if _, err := New(""); !errors.Is(err, provider.ErrMissingCredentials) {
+		t.Errorf("New with an empty API token: %v, want ErrMissingCredentials", err)
+	}
+}
@@ -0,0 +1,213 @@
+package hostinger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+const (
+	hostingerAPIURL = "https://developers.hostinger.com"
+)
+
+// HostingerProvider implements the Provider interface for Hostinger
+type HostingerProvider struct {
+	apiToken string
+	baseURL  string
+	client   *http.Client
+}
+
+// New creates a new instance of HostingerProvider
+// Returns provider.ErrMissingCredentials if apiToken is empty
+// Supported options: provider.WithBaseURL, provider.WithTLSConfig, provider.WithCertificatePin
+func New(apiToken string, opts ...provider.Option) (provider.Provider, error) {
+	if apiToken == "" {
+		return nil, fmt.Errorf("hostinger: api token is empty: %w", provider.ErrMissingCredentials)
+	}
+	options := provider.ApplyOptions(opts)
+	return &HostingerProvider{
+		apiToken: apiToken,
+		baseURL:  options.BaseURLOr(hostingerAPIURL),
+		client:   provider.NewHTTPClient(options),
+	}, nil
+}
+
+// GetName returns the provider name
+func (h *HostingerProvider) GetName() string {
+	return "hostinger"
+}
+
+// IsConfigured checks if the provider is configured
+func (h *HostingerProvider) IsConfigured() bool {
+	return h != nil && h.apiToken != ""
+}
+
+// Fingerprint returns a stable identifier of the provider account
+func (h *HostingerProvider) Fingerprint() string {
+	return provider.CredentialFingerprint(h.GetName(), h.apiToken)
+}
+
+// subscription represents a subscription in the Hostinger API response
+type subscription struct {
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	Status        string  `json:"status"`
+	IsAutoRenewed bool    `json:"is_auto_renewed"`
+	ExpiresAt     *string `json:"expires_at"`      // Subscription end (RFC 3339, nullable)
+	NextBillingAt *string `json:"next_billing_at"` // Next renewal charge (RFC 3339, nullable)
+}
+
+// errorResponse represents an error response from Hostinger API
+type errorResponse struct {
+	Message string `json:"message"`
+}
+
+// GetNextPaymentDate retrieves the next payment due date from Hostinger
+// Returns the earliest renewal date among active subscriptions, or nil if no active subscription renews
+// The next billing date is used when present, the expiry date otherwise
+func (h *HostingerProvider) GetNextPaymentDate(ctx context.Context) (*time.Time, error) {
+	dates, err := h.GetCandidateDates(ctx)
+	if err != nil || len(dates) == 0 {
+		return nil, err
+	}
+	return &dates[0], nil
+}
+
+// GetCandidateDates retrieves the renewal dates of all active subscriptions from Hostinger, sorted ascending
+func (h *HostingerProvider) GetCandidateDates(ctx context.Context) ([]time.Time, error) {
+	subscriptions, err := h.fetchSubscriptions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch subscriptions: %w", err)
+	}
+
+	var dates []time.Time
+	for _, sub := range subscriptions {
+		if sub.Status != "active" {
+			continue
+		}
+
+		renewal := sub.NextBillingAt
+		if renewal == nil || *renewal == "" {
+			renewal = sub.ExpiresAt
+		}
+		if renewal == nil || *renewal == "" {
+			continue
+		}
+
+		// Parse renewal date (format: "2026-02-20T10:00:00Z")
+		date, err := time.Parse(time.RFC3339, *renewal)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse renewal date of subscription %s: %w", sub.ID, err)
+		}
+		dates = append(dates, date.UTC())
+	}
+
+	sort.Slice(dates, func(i, j int) bool {
+		return dates[i].Before(dates[j])
+	})
+
+	return dates, nil
+}
+
+// makeRequest creates an HTTP request to Hostinger API
+// method - HTTP method (GET, POST, etc.)
+// path - API path (e.g., "/api/billing/v1/subscriptions")
+// queryParams - query parameters, can be nil
+// body - request body for POST requests, can be nil
+func (h *HostingerProvider) makeRequest(ctx context.Context, method, path string, queryParams map[string]string, body io.Reader) (*http.Request, error) {
+	// Build full URL
+	fullURL := h.baseURL + path
+	u, err := url.Parse(fullURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL: %w", err)
+	}
+
+	// Add query parameters if present
+	if len(queryParams) > 0 {
+		q := u.Query()
+		for key, value := range queryParams {
+			q.Set(key, value)
+		}
+		u.RawQuery = q.Encode()
+	}
+
+	// Create request with context
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set headers
+	req.Header.Set("Authorization", "Bearer "+h.apiToken)
+	req.Header.Set("Accept", "application/json")
+
+	// Tag request for tracing
+	provider.SetRequestIDHeader(req)
+
+	return req, nil
+}
+
+// executeRequest executes an HTTP request and returns the response body
+// Returns the response body as bytes or an error if the request fails
+func (h *HostingerProvider) executeRequest(req *http.Request) ([]byte, error) {
+	// Execute request
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Check status code
+	if resp.StatusCode != http.StatusOK {
+		var apiErr errorResponse
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Message != "" {
+			// Report the API error message instead of the raw body
+			body = []byte("API error: " + apiErr.Message)
+		}
+		return nil, provider.StatusError("Hostinger", resp.StatusCode, "HOSTINGER_API_TOKEN", body)
+	}
+
+	return body, nil
+}
+
+// FetchRaw performs the primary API call (subscription list) and returns the raw response body without parsing
+func (h *HostingerProvider) FetchRaw(ctx context.Context) ([]byte, error) {
+	// Create request to list subscriptions
+	req, err := h.makeRequest(ctx, "GET", "/api/billing/v1/subscriptions", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Execute request
+	return h.executeRequest(req)
+}
+
+// fetchSubscriptions fetches the subscriptions from Hostinger API
+func (h *HostingerProvider) fetchSubscriptions(ctx context.Context) ([]subscription, error) {
+	// Fetch raw response
+	body, err := h.FetchRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse JSON
+	var subscriptions []subscription
+	if err := json.Unmarshal(body, &subscriptions); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	return subscriptions, nil
+}
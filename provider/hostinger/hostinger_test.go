@@ -0,0 +1,120 @@
+package hostinger
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+// newSubscriptionServer returns a test API server answering the subscription list request with body
+func newSubscriptionServer(t *testing.T, body string) string {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/billing/v1/subscriptions" || r.Header.Get("Authorization") != "Bearer token" {
+			http.Error(w, `{"message":"Unauthenticated"}`, http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestGetCandidateDates(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string // Expected renewal dates in RFC 3339, ascending
+	}{
+		{
+			name: "multiple renewal dates",
+			body: `[` +
+				`{"id":"1","name":"VPS","status":"active","is_auto_renewed":true,"expires_at":"2027-01-20T10:00:00Z","next_billing_at":"2026-12-20T10:00:00Z"},` +
+				`{"id":"2","name":"Domain","status":"active","is_auto_renewed":false,"expires_at":"2026-11-05T00:00:00Z","next_billing_at":null},` +
+				`{"id":"3","name":"Email","status":"active","is_auto_renewed":true,"expires_at":"2027-03-01T00:00:00+03:00","next_billing_at":""}` +
+				`]`,
+			want: []string{"2026-11-05T00:00:00Z", "2026-12-20T10:00:00Z", "2027-02-28T21:00:00Z"},
+		},
+		{
+			name: "inactive subscriptions ignored",
+			body: `[` +
+				`{"id":"1","name":"VPS","status":"cancelled","expires_at":"2026-10-20T10:00:00Z","next_billing_at":null},` +
+				`{"id":"2","name":"Web","status":"active","expires_at":"2026-11-30T10:00:00Z","next_billing_at":null}` +
+				`]`,
+			want: []string{"2026-11-30T10:00:00Z"},
+		},
+		{
+			name: "nothing renews",
+			body: `[{"id":"1","name":"VPS","status":"active","expires_at":null,"next_billing_at":null}]`,
+		},
+		{name: "no subscriptions", body: `[]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := New("token", provider.WithBaseURL(newSubscriptionServer(t, tt.body)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			dates, err := p.(provider.CandidateDatesProvider).GetCandidateDates(context.Background())
+			if err != nil {
+				t.Fatalf("GetCandidateDates: %v", err)
+			}
+			var got []string
+			for _, date := range dates {
+				got = append(got, date.Format(time.RFC3339))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("dates %q, want %q", got, tt.want)
+			}
+
+			next, err := p.GetNextPaymentDate(context.Background())
+			if err != nil {
+				t.Fatalf("GetNextPaymentDate: %v", err)
+			}
+			switch {
+			case len(tt.want) == 0 && next != nil:
+				t.Errorf("next date %v, want none", next)
+			case len(tt.want) > 0 && (next == nil || next.Format(time.RFC3339) != tt.want[0]):
+				t.Errorf("next date %v, want the earliest %s", next, tt.want[0])
+			}
+		})
+	}
+}
+
+func TestInvalidRenewalDate(t *testing.T) {
+	body := `[{"id":"1","name":"VPS","status":"active","expires_at":"next month","next_billing_at":null}]`
+	p, err := New("token", provider.WithBaseURL(newSubscriptionServer(t, body)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.GetNextPaymentDate(context.Background()); err == nil {
+		t.Fatal("GetNextPaymentDate succeeded, want a parse error")
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	p, err := New("wrong", provider.WithBaseURL(newSubscriptionServer(t, `[]`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = p.GetNextPaymentDate(context.Background())
+	var statusErr *provider.HTTPStatusError
+	if !errors.As(err, &statusErr) || statusErr.Body != "API error: Unauthenticated" {
+		t.Fatalf("GetNextPaymentDate: %v, want the API error message", err)
+	}
+}
+
+func TestNewMissingCredentials(t *testing.T) {
+	if _, err := New(""); !errors.Is(err, provider.ErrMissingCredentials) {
+		t.Errorf("New with an empty API token: %v, want ErrMissingCredentials", err)
+	}
+}
@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// ErrCertificatePinMismatch is returned when the server certificate does not match any pinned fingerprint
+var ErrCertificatePinMismatch = errors.New("server certificate does not match pinned fingerprint")
+
+// NewHTTPClient creates the HTTP client used by a provider
+// The client has no timeout of its own: requests are bounded only by the deadline of their context,
+// so there is a single source of truth for cancellation (the monitor sets a per-provider deadline)
+// A dedicated transport is created only when TLS settings are present in the options,
+// otherwise the shared transport from the options or the default transport is used
+func NewHTTPClient(o Options) *http.Client {
+	client := &http.Client{}
+	if o.TLSConfig == nil && len(o.CertificatePins) == 0 {
+		if o.Transport != nil {
+			client.Transport = o.Transport
+		}
+		return client
+	}
+
+	tlsConfig := &tls.Config{}
+	if o.TLSConfig != nil {
+		tlsConfig = o.TLSConfig.Clone()
+	}
+	if len(o.CertificatePins) > 0 {
+		tlsConfig.VerifyConnection = pinVerifier(o.CertificatePins, tlsConfig.VerifyConnection)
+	}
+
+	base := http.DefaultTransport.(*http.Transport)
+	if o.Transport != nil {
+		base = o.Transport
+	}
+	transport := base.Clone()
+	transport.TLSClientConfig = tlsConfig
+	client.Transport = transport
+	return client
+}
+
+// pinVerifier returns a VerifyConnection callback that accepts only pinned leaf certificates
+// It runs after standard verification, so pinning adds to the system trust store instead of replacing it
+func pinVerifier(pins []string, next func(tls.ConnectionState) error) func(tls.ConnectionState) error {
+	allowed := make(map[string]bool, len(pins))
+	for _, pin := range pins {
+		allowed[normalizeFingerprint(pin)] = true
+	}
+
+	return func(state tls.ConnectionState) error {
+		if next != nil {
+			if err := next(state); err != nil {
+				return err
+			}
+		}
+		if len(state.PeerCertificates) == 0 {
+			return ErrCertificatePinMismatch
+		}
+		sum := sha256.Sum256(state.PeerCertificates[0].Raw)
+		if !allowed[hex.EncodeToString(sum[:])] {
+			return ErrCertificatePinMismatch
+		}
+		return nil
+	}
+}
+
+// normalizeFingerprint converts "AB:CD:..." or "abcd..." fingerprints to lowercase hex without separators
+func normalizeFingerprint(fingerprint string) string {
+	fingerprint = strings.ReplaceAll(fingerprint, ":", "")
+	fingerprint = strings.ReplaceAll(fingerprint, " ", "")
+	return strings.ToLower(fingerprint)
+}
@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCertificatePin(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	// Trust the test server, so only the pin decides
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+	trusted := &tls.Config{RootCAs: roots}
+
+	sum := sha256.Sum256(server.Certificate().Raw)
+	hexPin := fmt.Sprintf("%x", sum)
+	colonPin := strings.ToUpper(fmt.Sprintf("% x", sum))
+	colonPin = strings.ReplaceAll(colonPin, " ", ":")
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr error // nil for a successful request
+	}{
+		{name: "no pin", opts: []Option{WithTLSConfig(trusted)}},
+		{name: "matching pin", opts: []Option{WithTLSConfig(trusted), WithCertificatePin(hexPin)}},
+		{name: "matching pin with colons", opts: []Option{WithTLSConfig(trusted), WithCertificatePin(colonPin)}},
+		{name: "one of several pins", opts: []Option{WithTLSConfig(trusted), WithCertificatePin(strings.Repeat("00", 32), hexPin)}},
+		{name: "mismatched pin", opts: []Option{WithTLSConfig(trusted), WithCertificatePin(strings.Repeat("00", 32))}, wantErr: ErrCertificatePinMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewHTTPClient(ApplyOptions(tt.opts))
+			resp, err := client.Get(server.URL)
+			if err == nil {
+				resp.Body.Close()
+			}
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("request error %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRequestBoundByContext(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	for _, opts := range [][]Option{nil, {WithTLSConfig(&tls.Config{})}, {WithTransport(&http.Transport{})}} {
+		client := NewHTTPClient(ApplyOptions(opts))
+		if client.Timeout != 0 {
+			t.Errorf("client timeout %v, want none besides the context deadline", client.Timeout)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = client.Do(req)
+		cancel()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("request error %v, want it cancelled by the context deadline", err)
+		}
+	}
+}
+
+func TestSharedTransport(t *testing.T) {
+	shared := &http.Transport{MaxIdleConns: 42}
+
+	if client := NewHTTPClient(Options{}); client.Transport != nil {
+		t.Errorf("transport %v without options, want the default transport", client.Transport)
+	}
+	if client := NewHTTPClient(Options{Transport: shared}); client.Transport != shared {
+		t.Errorf("transport %v, want the shared transport", client.Transport)
+	}
+
+	// TLS settings need a transport of their own, derived from the shared one
+	client := NewHTTPClient(Options{Transport: shared, TLSConfig: &tls.Config{ServerName: "example.com"}})
+	transport, ok := client.Transport.(*http.Transport)
+	if !ok || transport == shared || transport.MaxIdleConns != 42 || transport.TLSClientConfig.ServerName != "example.com" {
+		t.Errorf("transport %+v, want a copy of the shared transport with the TLS settings", client.Transport)
+	}
+}
@@ -2,9 +2,13 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
+// ErrMissingCredentials is returned by provider constructors when required credentials are empty
+var ErrMissingCredentials = errors.New("missing credentials")
+
 // Provider defines the interface for working with VPS providers
 type Provider interface {
 	// GetName returns the provider name
@@ -12,8 +16,53 @@ type Provider interface {
 
 	// GetNextPaymentDate retrieves the next payment due date from the provider
 	// Returns the next payment date or nil if there's no payment due, and an error if something went wrong
+	// Date-only values are returned as midnight in the billing time zone, not converted to UTC,
+	// so they keep their calendar day in any display zone
 	GetNextPaymentDate(ctx context.Context) (*time.Time, error)
 
 	// IsConfigured checks if the provider is configured (credentials provided)
 	IsConfigured() bool
 }
+
+// RawFetcher is implemented by providers that can return the raw response of their primary API call
+// Intended for debugging parsing issues
+type RawFetcher interface {
+	// FetchRaw performs the primary API call and returns the response body without parsing
+	FetchRaw(ctx context.Context) ([]byte, error)
+}
+
+// CandidateDatesProvider is implemented by providers that choose the next payment date
+// among several candidates (e.g. multiple unpaid invoices)
+type CandidateDatesProvider interface {
+	// GetCandidateDates returns all upcoming due dates sorted ascending
+	// The first date is the one GetNextPaymentDate returns, an empty result means no payment is due
+	GetCandidateDates(ctx context.Context) ([]time.Time, error)
+}
+
+// LocationProvider is implemented by providers whose account data tells the time zone
+// the provider's control panel displays dates in
+type LocationProvider interface {
+	// GetAccountLocation returns the display time zone of the provider account
+	GetAccountLocation(ctx context.Context) (*time.Location, error)
+}
+
+// SuspensionReporter is implemented by providers that report whether the service is already suspended,
+// e.g. for non-payment
+type SuspensionReporter interface {
+	// IsSuspended reports whether the service is suspended and, if so, the reason given by the provider
+	IsSuspended(ctx context.Context) (suspended bool, reason string, err error)
+}
+
+// AccountDate is a due date together with the account it belongs to
+type AccountDate struct {
+	Date    time.Time // Payment due date
+	Account string    // ID of the account owning the payment, empty if the provider does not report it
+}
+
+// AccountDatesProvider is implemented by providers that can monitor several accounts
+// (e.g. sub-accounts of a reseller) and report which account each due date belongs to
+type AccountDatesProvider interface {
+	// GetAccountDates returns all upcoming due dates with their accounts sorted ascending by date
+	// The dates are the same GetCandidateDates returns
+	GetAccountDates(ctx context.Context) ([]AccountDate, error)
+}
@@ -0,0 +1,220 @@
+package kamatera
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+const (
+	kamateraAPIURL = "https://console.kamatera.com/service"
+)
+
+// KamateraProvider implements the Provider interface for Kamatera
+type KamateraProvider struct {
+	clientID string
+	secret   string
+	baseURL  string
+	client   *http.Client
+}
+
+// New creates a new instance of KamateraProvider
+// Returns provider.ErrMissingCredentials if clientID or secret is empty
+// Supported options: provider.WithBaseURL, provider.WithTLSConfig, provider.WithCertificatePin
+func New(clientID, secret string, opts ...provider.Option) (provider.Provider, error) {
+	if clientID == "" {
+		return nil, fmt.Errorf("kamatera: client id is empty: %w", provider.ErrMissingCredentials)
+	}
+	if secret == "" {
+		return nil, fmt.Errorf("kamatera: secret is empty: %w", provider.ErrMissingCredentials)
+	}
+	options := provider.ApplyOptions(opts)
+	return &KamateraProvider{
+		clientID: clientID,
+		secret:   secret,
+		baseURL:  options.BaseURLOr(kamateraAPIURL),
+		client:   provider.NewHTTPClient(options),
+	}, nil
+}
+
+// GetName returns the provider name
+func (k *KamateraProvider) GetName() string {
+	return "kamatera"
+}
+
+// IsConfigured checks if the provider is configured
+func (k *KamateraProvider) IsConfigured() bool {
+	return k != nil && k.clientID != "" && k.secret != ""
+}
+
+// Fingerprint returns a stable identifier of the provider account
+func (k *KamateraProvider) Fingerprint() string {
+	return provider.CredentialFingerprint(k.GetName(), k.clientID)
+}
+
+// billingResponse represents the API response from Kamatera for the account billing state
+type billingResponse struct {
+	Balance         float64 `json:"balance"`
+	Currency        string  `json:"currency"`
+	DailyCost       float64 `json:"dailyCost"`
+	Prepaid         bool    `json:"prepaid"`
+	NextBillingDate *string `json:"nextBillingDate"` // Next invoice date of postpaid accounts (YYYY-MM-DD, nullable)
+}
+
+// errorResponse represents an error response from Kamatera API
+type errorResponse struct {
+	Message string `json:"message"`
+}
+
+// GetBalance retrieves the current account balance from Kamatera
+func (k *KamateraProvider) GetBalance(ctx context.Context) (provider.Money, error) {
+	billing, err := k.fetchBilling(ctx)
+	if err != nil {
+		return provider.Money{}, fmt.Errorf("failed to fetch billing: %w", err)
+	}
+
+	return provider.Money{Amount: billing.Balance, Currency: billing.Currency}, nil
+}
+
+// GetNextPaymentDate retrieves the next payment due date from Kamatera
+// Postpaid accounts return the next billing date, prepaid accounts the estimated balance depletion date
+// Returns nil for prepaid accounts that are not billed, and a past date if the prepaid balance is depleted
+func (k *KamateraProvider) GetNextPaymentDate(ctx context.Context) (*time.Time, error) {
+	billing, err := k.fetchBilling(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch billing: %w", err)
+	}
+
+	if !billing.Prepaid {
+		if billing.NextBillingDate == nil || *billing.NextBillingDate == "" {
+			return nil, nil
+		}
+
+		// Parse billing date (format: "2026-02-20")
+		date, err := time.Parse("2006-01-02", *billing.NextBillingDate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse next billing date: %w", err)
+		}
+		return &date, nil
+	}
+
+	// Nothing is billed - the prepaid balance never runs out
+	if billing.DailyCost <= 0 {
+		return nil, nil
+	}
+
+	// Depleted balance - consider payment as overdue (return past date)
+	if billing.Balance <= 0 {
+		pastDate := time.Now().AddDate(0, 0, -1) // Yesterday - overdue
+		return &pastDate, nil
+	}
+
+	// Estimate when the balance runs out at the current daily cost
+	hoursLeft := billing.Balance / billing.DailyCost * 24
+	depletionDate := time.Now().UTC().Add(time.Duration(hoursLeft * float64(time.Hour)))
+
+	return &depletionDate, nil
+}
+
+// makeRequest creates an HTTP request to Kamatera API
+// method - HTTP method (GET, POST, etc.)
+// path - API path (e.g., "/billing")
+// queryParams - query parameters, can be nil
+// body - request body for POST requests, can be nil
+func (k *KamateraProvider) makeRequest(ctx context.Context, method, path string, queryParams map[string]string, body io.Reader) (*http.Request, error) {
+	// Build full URL
+	fullURL := k.baseURL + path
+	u, err := url.Parse(fullURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL: %w", err)
+	}
+
+	// Add query parameters if present
+	if len(queryParams) > 0 {
+		q := u.Query()
+		for key, value := range queryParams {
+			q.Set(key, value)
+		}
+		u.RawQuery = q.Encode()
+	}
+
+	// Create request with context
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set headers
+	req.Header.Set("X-APIKEY-ID", k.clientID)
+	req.Header.Set("X-APIKEY-SECRET", k.secret)
+	req.Header.Set("Accept", "application/json")
+
+	// Tag request for tracing
+	provider.SetRequestIDHeader(req)
+
+	return req, nil
+}
+
+// executeRequest executes an HTTP request and returns the response body
+// Returns the response body as bytes or an error if the request fails
+func (k *KamateraProvider) executeRequest(req *http.Request) ([]byte, error) {
+	// Execute request
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Check status code
+	if resp.StatusCode != http.StatusOK {
+		var apiErr errorResponse
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Message != "" {
+			// Report the API error message instead of the raw body
+			body = []byte("API error: " + apiErr.Message)
+		}
+		return nil, provider.StatusError("Kamatera", resp.StatusCode, "KAMATERA_CLIENT_ID and KAMATERA_SECRET", body)
+	}
+
+	return body, nil
+}
+
+// FetchRaw performs the primary API call (account billing) and returns the raw response body without parsing
+func (k *KamateraProvider) FetchRaw(ctx context.Context) ([]byte, error) {
+	// Create request to get account billing
+	req, err := k.makeRequest(ctx, "GET", "/billing", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Execute request
+	return k.executeRequest(req)
+}
+
+// fetchBilling fetches the account billing state from Kamatera API
+func (k *KamateraProvider) fetchBilling(ctx context.Context) (*billingResponse, error) {
+	// Fetch raw response
+	body, err := k.FetchRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse JSON
+	var apiResponse billingResponse
+	if err := json.Unmarshal(body, &apiResponse); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	return &apiResponse, nil
+}
@@ -0,0 +1,120 @@
+package kamatera
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+// newBillingServer returns a test API server answering the billing request of client "id" with body,
+// and rejecting any other credentials
+func newBillingServer(t *testing.T, body string) string {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/billing" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("X-APIKEY-ID") != "id" || r.Header.Get("X-APIKEY-SECRET") != "secret" {
+			http.Error(w, `{"message":"Authentication failed"}`, http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestGetNextPaymentDate(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		want      string  // Expected date for postpaid accounts
+		wantHours float64 // Expected hours until the depletion of prepaid accounts
+		wantNil   bool
+		wantPast  bool
+	}{
+		{name: "postpaid billing date", body: `{"balance":0,"currency":"USD","dailyCost":1.2,"prepaid":false,"nextBillingDate":"2026-11-01"}`, want: "2026-11-01"},
+		{name: "postpaid without a billing date", body: `{"balance":0,"currency":"USD","dailyCost":1.2,"prepaid":false,"nextBillingDate":null}`, wantNil: true},
+		{name: "prepaid depletion estimate", body: `{"balance":30,"currency":"USD","dailyCost":2,"prepaid":true}`, wantHours: 360},
+		{name: "prepaid not billed", body: `{"balance":30,"currency":"USD","dailyCost":0,"prepaid":true}`, wantNil: true},
+		{name: "prepaid depleted", body: `{"balance":0,"currency":"USD","dailyCost":2,"prepaid":true}`, wantPast: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := New("id", "secret", provider.WithBaseURL(newBillingServer(t, tt.body)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			date, err := p.GetNextPaymentDate(context.Background())
+			if err != nil {
+				t.Fatalf("GetNextPaymentDate: %v", err)
+			}
+
+			switch {
+			case tt.wantNil:
+				if date != nil {
+					t.Errorf("date %v, want none", date)
+				}
+			case tt.wantPast:
+				if date == nil || !date.Before(time.Now()) {
+					t.Errorf("date %v, want a past date", date)
+				}
+			case tt.want != "":
+				if date == nil || date.Format(time.DateOnly) != tt.want {
+					t.Errorf("date %v, want %s", date, tt.want)
+				}
+			default:
+				hours := time.Until(*date).Hours()
+				if hours < tt.wantHours-1 || hours > tt.wantHours {
+					t.Errorf("date %v is %.1f hours ahead, want %.0f", date, hours, tt.wantHours)
+				}
+			}
+		})
+	}
+}
+
+func TestGetBalance(t *testing.T) {
+	p, err := New("id", "secret", provider.WithBaseURL(newBillingServer(t, `{"balance":42.5,"currency":"EUR","dailyCost":1,"prepaid":true}`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	balance, err := p.(provider.BalanceProvider).GetBalance(context.Background())
+	if err != nil {
+		t.Fatalf("GetBalance: %v", err)
+	}
+	if balance != (provider.Money{Amount: 42.5, Currency: "EUR"}) {
+		t.Errorf("balance %v, want 42.50 EUR", balance)
+	}
+}
+
+func TestAuthError(t *testing.T) {
+	p, err := New("id", "wrong", provider.WithBaseURL(newBillingServer(t, `{}`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = p.GetNextPaymentDate(context.Background())
+	var statusErr *provider.HTTPStatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusUnauthorized || statusErr.Body != "API error: Authentication failed" {
+		t.Fatalf("GetNextPaymentDate: %v, want an unauthorized error with the API message", err)
+	}
+	if !errors.Is(err, provider.ErrPermanent) {
+		t.Errorf("GetNextPaymentDate: %v, want a permanent failure", err)
+	}
+}
+
+func TestNewMissingCredentials(t *testing.T) {
+	for _, credentials := range [][2]string{{"", "secret"}, {"id", ""}} {
+		if _, err := New(credentials[0], credentials[1]); !errors.Is(err, provider.ErrMissingCredentials) {
+			t.Errorf("New(%q, %q): %v, want ErrMissingCredentials", credentials[0], credentials[1], err)
+		}
+	}
+}
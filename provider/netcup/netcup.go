@@ -0,0 +1,321 @@
+package netcup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+const (
+	netcupAPIURL = "https://ccp.netcup.net/run/webservice/servers/endpoint.php?JSON"
+
+	// statusCodeSuccess is the statuscode of a successful API response
+	statusCodeSuccess = 2000
+	// statusCodeInvalidSession is the statuscode returned for an expired or unknown session
+	statusCodeInvalidSession = 4001
+)
+
+// errSessionExpired is returned when the API session must be renewed
+var errSessionExpired = errors.New("api session expired")
+
+// NetcupProvider implements the Provider interface for Netcup (CCP API)
+type NetcupProvider struct {
+	customerNumber string
+	apiKey         string
+	apiPassword    string
+	baseURL        string
+	client         *http.Client
+	location       *time.Location // Billing time zone of invoice dates
+
+	mu        sync.Mutex // Protects sessionID
+	sessionID string     // API session of the last login, empty before the first login
+}
+
+// New creates a new instance of NetcupProvider
+// Returns provider.ErrMissingCredentials if customerNumber, apiKey or apiPassword is empty
+// Supported options: provider.WithLocation (default: UTC), provider.WithBaseURL, provider.WithTLSConfig, provider.WithCertificatePin
+func New(customerNumber, apiKey, apiPassword string, opts ...provider.Option) (provider.Provider, error) {
+	if customerNumber == "" {
+		return nil, fmt.Errorf("netcup: customer number is empty: %w", provider.ErrMissingCredentials)
+	}
+	if apiKey == "" {
+		return nil, fmt.Errorf("netcup: api key is empty: %w", provider.ErrMissingCredentials)
+	}
+	if apiPassword == "" {
+		return nil, fmt.Errorf("netcup: api password is empty: %w", provider.ErrMissingCredentials)
+	}
+	options := provider.ApplyOptions(opts)
+	return &NetcupProvider{
+		customerNumber: customerNumber,
+		apiKey:         apiKey,
+		apiPassword:    apiPassword,
+		baseURL:        options.BaseURLOr(netcupAPIURL),
+		client:         provider.NewHTTPClient(options),
+		location:       options.Location,
+	}, nil
+}
+
+// GetName returns the provider name
+func (n *NetcupProvider) GetName() string {
+	return "netcup"
+}
+
+// IsConfigured checks if the provider is configured
+func (n *NetcupProvider) IsConfigured() bool {
+	return n != nil && n.customerNumber != "" && n.apiKey != "" && n.apiPassword != ""
+}
+
+// Fingerprint returns a stable identifier of the provider account
+func (n *NetcupProvider) Fingerprint() string {
+	return provider.CredentialFingerprint(n.GetName(), n.customerNumber, n.apiKey)
+}
+
+// apiRequest represents a request to the Netcup API
+type apiRequest struct {
+	Action string         `json:"action"`
+	Param  map[string]any `json:"param"`
+}
+
+// apiResponse represents the envelope of every Netcup API response
+type apiResponse struct {
+	Status       string          `json:"status"`
+	StatusCode   int             `json:"statuscode"`
+	ShortMessage string          `json:"shortmessage"`
+	LongMessage  string          `json:"longmessage"`
+	ResponseData json.RawMessage `json:"responsedata"`
+}
+
+// loginData represents the response data of the login action
+type loginData struct {
+	APISessionID string `json:"apisessionid"`
+}
+
+// invoice represents an invoice in the Netcup API response
+type invoice struct {
+	InvoiceNumber string `json:"invoicenumber"`
+	DueDate       string `json:"duedate"` // Format: "2026-02-20"
+	Paid          bool   `json:"paid"`
+}
+
+// GetNextPaymentDate retrieves the next payment due date from Netcup
+// Returns the earliest due date of the unpaid invoices, or nil if all invoices are paid
+func (n *NetcupProvider) GetNextPaymentDate(ctx context.Context) (*time.Time, error) {
+	invoices, err := n.fetchInvoices(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch invoices: %w", err)
+	}
+
+	var dueDates []time.Time
+	for _, inv := range invoices {
+		if inv.Paid {
+			continue
+		}
+
+		// Parse due date (format: "2026-02-20") as midnight in the billing time zone
+		dueDate, err := time.ParseInLocation("2006-01-02", inv.DueDate, n.location)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse due date of invoice %s: %w", inv.InvoiceNumber, err)
+		}
+		dueDates = append(dueDates, dueDate)
+	}
+
+	// No unpaid invoices - no payment due
+	if len(dueDates) == 0 {
+		return nil, nil
+	}
+
+	sort.Slice(dueDates, func(i, j int) bool {
+		return dueDates[i].Before(dueDates[j])
+	})
+	return &dueDates[0], nil
+}
+
+// makeRequest creates an HTTP request for an action of the Netcup API
+// action - API action (e.g., "login")
+// params - action parameters, the credentials are added automatically
+func (n *NetcupProvider) makeRequest(ctx context.Context, action string, params map[string]any) (*http.Request, error) {
+	// Every action is authenticated by the customer number and the API key
+	param := map[string]any{
+		"customernumber": n.customerNumber,
+		"apikey":         n.apiKey,
+	}
+	for key, value := range params {
+		param[key] = value
+	}
+
+	body, err := json.Marshal(apiRequest{Action: action, Param: param})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	// Create request with context
+	req, err := http.NewRequestWithContext(ctx, "POST", n.baseURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set headers
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	// Tag request for tracing
+	provider.SetRequestIDHeader(req)
+
+	return req, nil
+}
+
+// executeRequest executes an HTTP request and returns the response body
+// Returns errSessionExpired if the API rejected the session, and an error for any other failed action
+func (n *NetcupProvider) executeRequest(req *http.Request) ([]byte, error) {
+	// Execute request
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Check status code
+	if resp.StatusCode != http.StatusOK {
+		return nil, provider.StatusError("Netcup", resp.StatusCode, "NETCUP_API_KEY and NETCUP_API_PASSWORD", body)
+	}
+
+	// The API reports action failures in the response envelope
+	var envelope apiResponse
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	switch envelope.StatusCode {
+	case statusCodeSuccess:
+		return body, nil
+	case statusCodeInvalidSession:
+		return nil, errSessionExpired
+	default:
+		return nil, fmt.Errorf("API error %d: %s %s", envelope.StatusCode, envelope.ShortMessage, envelope.LongMessage)
+	}
+}
+
+// login starts a new API session and returns its ID
+func (n *NetcupProvider) login(ctx context.Context) (string, error) {
+	req, err := n.makeRequest(ctx, "login", map[string]any{"apipassword": n.apiPassword})
+	if err != nil {
+		return "", err
+	}
+
+	body, err := n.executeRequest(req)
+	if err != nil {
+		return "", fmt.Errorf("login failed: %w", err)
+	}
+
+	var envelope apiResponse
+	var data loginData
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return "", fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	if err := json.Unmarshal(envelope.ResponseData, &data); err != nil {
+		return "", fmt.Errorf("failed to parse login response: %w", err)
+	}
+	if data.APISessionID == "" {
+		return "", errors.New("login failed: no session id in response")
+	}
+
+	return data.APISessionID, nil
+}
+
+// session returns the current API session, logging in if there is none
+func (n *NetcupProvider) session(ctx context.Context) (string, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.sessionID == "" {
+		sessionID, err := n.login(ctx)
+		if err != nil {
+			return "", err
+		}
+		n.sessionID = sessionID
+	}
+	return n.sessionID, nil
+}
+
+// invalidateSession forgets the session so the next call logs in again
+// Sessions replaced by a concurrent login in the meantime are kept
+func (n *NetcupProvider) invalidateSession(sessionID string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.sessionID == sessionID {
+		n.sessionID = ""
+	}
+}
+
+// call executes an action within the API session and returns the raw response body
+// An expired session is renewed once and the action is retried
+func (n *NetcupProvider) call(ctx context.Context, action string, params map[string]any) ([]byte, error) {
+	for attempt := 0; ; attempt++ {
+		sessionID, err := n.session(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		sessionParams := map[string]any{"apisessionid": sessionID}
+		for key, value := range params {
+			sessionParams[key] = value
+		}
+		req, err := n.makeRequest(ctx, action, sessionParams)
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := n.executeRequest(req)
+		if errors.Is(err, errSessionExpired) && attempt == 0 {
+			n.invalidateSession(sessionID)
+			continue
+		}
+		return body, err
+	}
+}
+
+// FetchRaw performs the primary API call (invoice list) and returns the raw response body without parsing
+func (n *NetcupProvider) FetchRaw(ctx context.Context) ([]byte, error) {
+	return n.call(ctx, "listInvoices", nil)
+}
+
+// fetchInvoices fetches the invoices from Netcup API
+func (n *NetcupProvider) fetchInvoices(ctx context.Context) ([]invoice, error) {
+	// Fetch raw response
+	body, err := n.FetchRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse JSON
+	var envelope apiResponse
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	// Accounts without invoices have no response data
+	var invoices []invoice
+	if len(envelope.ResponseData) == 0 || string(envelope.ResponseData) == "null" || string(envelope.ResponseData) == `""` {
+		return invoices, nil
+	}
+	if err := json.Unmarshal(envelope.ResponseData, &invoices); err != nil {
+		return nil, fmt.Errorf("failed to parse invoices: %w", err)
+	}
+
+	return invoices, nil
+}
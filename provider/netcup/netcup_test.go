@@ -0,0 +1,140 @@
+package netcup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeCCP is a test double of the Netcup CCP API issuing sessions that can be expired on demand
+type fakeCCP struct {
+	mu       sync.Mutex
+	logins   int
+	sessions map[string]bool // Valid session IDs
+	invoices string          // Response data of listInvoices
+}
+
+func (f *fakeCCP) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req apiRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if req.Param["customernumber"] != "12345" || req.Param["apikey"] != "key" {
+		fmt.Fprint(w, `{"status":"error","statuscode":4013,"shortmessage":"Validation Error."}`)
+		return
+	}
+	switch req.Action {
+	case "login":
+		if req.Param["apipassword"] != "password" {
+			fmt.Fprint(w, `{"status":"error","statuscode":4013,"shortmessage":"Validation Error."}`)
+			return
+		}
+		f.logins++
+		sessionID := fmt.Sprintf("session-%d", f.logins)
+		f.sessions[sessionID] = true
+		fmt.Fprintf(w, `{"status":"success","statuscode":2000,"responsedata":{"apisessionid":%q}}`, sessionID)
+	case "listInvoices":
+		sessionID, _ := req.Param["apisessionid"].(string)
+		if !f.sessions[sessionID] {
+			fmt.Fprint(w, `{"status":"error","statuscode":4001,"shortmessage":"The session id is not in a valid format."}`)
+			return
+		}
+		fmt.Fprintf(w, `{"status":"success","statuscode":2000,"responsedata":%s}`, f.invoices)
+	default:
+		http.Error(w, "unknown action", http.StatusBadRequest)
+	}
+}
+
+// expireSessions invalidates every issued session, as the API does after a timeout
+func (f *fakeCCP) expireSessions() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	clear(f.sessions)
+}
+
+// loginCount returns how often the provider logged in
+func (f *fakeCCP) loginCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.logins
+}
+
+func newFakeCCP(t *testing.T) (*fakeCCP, *NetcupProvider) {
+	t.Helper()
+
+	ccp := &fakeCCP{
+		sessions: make(map[string]bool),
+		invoices: `[{"invoicenumber":"R1","duedate":"2026-10-01","paid":true},` +
+			`{"invoicenumber":"R2","duedate":"2026-11-20","paid":false},` +
+			`{"invoicenumber":"R3","duedate":"2026-11-05","paid":false}]`,
+	}
+	server := httptest.NewServer(ccp)
+	t.Cleanup(server.Close)
+
+	return ccp, &NetcupProvider{
+		customerNumber: "12345",
+		apiKey:         "key",
+		apiPassword:    "password",
+		baseURL:        server.URL,
+		client:         server.Client(),
+		location:       time.UTC,
+	}
+}
+
+func TestGetNextPaymentDate(t *testing.T) {
+	ccp, n := newFakeCCP(t)
+	want := time.Date(2026, 11, 5, 0, 0, 0, 0, time.UTC)
+
+	check := func(wantLogins int) {
+		t.Helper()
+		date, err := n.GetNextPaymentDate(context.Background())
+		if err != nil {
+			t.Fatalf("GetNextPaymentDate: %v", err)
+		}
+		if date == nil || !date.Equal(want) {
+			t.Fatalf("next payment date %v, want the earliest unpaid invoice %v", date, want)
+		}
+		if got := ccp.loginCount(); got != wantLogins {
+			t.Fatalf("%d logins, want %d", got, wantLogins)
+		}
+	}
+
+	check(1)
+	// The session is reused while it is valid
+	check(1)
+	// An expired session is renewed once and the invoice list requested again
+	ccp.expireSessions()
+	check(2)
+}
+
+func TestGetNextPaymentDateNoUnpaidInvoices(t *testing.T) {
+	ccp, n := newFakeCCP(t)
+	ccp.invoices = `[{"invoicenumber":"R1","duedate":"2026-10-01","paid":true}]`
+
+	date, err := n.GetNextPaymentDate(context.Background())
+	if err != nil {
+		t.Fatalf("GetNextPaymentDate: %v", err)
+	}
+	if date != nil {
+		t.Fatalf("next payment date %v, want none", date)
+	}
+}
+
+func TestGetNextPaymentDateLoginFailure(t *testing.T) {
+	_, n := newFakeCCP(t)
+	n.apiPassword = "wrong"
+
+	if _, err := n.GetNextPaymentDate(context.Background()); err == nil {
+		t.Fatal("GetNextPaymentDate succeeded with a wrong API password")
+	}
+}
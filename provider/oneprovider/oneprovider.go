@@ -3,11 +3,15 @@ package oneprovider
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/custom-app/NeverForgetVPS/provider"
@@ -20,22 +24,54 @@ const (
 
 // OneProvider implements the Provider interface for OneProvider
 type OneProvider struct {
-	apiKey    string
-	clientKey string
-	client    *http.Client
+	apiKey      string
+	clientKey   string
+	baseURL     string
+	client      *http.Client
+	location    *time.Location // Billing time zone of invoice due dates
+	outstanding []string       // Invoice statuses counted as unpaid
+	dateFormat  string         // Layout of the account date format, empty means detection
+	logger      *slog.Logger   // Receives invoices skipped because of invalid fields
+	subAccounts []string       // Client IDs of sub-accounts whose invoices are included
 }
 
 // New creates a new instance of OneProvider
-// If apiKey or clientKey is empty, the provider is considered not configured
-func New(apiKey, clientKey string) provider.Provider {
-	if apiKey == "" || clientKey == "" {
-		return nil
+// Returns provider.ErrMissingCredentials if apiKey or clientKey is empty
+// Supported options: provider.WithLocation (default: UTC), provider.WithBaseURL, provider.WithTLSConfig, provider.WithCertificatePin, provider.WithLogger,
+// provider.WithOutstandingStatuses (default: provider.DefaultOutstandingStatuses),
+// provider.WithDateFormat (default: ISO dates, and DD/MM/YYYY or MM/DD/YYYY told apart by the day and month ranges),
+// provider.WithSubAccounts (default: own account only)
+func New(apiKey, clientKey string, opts ...provider.Option) (provider.Provider, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("oneprovider: api key is empty: %w", provider.ErrMissingCredentials)
+	}
+	if clientKey == "" {
+		return nil, fmt.Errorf("oneprovider: client key is empty: %w", provider.ErrMissingCredentials)
 	}
+	options := provider.ApplyOptions(opts)
 	return &OneProvider{
-		apiKey:    apiKey,
-		clientKey: clientKey,
-		client:    &http.Client{Timeout: 30 * time.Second},
+		apiKey:      apiKey,
+		clientKey:   clientKey,
+		baseURL:     options.BaseURLOr(oneProviderAPIURL),
+		client:      provider.NewHTTPClient(options),
+		location:    options.Location,
+		outstanding: options.OutstandingStatusesOr(provider.DefaultOutstandingStatuses),
+		dateFormat:  options.DateFormat,
+		logger:      options.Logger,
+		subAccounts: options.SubAccounts,
+	}, nil
+}
+
+// NewOrNil creates a new instance of OneProvider
+// If apiKey or clientKey is empty, the provider is considered not configured and nil is returned
+//
+// Deprecated: use New, which reports missing credentials explicitly
+func NewOrNil(apiKey, clientKey string) provider.Provider {
+	p, err := New(apiKey, clientKey)
+	if err != nil {
+		return nil
 	}
+	return p
 }
 
 // GetName returns the provider name
@@ -48,25 +84,27 @@ func (o *OneProvider) IsConfigured() bool {
 	return o != nil && o.apiKey != "" && o.clientKey != ""
 }
 
-// invoiceResponse represents the API response from OneProvider for invoice list
-type invoiceResponse struct {
-	Result   string `json:"result"`
-	Response struct {
-		CurrentPage          int64     `json:"current_page"`
-		TotalPages           int64     `json:"total_pages"`
-		NumberOfEntries      int64     `json:"number_of_entries"`
-		TotalNumberOfEntries int64     `json:"total_number_of_entries"`
-		Invoices             []invoice `json:"invoices"`
-	} `json:"response"`
-	Error *struct {
-		Message string `json:"message"`
-		Code    string `json:"code"`
-	} `json:"error,omitempty"`
+// Fingerprint returns a stable identifier of the provider account
+func (o *OneProvider) Fingerprint() string {
+	return provider.CredentialFingerprint(o.GetName(), o.apiKey, o.clientKey)
+}
+
+// The API response from OneProvider for invoice list has the form
+//
+//	{"result": "...", "response": {"current_page": 1, "total_pages": 1, "invoices": [...]}, "error": {...}}
+//
+// and is decoded incrementally by decodeInvoiceResponse
+
+// apiError represents an error reported in the API response from OneProvider
+type apiError struct {
+	Message string `json:"message"`
+	Code    string `json:"code"`
 }
 
 // invoice represents an invoice from OneProvider API
 type invoice struct {
 	ID           string        `json:"id"`
+	ClientID     string        `json:"client_id"`
 	Status       string        `json:"status"`
 	CreationDate string        `json:"creation_date"`
 	DueDate      string        `json:"due_date"`
@@ -79,6 +117,20 @@ type invoice struct {
 	Items        []invoiceItem `json:"items"`
 }
 
+// isSettled reports whether the invoice balance shows nothing left to pay
+// A missing balance is not considered settled, so the invoice status decides
+func (i invoice) isSettled() (bool, error) {
+	if i.Balance == "" {
+		return false, nil
+	}
+
+	balance, err := strconv.ParseFloat(i.Balance, 64)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse balance: %w", err)
+	}
+	return balance <= 0, nil
+}
+
 // invoiceItem represents an invoice item
 type invoiceItem struct {
 	ID             string `json:"id"`
@@ -91,33 +143,167 @@ type invoiceItem struct {
 // GetNextPaymentDate retrieves the next payment due date from OneProvider
 // Returns the earliest due date from unpaid invoices, or nil if there are no unpaid invoices
 func (o *OneProvider) GetNextPaymentDate(ctx context.Context) (*time.Time, error) {
-	page := 1
-	limit := 20 // Number of invoices per page
-
-	invoices, _, err := o.fetchinvoicesPage(ctx, page, limit)
+	dates, err := o.GetCandidateDates(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch invoices: %w", err)
+		return nil, err
 	}
 
-	// Find the earliest due date from unpaid invoices
-	if len(invoices) == 0 {
+	if len(dates) == 0 {
 		return nil, nil
 	}
+	return &dates[0], nil
+}
 
-	var earliestDate *time.Time
-	for _, invoice := range invoices {
-		if invoice.Status == "Unpaid" && invoice.DueDate != "" {
-			dueDate, err := time.Parse("2006-01-02", invoice.DueDate)
+// GetCandidateDates retrieves the due dates of all unpaid invoices from OneProvider
+// Returns the dates sorted ascending, the first one is the next payment date
+func (o *OneProvider) GetCandidateDates(ctx context.Context) ([]time.Time, error) {
+	accounts, err := o.GetAccountDates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var dates []time.Time
+	for _, account := range accounts {
+		dates = append(dates, account.Date)
+	}
+	return dates, nil
+}
+
+// GetAccountDates retrieves the due dates of all unpaid invoices from OneProvider with the owning client ID
+// Invoices of every outstanding status are requested, see provider.WithOutstandingStatuses; the API filters
+// by a single status, so each status costs one request, and an invoice returned for several statuses counts once
+// Sub-accounts configured with provider.WithSubAccounts are requested one by one with the API's client filter,
+// each date is reported with the client ID of the account owing the invoice
+// Invoices with a zero balance are considered paid even if their status still says "Unpaid"
+// Invoices with an invalid balance or due date are skipped and logged one by one (see provider.WithLogger);
+// the check fails only if no invoice could be used, reporting all invalid invoices together
+func (o *OneProvider) GetAccountDates(ctx context.Context) ([]provider.AccountDate, error) {
+	page := 1
+	limit := 20 // Number of invoices per page
+
+	var invoices []invoice
+	seen := make(map[string]bool)
+	// The own account is requested without a client filter
+	for _, clientID := range append([]string{""}, o.subAccounts...) {
+		for _, status := range o.outstanding {
+			statusInvoices, _, err := o.fetchinvoicesPage(ctx, clientID, status, page, limit)
 			if err != nil {
-				return nil, fmt.Errorf("failed to parse due date: %w", err)
+				if clientID != "" {
+					return nil, fmt.Errorf("failed to fetch invoices of sub-account %s: %w", clientID, err)
+				}
+				return nil, fmt.Errorf("failed to fetch invoices: %w", err)
 			}
-			if earliestDate == nil || dueDate.Before(*earliestDate) {
-				earliestDate = &dueDate
+			for _, inv := range statusInvoices {
+				if inv.ID != "" && seen[inv.ID] {
+					continue
+				}
+				seen[inv.ID] = true
+				invoices = append(invoices, inv)
 			}
 		}
 	}
 
-	return earliestDate, nil
+	layout := o.dateLayout(invoices)
+	var dates []provider.AccountDate
+	var errs []error
+	for _, invoice := range invoices {
+		if !provider.IsOutstanding(invoice.Status, o.outstanding) || invoice.DueDate == "" {
+			continue
+		}
+
+		settled, err := invoice.isSettled()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invoice %s: %w", invoice.ID, err))
+			continue
+		}
+		if settled {
+			continue
+		}
+
+		dueDate, err := o.parseDueDate(invoice.DueDate, layout)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invoice %s: failed to parse due date: %w", invoice.ID, err))
+			continue
+		}
+		dates = append(dates, provider.AccountDate{Date: dueDate, Account: invoice.ClientID})
+	}
+
+	// One bad invoice must not hide the others, fail only when nothing usable is left
+	for _, err := range errs {
+		o.logger.Warn("invoice skipped", slog.Any("error", err))
+	}
+	if len(errs) > 0 && len(dates) == 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	sort.SliceStable(dates, func(i, j int) bool {
+		return dates[i].Date.Before(dates[j].Date)
+	})
+
+	return dates, nil
+}
+
+// Layouts of the slash-separated display date formats OneProvider accounts can use
+const (
+	dayFirstLayout   = "02/01/2006" // DD/MM/YYYY
+	monthFirstLayout = "01/02/2006" // MM/DD/YYYY
+)
+
+// dateLayout returns the layout of the account display format used by the due dates
+// The configured format wins; otherwise the format is inferred from the dates valid in only one of
+// DD/MM/YYYY and MM/DD/YYYY, since an account displays all its dates alike
+// Returns an empty layout if the format is not configured and the dates do not settle it
+func (o *OneProvider) dateLayout(invoices []invoice) string {
+	if o.dateFormat != "" {
+		return o.dateFormat
+	}
+
+	var dayFirstOnly, monthFirstOnly bool
+	for _, invoice := range invoices {
+		_, dayFirstErr := time.Parse(dayFirstLayout, invoice.DueDate)
+		_, monthFirstErr := time.Parse(monthFirstLayout, invoice.DueDate)
+		switch {
+		case dayFirstErr == nil && monthFirstErr != nil:
+			dayFirstOnly = true
+		case dayFirstErr != nil && monthFirstErr == nil:
+			monthFirstOnly = true
+		}
+	}
+
+	switch {
+	case dayFirstOnly && !monthFirstOnly:
+		return dayFirstLayout
+	case monthFirstOnly && !dayFirstOnly:
+		return monthFirstLayout
+	default:
+		return ""
+	}
+}
+
+// parseDueDate parses an invoice due date as midnight in the billing time zone
+// ISO dates ("2006-01-02") are always accepted. Dates in the account display format are parsed
+// with the given layout (see dateLayout), or otherwise as DD/MM/YYYY or MM/DD/YYYY, whichever has valid
+// day and month values; dates valid in both formats with different meanings are rejected as ambiguous
+func (o *OneProvider) parseDueDate(value, layout string) (time.Time, error) {
+	if date, err := time.ParseInLocation("2006-01-02", value, o.location); err == nil {
+		return date, nil
+	}
+	if layout != "" {
+		return time.ParseInLocation(layout, value, o.location)
+	}
+
+	dayFirst, dayFirstErr := time.ParseInLocation(dayFirstLayout, value, o.location)
+	monthFirst, monthFirstErr := time.ParseInLocation(monthFirstLayout, value, o.location)
+	switch {
+	case dayFirstErr == nil && monthFirstErr == nil && !dayFirst.Equal(monthFirst):
+		return time.Time{}, fmt.Errorf("ambiguous date %q, configure the account date format with provider.WithDateFormat", value)
+	case dayFirstErr == nil:
+		return dayFirst, nil
+	case monthFirstErr == nil:
+		return monthFirst, nil
+	default:
+		return time.Time{}, fmt.Errorf("unsupported date format: %q", value)
+	}
 }
 
 // makeRequest creates an HTTP request to OneProvider API
@@ -127,7 +313,7 @@ func (o *OneProvider) GetNextPaymentDate(ctx context.Context) (*time.Time, error
 // body - request body for POST requests, can be nil
 func (o *OneProvider) makeRequest(ctx context.Context, method, path string, queryParams map[string]string, body io.Reader) (*http.Request, error) {
 	// Build full URL
-	fullURL := oneProviderAPIURL + path
+	fullURL := o.baseURL + path
 	u, err := url.Parse(fullURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse URL: %w", err)
@@ -153,6 +339,9 @@ func (o *OneProvider) makeRequest(ctx context.Context, method, path string, quer
 	req.Header.Set("Client-Key", o.clientKey)
 	req.Header.Set("User-Agent", userAgent)
 
+	// Tag request for tracing
+	provider.SetRequestIDHeader(req)
+
 	return req, nil
 }
 
@@ -160,57 +349,245 @@ func (o *OneProvider) makeRequest(ctx context.Context, method, path string, quer
 // Returns the response body as bytes or an error if the request fails
 func (o *OneProvider) executeRequest(req *http.Request) ([]byte, error) {
 	// Execute request
-	resp, err := o.client.Do(req)
+	respBody, err := o.executeStreamingRequest(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
+	defer respBody.Close()
 
 	// Read response body
-	body, err := io.ReadAll(resp.Body)
+	body, err := io.ReadAll(respBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
+	return body, nil
+}
+
+// executeStreamingRequest executes an HTTP request and returns the unread response body
+// The caller must close the body; error responses are read completely and reported as errors
+func (o *OneProvider) executeStreamingRequest(req *http.Request) (io.ReadCloser, error) {
+	// Execute request
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+
 	// Check status code
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response: %w", err)
+		}
+		return nil, provider.StatusError("OneProvider", resp.StatusCode, "ONEPROVIDER_API_KEY and ONEPROVIDER_CLIENT_KEY", body)
 	}
 
-	return body, nil
+	return resp.Body, nil
+}
+
+// FetchRaw performs the primary API calls (first page of invoices for every outstanding status)
+// and returns the raw response bodies without parsing, as a JSON object keyed by status
+func (o *OneProvider) FetchRaw(ctx context.Context) ([]byte, error) {
+	bodies := make(map[string]json.RawMessage, len(o.outstanding))
+	for _, status := range o.outstanding {
+		body, err := o.fetchRawInvoicesPage(ctx, status, 1, 20)
+		if err != nil {
+			return nil, err
+		}
+		if !json.Valid(body) {
+			// Keep a non-JSON body readable instead of failing the debug call
+			body, _ = json.Marshal(string(body))
+		}
+		bodies[status] = body
+	}
+	return json.Marshal(bodies)
 }
 
-// fetchinvoicesPage fetches one page of invoices
-func (o *OneProvider) fetchinvoicesPage(ctx context.Context, page, limit int) ([]invoice, int, error) {
+// fetchRawInvoicesPage fetches one page of the own account's invoices with the given status without parsing
+func (o *OneProvider) fetchRawInvoicesPage(ctx context.Context, status string, page, limit int) ([]byte, error) {
+	// Create request
+	req, err := o.makeInvoicesRequest(ctx, "", status, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Execute request
+	return o.executeRequest(req)
+}
+
+// makeInvoicesRequest creates the request for one page of invoices with the given status
+// clientID filters the invoices of a sub-account, empty means the own account
+func (o *OneProvider) makeInvoicesRequest(ctx context.Context, clientID, status string, page, limit int) (*http.Request, error) {
 	// Build query parameters
 	queryParams := map[string]string{
-		"status": "Unpaid",
+		"status": status,
 		"page":   strconv.Itoa(page),
 		"limit":  strconv.Itoa(limit),
 	}
+	if clientID != "" {
+		queryParams["client_id"] = clientID
+	}
 
 	// Create request
-	req, err := o.makeRequest(ctx, "GET", "/invoices", queryParams, nil)
+	return o.makeRequest(ctx, "GET", "/invoices", queryParams, nil)
+}
+
+// fetchinvoicesPage fetches one page of invoices with the given status
+// The response is decoded while it is read, so large pages are never buffered as a whole
+func (o *OneProvider) fetchinvoicesPage(ctx context.Context, clientID, status string, page, limit int) ([]invoice, int, error) {
+	// Create request
+	req, err := o.makeInvoicesRequest(ctx, clientID, status, page, limit)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, fmt.Errorf("failed to execute request: %w", err)
 	}
 
 	// Execute request
-	resp, err := o.executeRequest(req)
+	body, err := o.executeStreamingRequest(req)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to execute request: %w", err)
 	}
+	defer body.Close()
 
 	// Parse JSON
-	var apiResponse invoiceResponse
-	if err := json.Unmarshal(resp, &apiResponse); err != nil {
+	invoices, totalPages, apiErr, err := decodeInvoiceResponse(body)
+	if err != nil {
 		return nil, 0, fmt.Errorf("failed to parse JSON: %w", err)
 	}
 
 	// Check for API error
-	if apiResponse.Error != nil {
-		return nil, 0, fmt.Errorf("API error: %s (code: %s)", apiResponse.Error.Message, apiResponse.Error.Code)
+	if apiErr != nil {
+		return nil, 0, fmt.Errorf("API error: %s (code: %s)", apiErr.Message, apiErr.Code)
+	}
+
+	return invoices, int(totalPages), nil
+}
+
+// decodeInvoiceResponse decodes an invoice list response token by token, one invoice at a time
+// Unknown fields are skipped
+func decodeInvoiceResponse(r io.Reader) ([]invoice, int64, *apiError, error) {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, 0, nil, err
+	}
+
+	var invoices []invoice
+	var totalPages int64
+	var apiErr *apiError
+	for dec.More() {
+		key, err := decodeKey(dec)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+
+		switch {
+		case strings.EqualFold(key, "response"):
+			invoices, totalPages, err = decodeInvoicePage(dec)
+		case strings.EqualFold(key, "error"):
+			err = dec.Decode(&apiErr)
+		default:
+			err = skipValue(dec)
+		}
+		if err != nil {
+			return nil, 0, nil, err
+		}
+	}
+
+	return invoices, totalPages, apiErr, expectDelim(dec, '}')
+}
+
+// decodeInvoicePage decodes the "response" object of an invoice list response
+func decodeInvoicePage(dec *json.Decoder) ([]invoice, int64, error) {
+	// The object may be null for error responses
+	token, err := dec.Token()
+	if err != nil {
+		return nil, 0, err
+	}
+	if token == nil {
+		return nil, 0, nil
+	}
+	if token != json.Delim('{') {
+		return nil, 0, fmt.Errorf("unexpected token %v, expected response object", token)
+	}
+
+	var invoices []invoice
+	var totalPages int64
+	for dec.More() {
+		key, err := decodeKey(dec)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		switch {
+		case strings.EqualFold(key, "total_pages"):
+			err = dec.Decode(&totalPages)
+		case strings.EqualFold(key, "invoices"):
+			invoices, err = decodeInvoices(dec)
+		default:
+			err = skipValue(dec)
+		}
+		if err != nil {
+			return nil, 0, err
+		}
 	}
 
-	return apiResponse.Response.Invoices, int(apiResponse.Response.TotalPages), nil
+	return invoices, totalPages, expectDelim(dec, '}')
+}
+
+// decodeInvoices decodes an array of invoices element by element
+func decodeInvoices(dec *json.Decoder) ([]invoice, error) {
+	// The field may be null when there are no invoices
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if token == nil {
+		return nil, nil
+	}
+	if token != json.Delim('[') {
+		return nil, fmt.Errorf("unexpected token %v, expected invoice array", token)
+	}
+
+	var invoices []invoice
+	for dec.More() {
+		var inv invoice
+		if err := dec.Decode(&inv); err != nil {
+			return nil, err
+		}
+		invoices = append(invoices, inv)
+	}
+
+	return invoices, expectDelim(dec, ']')
+}
+
+// decodeKey reads an object key
+// Callers match keys case-insensitively, like json.Unmarshal does for struct fields
+func decodeKey(dec *json.Decoder) (string, error) {
+	token, err := dec.Token()
+	if err != nil {
+		return "", err
+	}
+	key, ok := token.(string)
+	if !ok {
+		return "", fmt.Errorf("unexpected token %v, expected object key", token)
+	}
+	return key, nil
+}
+
+// expectDelim reads the next token and checks that it is the given delimiter
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("unexpected token %v, expected %v", token, delim)
+	}
+	return nil
+}
+
+// skipValue reads and discards the next value
+func skipValue(dec *json.Decoder) error {
+	var value json.RawMessage
+	return dec.Decode(&value)
 }
@@ -0,0 +1,369 @@
+package oneprovider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+// newInvoiceServer returns a test API server listing one unpaid invoice per client ID,
+// the own account's for requests without a client filter
+func newInvoiceServer(t *testing.T, dueDates map[string]string) string {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientID := r.URL.Query().Get("client_id")
+		if clientID == "" {
+			clientID = "own"
+		}
+		var invoices []string
+		if dueDate, found := dueDates[clientID]; found && r.URL.Query().Get("status") == "Unpaid" {
+			invoices = append(invoices, fmt.Sprintf(`{"id":"inv-%s","client_id":"%s","status":"Unpaid","due_date":"%s","balance":"10.00"}`, clientID, clientID, dueDate))
+		}
+		fmt.Fprintf(w, `{"result":"success","response":{"current_page":1,"total_pages":1,"invoices":[%s]}}`, strings.Join(invoices, ","))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestSubAccounts(t *testing.T) {
+	baseURL := newInvoiceServer(t, map[string]string{
+		"own": "2026-11-10",
+		"101": "2026-11-05",
+		"102": "2026-11-01",
+		"103": "2026-10-20", // Not a configured sub-account
+	})
+
+	tests := []struct {
+		name        string
+		opts        []provider.Option
+		wantDate    string
+		wantAccount string
+		wantCount   int
+	}{
+		{name: "own account only by default", wantDate: "2026-11-10", wantAccount: "own", wantCount: 1},
+		{name: "two sub-accounts", opts: []provider.Option{provider.WithSubAccounts("101", "102")}, wantDate: "2026-11-01", wantAccount: "102", wantCount: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := New("key", "client", append(tt.opts, provider.WithBaseURL(baseURL))...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			dates, err := p.(provider.AccountDatesProvider).GetAccountDates(context.Background())
+			if err != nil {
+				t.Fatalf("GetAccountDates: %v", err)
+			}
+			if len(dates) != tt.wantCount {
+				t.Fatalf("%d dates, want %d: %v", len(dates), tt.wantCount, dates)
+			}
+			if got := dates[0].Date.Format(time.DateOnly); got != tt.wantDate || dates[0].Account != tt.wantAccount {
+				t.Errorf("earliest date %s of account %q, want %s of %q", got, dates[0].Account, tt.wantDate, tt.wantAccount)
+			}
+
+			next, err := p.GetNextPaymentDate(context.Background())
+			if err != nil {
+				t.Fatalf("GetNextPaymentDate: %v", err)
+			}
+			if got := next.Format(time.DateOnly); got != tt.wantDate {
+				t.Errorf("next payment date %s, want %s", got, tt.wantDate)
+			}
+		})
+	}
+}
+
+func TestNewMissingCredentials(t *testing.T) {
+	tests := []struct {
+		name      string
+		apiKey    string
+		clientKey string
+	}{
+		{name: "both empty"},
+		{name: "empty API key", clientKey: "client"},
+		{name: "empty client key", apiKey: "key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.apiKey, tt.clientKey); !errors.Is(err, provider.ErrMissingCredentials) {
+				t.Errorf("New: %v, want ErrMissingCredentials", err)
+			}
+			if p := NewOrNil(tt.apiKey, tt.clientKey); p != nil {
+				t.Errorf("NewOrNil returned %v, want nil", p)
+			}
+		})
+	}
+}
+
+func TestStatusHints(t *testing.T) {
+	tests := []struct {
+		status   int
+		wantHint string
+	}{
+		{status: http.StatusUnauthorized, wantHint: "OneProvider: 401 Unauthorized — check ONEPROVIDER_API_KEY and ONEPROVIDER_CLIENT_KEY"},
+		{status: http.StatusForbidden, wantHint: "OneProvider: 403 Forbidden — ONEPROVIDER_API_KEY and ONEPROVIDER_CLIENT_KEY is valid but lacks access to billing, check its permissions"},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "denied", tt.status)
+			}))
+			defer server.Close()
+
+			p, err := New("key", "client", provider.WithBaseURL(server.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = p.GetNextPaymentDate(context.Background())
+			var statusErr *provider.HTTPStatusError
+			if !errors.As(err, &statusErr) || statusErr.Hint != tt.wantHint {
+				t.Fatalf("GetNextPaymentDate: %v, want the hint %q", err, tt.wantHint)
+			}
+		})
+	}
+}
+
+// newInvoiceListServer returns a test API server listing the given invoices as unpaid
+func newInvoiceListServer(t *testing.T, invoices ...string) string {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var listed []string
+		if r.URL.Query().Get("status") == "Unpaid" {
+			listed = invoices
+		}
+		fmt.Fprintf(w, `{"result":"success","response":{"current_page":1,"total_pages":1,"invoices":[%s]}}`, strings.Join(listed, ","))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestInvoiceBalance(t *testing.T) {
+	const (
+		zeroBalance     = `{"id":"1","status":"Unpaid","due_date":"2026-10-20","balance":"0.00"}`
+		negativeBalance = `{"id":"2","status":"Unpaid","due_date":"2026-10-21","balance":"-1.00"}`
+		invalidBalance  = `{"id":"3","status":"Unpaid","due_date":"2026-10-22","balance":"ten"}`
+		owed            = `{"id":"4","status":"Unpaid","due_date":"2026-11-01","balance":"9.99"}`
+	)
+
+	tests := []struct {
+		name        string
+		invoices    []string
+		want        string // Expected next payment date, "" for none
+		wantErr     bool
+		wantSkipped int // Invoices logged as skipped
+	}{
+		{name: "unpaid invoice with zero balance", invoices: []string{zeroBalance, owed}, want: "2026-11-01"},
+		{name: "only settled invoices", invoices: []string{zeroBalance, negativeBalance}},
+		{name: "invalid balance skipped", invoices: []string{invalidBalance, owed}, want: "2026-11-01", wantSkipped: 1},
+		{name: "nothing usable", invoices: []string{invalidBalance}, wantErr: true, wantSkipped: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs strings.Builder
+			logger := slog.New(slog.NewTextHandler(&logs, nil))
+			p, err := New("key", "client", provider.WithBaseURL(newInvoiceListServer(t, tt.invoices...)), provider.WithLogger(logger))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			date, err := p.GetNextPaymentDate(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetNextPaymentDate: %v, want error %v", err, tt.wantErr)
+			}
+			var got string
+			if date != nil {
+				got = date.Format(time.DateOnly)
+			}
+			if got != tt.want {
+				t.Errorf("date %q, want %q", got, tt.want)
+			}
+			if skipped := strings.Count(logs.String(), "invoice skipped"); skipped != tt.wantSkipped {
+				t.Errorf("%d invoices logged as skipped, want %d: %s", skipped, tt.wantSkipped, logs.String())
+			}
+		})
+	}
+}
+
+func TestOutstandingStatuses(t *testing.T) {
+	// Invoices listed per status filter; the API answers in its own case
+	invoices := map[string]string{
+		"Unpaid":          `{"id":"1","status":"Unpaid","due_date":"2026-11-15","balance":"10.00"}`,
+		"Overdue":         `{"id":"2","status":"Overdue","due_date":"2026-10-10","balance":"10.00"}`,
+		"Payment Pending": `{"id":"3","status":"payment pending","due_date":"2026-10-05","balance":"10.00"}`,
+	}
+
+	tests := []struct {
+		name          string
+		statuses      []string
+		want          string
+		wantRequested []string
+	}{
+		{name: "default statuses", want: "2026-10-10", wantRequested: []string{"Unpaid", "Overdue"}},
+		{name: "non-standard status", statuses: []string{"Unpaid", "Payment Pending"}, want: "2026-10-05", wantRequested: []string{"Unpaid", "Payment Pending"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requested []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				status := r.URL.Query().Get("status")
+				requested = append(requested, status)
+				fmt.Fprintf(w, `{"result":"success","response":{"current_page":1,"total_pages":1,"invoices":[%s]}}`, invoices[status])
+			}))
+			defer server.Close()
+
+			opts := []provider.Option{provider.WithBaseURL(server.URL)}
+			if tt.statuses != nil {
+				opts = append(opts, provider.WithOutstandingStatuses(tt.statuses...))
+			}
+			p, err := New("key", "client", opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			date, err := p.GetNextPaymentDate(context.Background())
+			if err != nil {
+				t.Fatalf("GetNextPaymentDate: %v", err)
+			}
+			if date == nil || date.Format(time.DateOnly) != tt.want {
+				t.Errorf("date %v, want %s", date, tt.want)
+			}
+			if !reflect.DeepEqual(requested, tt.wantRequested) {
+				t.Errorf("requested statuses %q, want %q", requested, tt.wantRequested)
+			}
+		})
+	}
+}
+
+func TestDateFormats(t *testing.T) {
+	// unpaid returns an unpaid invoice due at the given date
+	unpaid := func(id, dueDate string) string {
+		return fmt.Sprintf(`{"id":"%s","status":"Unpaid","due_date":"%s","balance":"10.00"}`, id, dueDate)
+	}
+
+	tests := []struct {
+		name     string
+		invoices []string
+		format   string
+		want     string // Expected next payment date, "" for an error
+	}{
+		{name: "ISO", invoices: []string{unpaid("1", "2026-10-25")}, want: "2026-10-25"},
+		{name: "DD/MM/YYYY", invoices: []string{unpaid("1", "25/10/2026")}, want: "2026-10-25"},
+		{name: "MM/DD/YYYY", invoices: []string{unpaid("1", "10/25/2026")}, want: "2026-10-25"},
+		{name: "ambiguous date", invoices: []string{unpaid("1", "03/04/2026")}},
+		{name: "format inferred from another invoice", invoices: []string{unpaid("1", "03/04/2026"), unpaid("2", "25/12/2026")}, want: "2026-04-03"},
+		{name: "configured MM/DD/YYYY", invoices: []string{unpaid("1", "03/04/2026")}, format: "01/02/2006", want: "2026-03-04"},
+		{name: "configured DD/MM/YYYY", invoices: []string{unpaid("1", "03/04/2026")}, format: "02/01/2006", want: "2026-04-03"},
+		{name: "invalid in both formats", invoices: []string{unpaid("1", "13/13/2026")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []provider.Option{provider.WithBaseURL(newInvoiceListServer(t, tt.invoices...))}
+			if tt.format != "" {
+				opts = append(opts, provider.WithDateFormat(tt.format))
+			}
+			p, err := New("key", "client", opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			date, err := p.GetNextPaymentDate(context.Background())
+			if tt.want == "" {
+				if err == nil {
+					t.Fatalf("GetNextPaymentDate: %v, want an error", date)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetNextPaymentDate: %v", err)
+			}
+			if date == nil || date.Format(time.DateOnly) != tt.want {
+				t.Errorf("date %v, want %s", date, tt.want)
+			}
+		})
+	}
+}
+
+// syntheticInvoiceResponse returns an invoice list response with count invoices,
+// including fields the decoder does not know and keys in a different case
+func syntheticInvoiceResponse(count int) []byte {
+	var b strings.Builder
+	b.WriteString(`{"result":"success","Response":{"current_page":1,"TOTAL_PAGES":7,"extra":{"nested":[1,{"a":null}]},"invoices":[`)
+	for i := range count {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(&b, `{"id":"%d","client_id":"c%d","status":"Unpaid","due_date":"2026-%02d-%02d","balance":"%d.50","unknown":[true,"x"],`+
+			`"items":[{"id":"i%d","type":"Hosting","description":"Server \"%d\"","amount":"9.99"}]}`, i, i%3, i%12+1, i%28+1, i, i, i)
+	}
+	b.WriteString(`]},"error":null}`)
+	return []byte(b.String())
+}
+
+func TestDecodeInvoiceResponse(t *testing.T) {
+	body := syntheticInvoiceResponse(20000)
+
+	invoices, totalPages, apiErr, err := decodeInvoiceResponse(bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("decodeInvoiceResponse: %v", err)
+	}
+
+	// The streaming decoder must agree with decoding the whole response at once
+	var want struct {
+		Response struct {
+			TotalPages int64     `json:"total_pages"`
+			Invoices   []invoice `json:"invoices"`
+		} `json:"response"`
+		Error *apiError `json:"error"`
+	}
+	if err := json.Unmarshal(body, &want); err != nil {
+		t.Fatal(err)
+	}
+	if totalPages != want.Response.TotalPages || apiErr != nil {
+		t.Errorf("total pages %d and API error %v, want %d and none", totalPages, apiErr, want.Response.TotalPages)
+	}
+	if !reflect.DeepEqual(invoices, want.Response.Invoices) {
+		t.Fatalf("%d invoices differing from json.Unmarshal's %d", len(invoices), len(want.Response.Invoices))
+	}
+
+	t.Run("API error", func(t *testing.T) {
+		_, _, apiErr, err := decodeInvoiceResponse(strings.NewReader(`{"result":"error","response":null,"error":{"message":"invalid key","code":"401"}}`))
+		if err != nil || apiErr == nil || apiErr.Message != "invalid key" || apiErr.Code != "401" {
+			t.Fatalf("API error %+v with %v, want the decoded error", apiErr, err)
+		}
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		if _, _, _, err := decodeInvoiceResponse(bytes.NewReader(body[:len(body)/2])); err == nil {
+			t.Fatal("decoding a truncated response succeeded, want an error")
+		}
+	})
+}
+
+func BenchmarkDecodeInvoiceResponse(b *testing.B) {
+	body := syntheticInvoiceResponse(5000)
+	b.SetBytes(int64(len(body)))
+	b.ReportAllocs()
+
+	for b.Loop() {
+		if _, _, _, err := decodeInvoiceResponse(bytes.NewReader(body)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
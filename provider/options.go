@@ -0,0 +1,206 @@
+package provider
+
+import (
+	"crypto/tls"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Options contains settings shared by provider implementations
+// Each provider applies the options that are relevant to it and ignores the rest
+type Options struct {
+	// Location is the provider's billing time zone used to interpret date-only values (default: UTC)
+	Location *time.Location
+
+	// BaseURL overrides the provider API base URL, e.g. for a sandbox or an API gateway (default: production API)
+	BaseURL string
+
+	// TLSConfig is a custom TLS configuration for the provider API connection (default: system defaults)
+	TLSConfig *tls.Config
+
+	// CertificatePins are SHA-256 fingerprints of accepted server certificates (default: no pinning)
+	// Connections to servers presenting any other certificate are rejected
+	CertificatePins []string
+
+	// NilResult tells what a missing date in the provider response means (default: provider-specific)
+	NilResult NilResult
+
+	// SubAccounts are the client IDs of managed sub-accounts whose invoices are included (default: own account only)
+	SubAccounts []string
+
+	// DateFormat is the layout of dates in provider responses that mirror the account's display format,
+	// e.g. "02/01/2006" for DD/MM/YYYY (default: provider-specific detection)
+	DateFormat string
+
+	// Transport is the HTTP transport shared by providers, e.g. a tuned connection pool (default: per-provider clients
+	// with the default transport); providers with TLS settings use a copy of it with their own TLS configuration
+	Transport *http.Transport
+
+	// OutstandingStatuses are the invoice statuses invoice-based providers count as unpaid (default: DefaultOutstandingStatuses)
+	OutstandingStatuses []string
+
+	// Logger receives problems that do not fail a check, e.g. a single unparsable invoice (default: discard)
+	Logger *slog.Logger
+}
+
+// DefaultOutstandingStatuses are the invoice statuses counted as unpaid unless WithOutstandingStatuses is used
+var DefaultOutstandingStatuses = []string{"Unpaid", "Overdue"}
+
+// NilResult is the interpretation of a missing date (e.g. an empty forecast) in a provider response
+type NilResult int
+
+const (
+	// NilResultDefault keeps the provider's own interpretation
+	NilResultDefault NilResult = iota
+	// NilResultOverdue treats a missing date as an overdue payment (due yesterday)
+	NilResultOverdue
+	// NilResultHealthy treats a missing date as no payment due
+	NilResultHealthy
+)
+
+// Option configures provider Options
+type Option func(*Options)
+
+// WithLocation sets the provider's billing time zone
+// Date-only values returned by the provider API are interpreted and returned as midnight in this zone
+func WithLocation(loc *time.Location) Option {
+	return func(o *Options) {
+		o.Location = loc
+	}
+}
+
+// WithBaseURL overrides the provider API base URL
+func WithBaseURL(baseURL string) Option {
+	return func(o *Options) {
+		o.BaseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithTLSConfig sets a custom TLS configuration for the provider API connection
+func WithTLSConfig(config *tls.Config) Option {
+	return func(o *Options) {
+		o.TLSConfig = config
+	}
+}
+
+// WithCertificatePin pins the provider API server certificate to the given SHA-256 fingerprints
+// Fingerprints are hex encoded, with or without colon separators
+func WithCertificatePin(fingerprints ...string) Option {
+	return func(o *Options) {
+		o.CertificatePins = append(o.CertificatePins, fingerprints...)
+	}
+}
+
+// WithNilResultMeans sets what a missing date in the provider response means
+func WithNilResultMeans(meaning NilResult) Option {
+	return func(o *Options) {
+		o.NilResult = meaning
+	}
+}
+
+// WithSubAccounts includes the invoices of sub-accounts managed by a reseller account, given by client ID
+// The earliest due date across the own account and the sub-accounts is the next payment date,
+// reported with the client ID of the account owing it
+func WithSubAccounts(clientIDs ...string) Option {
+	return func(o *Options) {
+		o.SubAccounts = append(o.SubAccounts, clientIDs...)
+	}
+}
+
+// WithDateFormat sets the layout (in time.Parse notation) of dates in provider responses,
+// for accounts whose display date format is mirrored by the API, e.g. "01/02/2006" for MM/DD/YYYY
+// Without it the format is inferred from the dates that fit only one format in the same response,
+// so it is only needed when every date is ambiguous, e.g. 03/04/2026
+func WithDateFormat(layout string) Option {
+	return func(o *Options) {
+		o.DateFormat = layout
+	}
+}
+
+// WithTransport makes the provider send its requests through the given transport,
+// sharing its connection pool with every other provider using the same transport
+func WithTransport(transport *http.Transport) Option {
+	return func(o *Options) {
+		o.Transport = transport
+	}
+}
+
+// WithOutstandingStatuses sets the invoice statuses counted as unpaid, replacing the defaults
+// Use it for providers with their own status vocabulary, e.g. "Payment Pending" or "Collections"
+func WithOutstandingStatuses(statuses ...string) Option {
+	return func(o *Options) {
+		o.OutstandingStatuses = append([]string(nil), statuses...)
+	}
+}
+
+// WithLogger sets the logger receiving problems that do not fail a check
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *Options) {
+		o.Logger = logger
+	}
+}
+
+// ApplyOptions builds Options from defaults and the given option functions
+func ApplyOptions(opts []Option) Options {
+	o := Options{
+		Location: time.UTC,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+	if o.Location == nil {
+		o.Location = time.UTC
+	}
+	if o.Logger == nil {
+		o.Logger = slog.New(slog.DiscardHandler)
+	}
+	return o
+}
+
+// BaseURLOr returns the configured base URL or defaultURL if none was set
+func (o Options) BaseURLOr(defaultURL string) string {
+	if o.BaseURL == "" {
+		return defaultURL
+	}
+	return o.BaseURL
+}
+
+// NilResultOr returns the configured nil result interpretation or defaultMeaning if none was set
+func (o Options) NilResultOr(defaultMeaning NilResult) NilResult {
+	if o.NilResult == NilResultDefault {
+		return defaultMeaning
+	}
+	return o.NilResult
+}
+
+// OutstandingStatusesOr returns the configured outstanding invoice statuses or defaults if none were set
+func (o Options) OutstandingStatusesOr(defaults []string) []string {
+	if len(o.OutstandingStatuses) == 0 {
+		return defaults
+	}
+	return o.OutstandingStatuses
+}
+
+// IsOutstanding reports whether an invoice status is one of the outstanding statuses, ignoring case
+func IsOutstanding(status string, outstanding []string) bool {
+	for _, candidate := range outstanding {
+		if strings.EqualFold(status, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// NilResultDate returns the payment date a provider reports for a missing date:
+// yesterday for NilResultOverdue and nil (no payment due) for NilResultHealthy
+func NilResultDate(meaning NilResult) *time.Time {
+	if meaning != NilResultOverdue {
+		return nil
+	}
+	pastDate := time.Now().AddDate(0, 0, -1) // Yesterday - overdue
+	return &pastDate
+}
@@ -0,0 +1,330 @@
+package oraclecloud
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+const (
+	usageAPIURLFormat = "https://usageapi.%s.oci.oraclecloud.com"
+	usageAPIVersion   = "/20200107"
+)
+
+// OracleCloudProvider implements the Provider interface for Oracle Cloud Infrastructure
+type OracleCloudProvider struct {
+	tenancyOCID    string
+	userOCID       string
+	keyFingerprint string
+	privateKey     *rsa.PrivateKey
+	baseURL        string
+	client         *http.Client
+	now            func() time.Time // Clock used for the Date header and the billing period
+}
+
+// New creates a new instance of OracleCloudProvider
+// privateKeyPEM is the API signing key in PEM format (PKCS#1 or PKCS#8)
+// Returns provider.ErrMissingCredentials if any credential is empty
+// Supported options: provider.WithBaseURL, provider.WithTLSConfig, provider.WithCertificatePin
+func New(tenancyOCID, userOCID, keyFingerprint, privateKeyPEM, region string, opts ...provider.Option) (provider.Provider, error) {
+	switch {
+	case tenancyOCID == "":
+		return nil, fmt.Errorf("oraclecloud: tenancy OCID is empty: %w", provider.ErrMissingCredentials)
+	case userOCID == "":
+		return nil, fmt.Errorf("oraclecloud: user OCID is empty: %w", provider.ErrMissingCredentials)
+	case keyFingerprint == "":
+		return nil, fmt.Errorf("oraclecloud: key fingerprint is empty: %w", provider.ErrMissingCredentials)
+	case privateKeyPEM == "":
+		return nil, fmt.Errorf("oraclecloud: private key is empty: %w", provider.ErrMissingCredentials)
+	case region == "":
+		return nil, fmt.Errorf("oraclecloud: region is empty: %w", provider.ErrMissingCredentials)
+	}
+
+	privateKey, err := parsePrivateKey(privateKeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("oraclecloud: %w", err)
+	}
+
+	options := provider.ApplyOptions(opts)
+	return &OracleCloudProvider{
+		tenancyOCID:    tenancyOCID,
+		userOCID:       userOCID,
+		keyFingerprint: keyFingerprint,
+		privateKey:     privateKey,
+		baseURL:        options.BaseURLOr(fmt.Sprintf(usageAPIURLFormat, region)) + usageAPIVersion,
+		client:         provider.NewHTTPClient(options),
+		now:            time.Now,
+	}, nil
+}
+
+// parsePrivateKey parses an RSA private key in PKCS#1 or PKCS#8 PEM format
+func parsePrivateKey(privateKeyPEM string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(privateKeyPEM))
+	if block == nil {
+		return nil, errors.New("private key is not PEM encoded")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not an RSA key")
+	}
+	return rsaKey, nil
+}
+
+// GetName returns the provider name
+func (o *OracleCloudProvider) GetName() string {
+	return "oraclecloud"
+}
+
+// IsConfigured checks if the provider is configured
+func (o *OracleCloudProvider) IsConfigured() bool {
+	return o != nil && o.tenancyOCID != "" && o.userOCID != "" && o.keyFingerprint != "" && o.privateKey != nil
+}
+
+// Fingerprint returns a stable identifier of the provider account
+func (o *OracleCloudProvider) Fingerprint() string {
+	return provider.CredentialFingerprint(o.GetName(), o.tenancyOCID, o.userOCID, o.keyFingerprint)
+}
+
+// usageRequest represents the request body of the Usage API summarized usages call
+type usageRequest struct {
+	TenantID         string `json:"tenantId"`
+	TimeUsageStarted string `json:"timeUsageStarted"`
+	TimeUsageEnded   string `json:"timeUsageEnded"`
+	Granularity      string `json:"granularity"`
+	QueryType        string `json:"queryType"`
+}
+
+// usageResponse represents the API response from the Usage API
+type usageResponse struct {
+	Items []usageItem `json:"items"`
+}
+
+// usageItem represents one summarized usage entry
+type usageItem struct {
+	TimeUsageStarted time.Time `json:"timeUsageStarted"`
+	TimeUsageEnded   time.Time `json:"timeUsageEnded"`
+	ComputedAmount   *float64  `json:"computedAmount"` // Cost of the period (nullable)
+	Currency         string    `json:"currency"`
+}
+
+// errorResponse represents an error response from OCI API
+type errorResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// GetNextPaymentDate retrieves the next payment due date from Oracle Cloud
+// Returns the close of the current monthly billing period, or nil if the period has no cost (Free Tier)
+func (o *OracleCloudProvider) GetNextPaymentDate(ctx context.Context) (*time.Time, error) {
+	usage, err := o.fetchUsage(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch usage: %w", err)
+	}
+
+	var total float64
+	var periodEnd time.Time
+	for _, item := range usage.Items {
+		if item.ComputedAmount != nil {
+			total += *item.ComputedAmount
+		}
+		if item.TimeUsageEnded.After(periodEnd) {
+			periodEnd = item.TimeUsageEnded
+		}
+	}
+
+	// Nothing is billed - no payment due
+	if total <= 0 || periodEnd.IsZero() {
+		return nil, nil
+	}
+
+	return &periodEnd, nil
+}
+
+// makeRequest creates a signed HTTP request to OCI API
+// method - HTTP method (GET, POST, etc.)
+// path - API path (e.g., "/usage")
+// queryParams - query parameters, can be nil
+// body - request body for POST requests, can be nil
+func (o *OracleCloudProvider) makeRequest(ctx context.Context, method, path string, queryParams map[string]string, body []byte) (*http.Request, error) {
+	// Build full URL
+	fullURL := o.baseURL + path
+	u, err := url.Parse(fullURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL: %w", err)
+	}
+
+	// Add query parameters if present
+	if len(queryParams) > 0 {
+		q := u.Query()
+		for key, value := range queryParams {
+			q.Set(key, value)
+		}
+		u.RawQuery = q.Encode()
+	}
+
+	// Create request with context
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set headers
+	req.Header.Set("Date", o.now().UTC().Format(http.TimeFormat))
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Content-Length", strconv.Itoa(len(body)))
+		sum := sha256.Sum256(body)
+		req.Header.Set("X-Content-Sha256", base64.StdEncoding.EncodeToString(sum[:]))
+	}
+
+	// Tag request for tracing
+	provider.SetRequestIDHeader(req)
+
+	// Sign request
+	if err := o.signRequest(req, body != nil); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// signRequest adds the Authorization header required by OCI (draft-cavage HTTP signatures, rsa-sha256)
+// Requests with a body additionally sign the content headers
+func (o *OracleCloudProvider) signRequest(req *http.Request, withBody bool) error {
+	headers := []string{"date", "(request-target)", "host"}
+	if withBody {
+		headers = append(headers, "content-length", "content-type", "x-content-sha256")
+	}
+
+	signingString := buildSigningString(req, headers)
+	digest := sha256.Sum256([]byte(signingString))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, o.privateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return fmt.Errorf("failed to sign request: %w", err)
+	}
+
+	keyID := o.tenancyOCID + "/" + o.userOCID + "/" + o.keyFingerprint
+	req.Header.Set("Authorization", fmt.Sprintf(`Signature version="1",keyId="%s",algorithm="rsa-sha256",headers="%s",signature="%s"`,
+		keyID, strings.Join(headers, " "), base64.StdEncoding.EncodeToString(signature)))
+
+	return nil
+}
+
+// buildSigningString builds the string to sign from the listed headers in order
+func buildSigningString(req *http.Request, headers []string) string {
+	lines := make([]string, 0, len(headers))
+	for _, name := range headers {
+		var value string
+		switch name {
+		case "(request-target)":
+			value = strings.ToLower(req.Method) + " " + req.URL.RequestURI()
+		case "host":
+			value = req.URL.Host
+		default:
+			value = req.Header.Get(name)
+		}
+		lines = append(lines, name+": "+value)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// executeRequest executes an HTTP request and returns the response body
+// Returns the response body as bytes or an error if the request fails
+func (o *OracleCloudProvider) executeRequest(req *http.Request) ([]byte, error) {
+	// Execute request
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Check status code
+	if resp.StatusCode != http.StatusOK {
+		var apiErr errorResponse
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Message != "" {
+			// Report the API error message instead of the raw body
+			body = []byte(fmt.Sprintf("API error: %s (code: %s)", apiErr.Message, apiErr.Code))
+		}
+		return nil, provider.StatusError("Oracle Cloud", resp.StatusCode, "OCI_TENANCY_OCID, OCI_USER_OCID, OCI_KEY_FINGERPRINT and OCI_PRIVATE_KEY", body)
+	}
+
+	return body, nil
+}
+
+// FetchRaw performs the primary API call (usage of the current billing month) and returns the raw response body without parsing
+func (o *OracleCloudProvider) FetchRaw(ctx context.Context) ([]byte, error) {
+	// Current monthly billing period
+	now := o.now().UTC()
+	periodStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := periodStart.AddDate(0, 1, 0)
+
+	body, err := json.Marshal(usageRequest{
+		TenantID:         o.tenancyOCID,
+		TimeUsageStarted: periodStart.Format(time.RFC3339),
+		TimeUsageEnded:   periodEnd.Format(time.RFC3339),
+		Granularity:      "MONTHLY",
+		QueryType:        "COST",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	// Create request to summarize usage
+	req, err := o.makeRequest(ctx, "POST", "/usage", nil, body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Execute request
+	return o.executeRequest(req)
+}
+
+// fetchUsage fetches the usage of the current billing month from OCI Usage API
+func (o *OracleCloudProvider) fetchUsage(ctx context.Context) (*usageResponse, error) {
+	// Fetch raw response
+	body, err := o.FetchRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse JSON
+	var apiResponse usageResponse
+	if err := json.Unmarshal(body, &apiResponse); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	return &apiResponse, nil
+}
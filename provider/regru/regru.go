@@ -0,0 +1,230 @@
+package regru
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+const (
+	regRuAPIURL = "https://api.reg.ru/api/regru2"
+)
+
+// RegRuProvider implements the Provider interface for Reg.ru
+type RegRuProvider struct {
+	username string
+	password string
+	baseURL  string
+	client   *http.Client
+	location *time.Location // Billing time zone of expiration dates
+}
+
+// New creates a new instance of RegRuProvider
+// Returns provider.ErrMissingCredentials if username or password is empty
+// Supported options: provider.WithLocation (default: UTC), provider.WithBaseURL, provider.WithTLSConfig, provider.WithCertificatePin
+func New(username, password string, opts ...provider.Option) (provider.Provider, error) {
+	if username == "" {
+		return nil, fmt.Errorf("regru: username is empty: %w", provider.ErrMissingCredentials)
+	}
+	if password == "" {
+		return nil, fmt.Errorf("regru: password is empty: %w", provider.ErrMissingCredentials)
+	}
+	options := provider.ApplyOptions(opts)
+	return &RegRuProvider{
+		username: username,
+		password: password,
+		baseURL:  options.BaseURLOr(regRuAPIURL),
+		client:   provider.NewHTTPClient(options),
+		location: options.Location,
+	}, nil
+}
+
+// GetName returns the provider name
+func (r *RegRuProvider) GetName() string {
+	return "regru"
+}
+
+// IsConfigured checks if the provider is configured
+func (r *RegRuProvider) IsConfigured() bool {
+	return r != nil && r.username != "" && r.password != ""
+}
+
+// Fingerprint returns a stable identifier of the provider account
+func (r *RegRuProvider) Fingerprint() string {
+	return provider.CredentialFingerprint(r.GetName(), r.username)
+}
+
+// apiResponse represents the result/error envelope of every Reg.ru API response
+type apiResponse struct {
+	Result    string          `json:"result"` // "success" or "error"
+	ErrorCode string          `json:"error_code"`
+	ErrorText string          `json:"error_text"`
+	Answer    json.RawMessage `json:"answer"`
+}
+
+// serviceListAnswer represents the answer of the service list request
+type serviceListAnswer struct {
+	Services []service `json:"services"`
+}
+
+// service represents a service (domain, VPS, hosting) in the Reg.ru API response
+type service struct {
+	ServiceID      json.Number `json:"service_id"`
+	DName          string      `json:"dname"`
+	ServType       string      `json:"servtype"`
+	State          string      `json:"state"`           // "A" - active, "S" - suspended, "N" - not activated
+	ExpirationDate string      `json:"expiration_date"` // Format: "2026-02-20"
+}
+
+// authErrorCodes are envelope error codes caused by wrong credentials
+var authErrorCodes = map[string]bool{
+	"PASSWORD_AUTH_FAILED":   true,
+	"NO_USERNAME":            true,
+	"NO_AUTH":                true,
+	"ACCESS_DENIED_FROM_IP":  true,
+	"INVALID_API_CREDENTIAL": true,
+}
+
+// GetNextPaymentDate retrieves the next payment due date from Reg.ru
+// Returns the earliest expiration date among active and suspended services, or nil if there is none
+func (r *RegRuProvider) GetNextPaymentDate(ctx context.Context) (*time.Time, error) {
+	dates, err := r.GetCandidateDates(ctx)
+	if err != nil || len(dates) == 0 {
+		return nil, err
+	}
+	return &dates[0], nil
+}
+
+// GetCandidateDates retrieves the expiration dates of all active and suspended services from Reg.ru, sorted ascending
+// Suspended services are included, their renewal is the most urgent one
+func (r *RegRuProvider) GetCandidateDates(ctx context.Context) ([]time.Time, error) {
+	services, err := r.fetchServices(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch services: %w", err)
+	}
+
+	var dates []time.Time
+	for _, svc := range services {
+		if (svc.State != "A" && svc.State != "S") || svc.ExpirationDate == "" {
+			continue
+		}
+
+		// Parse expiration date (format: "2026-02-20") as midnight in the billing time zone
+		date, err := time.ParseInLocation("2006-01-02", svc.ExpirationDate, r.location)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse expiration date of service %s: %w", svc.ServiceID, err)
+		}
+		dates = append(dates, date)
+	}
+
+	sort.Slice(dates, func(i, j int) bool {
+		return dates[i].Before(dates[j])
+	})
+
+	return dates, nil
+}
+
+// makeRequest creates an HTTP request to Reg.ru API
+// Credentials are sent in the form-encoded body, never in the URL
+// path - API function path (e.g., "/service/get_list")
+// params - additional function parameters, can be nil
+func (r *RegRuProvider) makeRequest(ctx context.Context, path string, params map[string]string) (*http.Request, error) {
+	// Build form body
+	form := url.Values{}
+	form.Set("username", r.username)
+	form.Set("password", r.password)
+	form.Set("output_format", "json")
+	for key, value := range params {
+		form.Set(key, value)
+	}
+
+	// Create request with context
+	req, err := http.NewRequestWithContext(ctx, "POST", r.baseURL+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set headers
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	// Tag request for tracing
+	provider.SetRequestIDHeader(req)
+
+	return req, nil
+}
+
+// executeRequest executes an HTTP request and returns the response body
+// Returns the response body as bytes or an error if the request fails
+func (r *RegRuProvider) executeRequest(req *http.Request) ([]byte, error) {
+	// Execute request
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Check status code
+	if resp.StatusCode != http.StatusOK {
+		return nil, provider.StatusError("Reg.ru", resp.StatusCode, "REGRU_USERNAME and REGRU_PASSWORD", body)
+	}
+
+	return body, nil
+}
+
+// FetchRaw performs the primary API call (service list) and returns the raw response body without parsing
+func (r *RegRuProvider) FetchRaw(ctx context.Context) ([]byte, error) {
+	// Create request to list services
+	req, err := r.makeRequest(ctx, "/service/get_list", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Execute request
+	return r.executeRequest(req)
+}
+
+// fetchServices fetches the services from Reg.ru API
+func (r *RegRuProvider) fetchServices(ctx context.Context) ([]service, error) {
+	// Fetch raw response
+	body, err := r.FetchRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse JSON
+	var envelope apiResponse
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	// The API reports errors in the envelope with HTTP 200
+	if envelope.Result != "success" {
+		if authErrorCodes[envelope.ErrorCode] {
+			// Report wrong credentials like an HTTP 401 so they are classified as permanent auth failures
+			return nil, provider.StatusError("Reg.ru", http.StatusUnauthorized, "REGRU_USERNAME and REGRU_PASSWORD", []byte("API error: "+envelope.ErrorText))
+		}
+		return nil, fmt.Errorf("API error: %s (code: %s)", envelope.ErrorText, envelope.ErrorCode)
+	}
+
+	var answer serviceListAnswer
+	if err := json.Unmarshal(envelope.Answer, &answer); err != nil {
+		return nil, fmt.Errorf("failed to parse services: %w", err)
+	}
+
+	return answer.Services, nil
+}
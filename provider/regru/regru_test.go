@@ -0,0 +1,126 @@
+package regru
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+// newServiceServer returns a test API server answering the service list request of user "user"
+// with the given envelope, and rejecting any other credentials with an auth error
+func newServiceServer(t *testing.T, body string) string {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/service/get_list" || r.URL.RawQuery != "" {
+			http.Error(w, "unexpected request", http.StatusNotFound)
+			return
+		}
+		if r.PostFormValue("username") != "user" || r.PostFormValue("password") != "secret" {
+			w.Write([]byte(`{"result":"error","error_code":"PASSWORD_AUTH_FAILED","error_text":"Username/password Incorrect"}`))
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestGetCandidateDates(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string // Expected expiration dates, ascending
+	}{
+		{
+			name: "renewal dates",
+			body: `{"result":"success","answer":{"services":[` +
+				`{"service_id":"101","dname":"example.ru","servtype":"domain","state":"A","expiration_date":"2027-03-01"},` +
+				`{"service_id":102,"dname":"vps-1","servtype":"srv_vps","state":"S","expiration_date":"2026-10-20"},` +
+				`{"service_id":"103","dname":"vps-2","servtype":"srv_vps","state":"N","expiration_date":"2026-10-17"},` +
+				`{"service_id":"104","dname":"hosting","servtype":"srv_hosting_ispmgr","state":"A","expiration_date":"2026-12-15"},` +
+				`{"service_id":"105","dname":"ssl","servtype":"srv_ssl_certificate","state":"A","expiration_date":""}` +
+				`]}}`,
+			want: []string{"2026-10-20", "2026-12-15", "2027-03-01"},
+		},
+		{name: "no services", body: `{"result":"success","answer":{"services":[]}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := New("user", "secret", provider.WithBaseURL(newServiceServer(t, tt.body)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			dates, err := p.(provider.CandidateDatesProvider).GetCandidateDates(context.Background())
+			if err != nil {
+				t.Fatalf("GetCandidateDates: %v", err)
+			}
+			var got []string
+			for _, date := range dates {
+				got = append(got, date.Format(time.DateOnly))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("dates %q, want %q", got, tt.want)
+			}
+
+			next, err := p.GetNextPaymentDate(context.Background())
+			if err != nil {
+				t.Fatalf("GetNextPaymentDate: %v", err)
+			}
+			switch {
+			case len(tt.want) == 0 && next != nil:
+				t.Errorf("next date %v, want none", next)
+			case len(tt.want) > 0 && (next == nil || next.Format(time.DateOnly) != tt.want[0]):
+				t.Errorf("next date %v, want the earliest %s", next, tt.want[0])
+			}
+		})
+	}
+}
+
+func TestAuthError(t *testing.T) {
+	p, err := New("user", "wrong", provider.WithBaseURL(newServiceServer(t, `{}`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = p.GetNextPaymentDate(context.Background())
+	var statusErr *provider.HTTPStatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusUnauthorized || statusErr.Body != "API error: Username/password Incorrect" {
+		t.Fatalf("GetNextPaymentDate: %v, want an unauthorized error with the API message", err)
+	}
+	if !errors.Is(err, provider.ErrPermanent) {
+		t.Errorf("GetNextPaymentDate: %v, want a permanent failure", err)
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	body := `{"result":"error","error_code":"SERVICE_UNAVAILABLE","error_text":"Try again later"}`
+	p, err := New("user", "secret", provider.WithBaseURL(newServiceServer(t, body)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = p.GetNextPaymentDate(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "API error: Try again later (code: SERVICE_UNAVAILABLE)") {
+		t.Fatalf("GetNextPaymentDate: %v, want the API error", err)
+	}
+	if errors.Is(err, provider.ErrPermanent) {
+		t.Errorf("GetNextPaymentDate: %v, want no permanent failure for a non-auth error", err)
+	}
+}
+
+func TestNewMissingCredentials(t *testing.T) {
+	for _, credentials := range [][2]string{{"", "secret"}, {"user", ""}} {
+		if _, err := New(credentials[0], credentials[1]); !errors.Is(err, provider.ErrMissingCredentials) {
+			t.Errorf("New(%q, %q): %v, want ErrMissingCredentials", credentials[0], credentials[1], err)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// RequestIDHeader is the header carrying the request ID to the provider API
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDKey is the context key of the request ID
+type requestIDKey struct{}
+
+// requestID is the request ID stored in a context
+type requestID struct {
+	id         string
+	sendHeader bool
+}
+
+// NewRequestID generates a random request ID for tracing provider calls
+func NewRequestID() string {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// WithRequestID returns a context tagging provider calls with the request ID
+// If sendHeader is true, providers also send it to the API in the X-Request-ID header
+func WithRequestID(ctx context.Context, id string, sendHeader bool) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID{id: id, sendHeader: sendHeader})
+}
+
+// RequestID returns the request ID of the context, or an empty string if there is none
+func RequestID(ctx context.Context) string {
+	value, _ := ctx.Value(requestIDKey{}).(requestID)
+	return value.id
+}
+
+// SetRequestIDHeader sets the X-Request-ID header from the request context if sending it was requested
+// Intended for makeRequest implementations
+func SetRequestIDHeader(req *http.Request) {
+	value, _ := req.Context().Value(requestIDKey{}).(requestID)
+	if value.id != "" && value.sendHeader {
+		req.Header.Set(RequestIDHeader, value.id)
+	}
+}
@@ -0,0 +1,216 @@
+package timeweb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+const (
+	timewebAPIURL = "https://api.timeweb.cloud/api/v1"
+)
+
+// TimewebProvider implements the Provider interface for Timeweb Cloud
+type TimewebProvider struct {
+	apiToken string
+	baseURL  string
+	client   *http.Client
+}
+
+// New creates a new instance of TimewebProvider
+// Returns provider.ErrMissingCredentials if apiToken is empty
+// Supported options: provider.WithBaseURL, provider.WithTLSConfig, provider.WithCertificatePin
+func New(apiToken string, opts ...provider.Option) (provider.Provider, error) {
+	if apiToken == "" {
+		return nil, fmt.Errorf("timeweb: api token is empty: %w", provider.ErrMissingCredentials)
+	}
+	options := provider.ApplyOptions(opts)
+	return &TimewebProvider{
+		apiToken: apiToken,
+		baseURL:  options.BaseURLOr(timewebAPIURL),
+		client:   provider.NewHTTPClient(options),
+	}, nil
+}
+
+// GetName returns the provider name
+func (t *TimewebProvider) GetName() string {
+	return "timeweb"
+}
+
+// IsConfigured checks if the provider is configured
+func (t *TimewebProvider) IsConfigured() bool {
+	return t != nil && t.apiToken != ""
+}
+
+// Fingerprint returns a stable identifier of the provider account
+func (t *TimewebProvider) Fingerprint() string {
+	return provider.CredentialFingerprint(t.GetName(), t.apiToken)
+}
+
+// financesResponse represents the API response from Timeweb for account finances
+type financesResponse struct {
+	Finances struct {
+		Balance     float64 `json:"balance"`
+		Currency    string  `json:"currency"`
+		HourlyCost  float64 `json:"hourly_cost"`
+		MonthlyCost float64 `json:"monthly_cost"`
+		HoursLeft   *int64  `json:"hours_left"` // Hours until the balance runs out (nullable)
+	} `json:"finances"`
+	ResponseID string `json:"response_id"`
+}
+
+// errorResponse represents an error response from Timeweb API
+type errorResponse struct {
+	StatusCode int    `json:"status_code"`
+	ErrorCode  string `json:"error_code"`
+	Message    string `json:"message"`
+}
+
+// GetBalance retrieves the current account balance from Timeweb
+func (t *TimewebProvider) GetBalance(ctx context.Context) (provider.Money, error) {
+	finances, err := t.fetchFinances(ctx)
+	if err != nil {
+		return provider.Money{}, fmt.Errorf("failed to fetch finances: %w", err)
+	}
+
+	return provider.Money{Amount: finances.Finances.Balance, Currency: finances.Finances.Currency}, nil
+}
+
+// GetNextPaymentDate retrieves the next payment due date from Timeweb
+// Returns the forecast shutdown date derived from the hours left on the balance
+// Returns nil if nothing is billed, and a past date if the balance is depleted
+func (t *TimewebProvider) GetNextPaymentDate(ctx context.Context) (*time.Time, error) {
+	finances, err := t.fetchFinances(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch finances: %w", err)
+	}
+
+	// Nothing is billed - no payment due
+	if finances.Finances.MonthlyCost <= 0 && finances.Finances.HourlyCost <= 0 {
+		return nil, nil
+	}
+
+	// Depleted balance - consider payment as overdue (return past date)
+	if finances.Finances.Balance <= 0 {
+		pastDate := time.Now().AddDate(0, 0, -1) // Yesterday - overdue
+		return &pastDate, nil
+	}
+
+	// Use the API forecast if present, otherwise estimate from the hourly cost
+	var hoursLeft float64
+	switch {
+	case finances.Finances.HoursLeft != nil:
+		hoursLeft = float64(*finances.Finances.HoursLeft)
+	case finances.Finances.HourlyCost > 0:
+		hoursLeft = finances.Finances.Balance / finances.Finances.HourlyCost
+	default:
+		hoursLeft = finances.Finances.Balance / finances.Finances.MonthlyCost * 30 * 24
+	}
+
+	forecastDate := time.Now().UTC().Add(time.Duration(hoursLeft * float64(time.Hour)))
+
+	return &forecastDate, nil
+}
+
+// makeRequest creates an HTTP request to Timeweb API
+// method - HTTP method (GET, POST, etc.)
+// path - API path (e.g., "/account/finances")
+// queryParams - query parameters, can be nil
+// body - request body for POST requests, can be nil
+func (t *TimewebProvider) makeRequest(ctx context.Context, method, path string, queryParams map[string]string, body io.Reader) (*http.Request, error) {
+	// Build full URL
+	fullURL := t.baseURL + path
+	u, err := url.Parse(fullURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL: %w", err)
+	}
+
+	// Add query parameters if present
+	if len(queryParams) > 0 {
+		q := u.Query()
+		for key, value := range queryParams {
+			q.Set(key, value)
+		}
+		u.RawQuery = q.Encode()
+	}
+
+	// Create request with context
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set headers
+	req.Header.Set("Authorization", "Bearer "+t.apiToken)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	// Tag request for tracing
+	provider.SetRequestIDHeader(req)
+
+	return req, nil
+}
+
+// executeRequest executes an HTTP request and returns the response body
+// Returns the response body as bytes or an error if the request fails
+func (t *TimewebProvider) executeRequest(req *http.Request) ([]byte, error) {
+	// Execute request
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Check status code
+	if resp.StatusCode != http.StatusOK {
+		var apiErr errorResponse
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Message != "" {
+			// Report the API error message instead of the raw body
+			body = []byte(fmt.Sprintf("API error: %s (code: %s)", apiErr.Message, apiErr.ErrorCode))
+		}
+		return nil, provider.StatusError("Timeweb", resp.StatusCode, "TIMEWEB_API_TOKEN", body)
+	}
+
+	return body, nil
+}
+
+// FetchRaw performs the primary API call (account finances) and returns the raw response body without parsing
+func (t *TimewebProvider) FetchRaw(ctx context.Context) ([]byte, error) {
+	// Create request to get account finances
+	req, err := t.makeRequest(ctx, "GET", "/account/finances", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Execute request
+	return t.executeRequest(req)
+}
+
+// fetchFinances fetches account finances from Timeweb API
+func (t *TimewebProvider) fetchFinances(ctx context.Context) (*financesResponse, error) {
+	// Fetch raw response
+	body, err := t.FetchRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse JSON
+	var apiResponse financesResponse
+	if err := json.Unmarshal(body, &apiResponse); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	return &apiResponse, nil
+}
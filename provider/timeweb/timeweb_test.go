@@ -0,0 +1,100 @@
+package timeweb
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+func TestGetNextPaymentDate(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		wantHours float64 // Expected hours until the date, ignored for wantNil and wantPast
+		wantNil   bool
+		wantPast  bool
+	}{
+		{name: "healthy forecast", body: `{"finances":{"balance":1000,"currency":"RUB","hourly_cost":1,"monthly_cost":720,"hours_left":240}}`, wantHours: 240},
+		{name: "forecast estimated from the hourly cost", body: `{"finances":{"balance":100,"currency":"RUB","hourly_cost":2,"monthly_cost":1440}}`, wantHours: 50},
+		{name: "depleted balance", body: `{"finances":{"balance":0,"currency":"RUB","hourly_cost":1,"monthly_cost":720,"hours_left":0}}`, wantPast: true},
+		{name: "nothing billed", body: `{"finances":{"balance":50,"currency":"RUB","hourly_cost":0,"monthly_cost":0}}`, wantNil: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/account/finances" || r.Header.Get("Authorization") != "Bearer token" {
+					http.Error(w, "unexpected request", http.StatusNotFound)
+					return
+				}
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			p, err := New("token", provider.WithBaseURL(server.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			date, err := p.GetNextPaymentDate(context.Background())
+			if err != nil {
+				t.Fatalf("GetNextPaymentDate: %v", err)
+			}
+
+			switch {
+			case tt.wantNil:
+				if date != nil {
+					t.Errorf("date %v, want none", date)
+				}
+			case tt.wantPast:
+				if date == nil || !date.Before(time.Now()) {
+					t.Errorf("date %v, want a past date", date)
+				}
+			default:
+				hours := time.Until(*date).Hours()
+				if hours < tt.wantHours-1 || hours > tt.wantHours {
+					t.Errorf("date %v is %.1f hours ahead, want %.0f", date, hours, tt.wantHours)
+				}
+			}
+		})
+	}
+}
+
+func TestNewMissingCredentials(t *testing.T) {
+	if _, err := New(""); !errors.Is(err, provider.ErrMissingCredentials) {
+		t.Errorf("New with an empty API token: %v, want ErrMissingCredentials", err)
+	}
+}
+
+func TestStatusHints(t *testing.T) {
+	tests := []struct {
+		status   int
+		wantHint string
+	}{
+		{status: http.StatusUnauthorized, wantHint: "Timeweb: 401 Unauthorized — check TIMEWEB_API_TOKEN"},
+		{status: http.StatusForbidden, wantHint: "Timeweb: 403 Forbidden — TIMEWEB_API_TOKEN is valid but lacks access to billing, check its permissions"},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "denied", tt.status)
+			}))
+			defer server.Close()
+
+			p, err := New("token", provider.WithBaseURL(server.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = p.GetNextPaymentDate(context.Background())
+			var statusErr *provider.HTTPStatusError
+			if !errors.As(err, &statusErr) || statusErr.Hint != tt.wantHint {
+				t.Fatalf("GetNextPaymentDate: %v, want the hint %q", err, tt.wantHint)
+			}
+		})
+	}
+}
@@ -18,20 +18,42 @@ const (
 
 // VdsinaProvider implements the Provider interface for VDSina
 type VdsinaProvider struct {
-	apiKey string
-	client *http.Client
+	apiKey    string
+	baseURL   string
+	client    *http.Client
+	location  *time.Location     // Billing time zone of forecast dates
+	nilResult provider.NilResult // Meaning of a missing forecast
 }
 
 // New creates a new instance of VdsinaProvider
-// If apiKey is empty, the provider is considered not configured
-func New(apiKey string) provider.Provider {
+// Returns provider.ErrMissingCredentials if apiKey is empty
+// Supported options: provider.WithLocation (VDSina bills in Moscow time, default: UTC for compatibility),
+// provider.WithBaseURL, provider.WithTLSConfig, provider.WithCertificatePin,
+// provider.WithNilResultMeans (default: a missing forecast means overdue)
+func New(apiKey string, opts ...provider.Option) (provider.Provider, error) {
 	if apiKey == "" {
-		return nil
+		return nil, fmt.Errorf("vdsina: api key is empty: %w", provider.ErrMissingCredentials)
 	}
+	options := provider.ApplyOptions(opts)
 	return &VdsinaProvider{
-		apiKey: apiKey,
-		client: &http.Client{Timeout: 40 * time.Second},
+		apiKey:    apiKey,
+		baseURL:   options.BaseURLOr(vdsinaAPIURL),
+		client:    provider.NewHTTPClient(options),
+		location:  options.Location,
+		nilResult: options.NilResultOr(provider.NilResultOverdue),
+	}, nil
+}
+
+// NewOrNil creates a new instance of VdsinaProvider
+// If apiKey is empty, the provider is considered not configured and nil is returned
+//
+// Deprecated: use New, which reports missing credentials explicitly
+func NewOrNil(apiKey string) provider.Provider {
+	p, err := New(apiKey)
+	if err != nil {
+		return nil
 	}
+	return p
 }
 
 // GetName returns the provider name
@@ -44,6 +66,11 @@ func (v *VdsinaProvider) IsConfigured() bool {
 	return v != nil && v.apiKey != ""
 }
 
+// Fingerprint returns a stable identifier of the provider account
+func (v *VdsinaProvider) Fingerprint() string {
+	return provider.CredentialFingerprint(v.GetName(), v.apiKey)
+}
+
 // accountResponse represents the API response from VDSina for account information
 type accountResponse struct {
 	Status    string `json:"status"`
@@ -63,30 +90,84 @@ type accountResponse struct {
 	} `json:"data"`
 }
 
+// balanceResponse represents the API response from VDSina for account balance
+type balanceResponse struct {
+	Status    string `json:"status"`
+	StatusMsg string `json:"status_msg"`
+	Data      struct {
+		Real    float64 `json:"real"`    // Real money balance
+		Bonus   float64 `json:"bonus"`   // Bonus balance
+		Partner float64 `json:"partner"` // Partner program balance
+	} `json:"data"`
+}
+
+// GetBalance retrieves the current account balance from VDSina
+// Only the real money balance is reported, bonus and partner funds are excluded
+func (v *VdsinaProvider) GetBalance(ctx context.Context) (provider.Money, error) {
+	req, err := v.makeRequest(ctx, "GET", "/account.balance", nil, nil)
+	if err != nil {
+		return provider.Money{}, err
+	}
+
+	body, err := v.executeRequest(req)
+	if err != nil {
+		return provider.Money{}, fmt.Errorf("failed to fetch balance: %w", err)
+	}
+
+	var apiResponse balanceResponse
+	if err := json.Unmarshal(body, &apiResponse); err != nil {
+		return provider.Money{}, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	if apiResponse.Status == "error" {
+		return provider.Money{}, fmt.Errorf("API error: %s", apiResponse.StatusMsg)
+	}
+
+	return provider.Money{Amount: apiResponse.Data.Real, Currency: "RUB"}, nil
+}
+
+// IsSuspended reports whether the VDSina account is restricted
+// VDSina revokes the permissions to add services and users of accounts blocked for non-payment
+func (v *VdsinaProvider) IsSuspended(ctx context.Context) (bool, string, error) {
+	accountInfo, err := v.fetchAccount(ctx)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to fetch account: %w", err)
+	}
+
+	can := accountInfo.Data.Can
+	if !can.AddService && !can.AddUser {
+		return true, "account is restricted: adding services and users is not allowed", nil
+	}
+	return false, "", nil
+}
+
+// GetAccountLocation returns the time zone the VDSina control panel displays dates in
+// The account data has no region, VDSina shows Moscow time for every account
+func (v *VdsinaProvider) GetAccountLocation(ctx context.Context) (*time.Location, error) {
+	return time.LoadLocation("Europe/Moscow")
+}
+
 // GetNextPaymentDate retrieves the next payment due date from VDSina
 // Returns the forecast date (shutdown forecast) from account information
+// A missing forecast is reported as overdue (yesterday) or as no payment due, see provider.WithNilResultMeans
 func (v *VdsinaProvider) GetNextPaymentDate(ctx context.Context) (*time.Time, error) {
 	accountInfo, err := v.fetchAccount(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch account: %w", err)
 	}
 
-	// If forecast is nil or empty, consider payment as overdue (return past date)
+	// If forecast is nil or empty, interpret it as configured (default: overdue)
 	if accountInfo.Data.Forecast == nil || *accountInfo.Data.Forecast == "" {
-		pastDate := time.Now().AddDate(0, 0, -1) // Yesterday - overdue
-		return &pastDate, nil
+		return provider.NilResultDate(v.nilResult), nil
 	}
 
-	// Parse forecast date (format: "2029-02-20") in UTC
-	forecastDate, err := time.Parse("2006-01-02", *accountInfo.Data.Forecast)
+	// Parse forecast date (format: "2029-02-20") as midnight in the billing time zone
+	forecastDate, err := time.ParseInLocation("2006-01-02", *accountInfo.Data.Forecast, v.location)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse forecast date: %w", err)
 	}
 
-	// Convert to UTC (at midnight UTC)
-	forecastDateUTC := forecastDate.UTC()
-
-	return &forecastDateUTC, nil
+	return &forecastDate, nil
 }
 
 // makeRequest creates an HTTP request to VDSina API
@@ -96,7 +177,7 @@ func (v *VdsinaProvider) GetNextPaymentDate(ctx context.Context) (*time.Time, er
 // body - request body for POST requests, can be nil
 func (v *VdsinaProvider) makeRequest(ctx context.Context, method, path string, queryParams map[string]string, body io.Reader) (*http.Request, error) {
 	// Build full URL
-	fullURL := vdsinaAPIURL + path
+	fullURL := v.baseURL + path
 	u, err := url.Parse(fullURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse URL: %w", err)
@@ -122,6 +203,9 @@ func (v *VdsinaProvider) makeRequest(ctx context.Context, method, path string, q
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
+	// Tag request for tracing
+	provider.SetRequestIDHeader(req)
+
 	return req, nil
 }
 
@@ -143,14 +227,14 @@ func (v *VdsinaProvider) executeRequest(req *http.Request) ([]byte, error) {
 
 	// Check status code
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+		return nil, provider.StatusError("VDSina", resp.StatusCode, "VDSINA_API_KEY", body)
 	}
 
 	return body, nil
 }
 
-// fetchAccount fetches account information from VDSina API
-func (v *VdsinaProvider) fetchAccount(ctx context.Context) (*accountResponse, error) {
+// FetchRaw performs the primary API call (account information) and returns the raw response body without parsing
+func (v *VdsinaProvider) FetchRaw(ctx context.Context) ([]byte, error) {
 	// Create request to get account information
 	req, err := v.makeRequest(ctx, "GET", "/account", nil, nil)
 	if err != nil {
@@ -158,7 +242,13 @@ func (v *VdsinaProvider) fetchAccount(ctx context.Context) (*accountResponse, er
 	}
 
 	// Execute request
-	body, err := v.executeRequest(req)
+	return v.executeRequest(req)
+}
+
+// fetchAccount fetches account information from VDSina API
+func (v *VdsinaProvider) fetchAccount(ctx context.Context) (*accountResponse, error) {
+	// Fetch raw response
+	body, err := v.FetchRaw(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,168 @@
+package vdsina
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+func TestNewMissingCredentials(t *testing.T) {
+	if _, err := New(""); !errors.Is(err, provider.ErrMissingCredentials) {
+		t.Errorf("New with an empty API key: %v, want ErrMissingCredentials", err)
+	}
+	if p := NewOrNil(""); p != nil {
+		t.Errorf("NewOrNil with an empty API key returned %v, want nil", p)
+	}
+}
+
+func TestForecastLocation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"ok","data":{"forecast":"2026-10-19"}}`))
+	}))
+	defer server.Close()
+
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Skipf("time zone Europe/Moscow unavailable: %v", err)
+	}
+	// Late evening in UTC, but already the forecast day in Moscow
+	now := time.Date(2026, 10, 18, 22, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		opts    []provider.Option
+		want    time.Time
+		overdue bool
+	}{
+		{name: "default UTC", want: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), overdue: false},
+		{name: "Moscow", opts: []provider.Option{provider.WithLocation(moscow)}, want: time.Date(2026, 10, 18, 21, 0, 0, 0, time.UTC), overdue: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := New("key", append(tt.opts, provider.WithBaseURL(server.URL))...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			date, err := p.GetNextPaymentDate(context.Background())
+			if err != nil {
+				t.Fatalf("GetNextPaymentDate: %v", err)
+			}
+			if !date.Equal(tt.want) {
+				t.Errorf("forecast %v, want %v", date, tt.want)
+			}
+			if overdue := !date.After(now); overdue != tt.overdue {
+				t.Errorf("forecast reached at %v: %v, want %v", now, overdue, tt.overdue)
+			}
+		})
+	}
+}
+
+func TestStatusHints(t *testing.T) {
+	tests := []struct {
+		status   int
+		wantHint string
+	}{
+		{status: http.StatusUnauthorized, wantHint: "VDSina: 401 Unauthorized — check VDSINA_API_KEY"},
+		{status: http.StatusForbidden, wantHint: "VDSina: 403 Forbidden — VDSINA_API_KEY is valid but lacks access to billing, check its permissions"},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "denied", tt.status)
+			}))
+			defer server.Close()
+
+			p, err := New("key", provider.WithBaseURL(server.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = p.GetNextPaymentDate(context.Background())
+			var statusErr *provider.HTTPStatusError
+			if !errors.As(err, &statusErr) || statusErr.Hint != tt.wantHint {
+				t.Fatalf("GetNextPaymentDate: %v, want the hint %q", err, tt.wantHint)
+			}
+		})
+	}
+}
+
+func TestNilResultMeans(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		opts     []provider.Option
+		wantNone bool // No payment due instead of an overdue date
+	}{
+		{name: "missing forecast is overdue by default", body: `{"status":"ok","data":{}}`},
+		{name: "empty forecast is overdue by default", body: `{"status":"ok","data":{"forecast":""}}`},
+		{name: "explicitly overdue", body: `{"status":"ok","data":{}}`, opts: []provider.Option{provider.WithNilResultMeans(provider.NilResultOverdue)}},
+		{name: "healthy", body: `{"status":"ok","data":{}}`, opts: []provider.Option{provider.WithNilResultMeans(provider.NilResultHealthy)}, wantNone: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			p, err := New("key", append(tt.opts, provider.WithBaseURL(server.URL))...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			date, err := p.GetNextPaymentDate(context.Background())
+			if err != nil {
+				t.Fatalf("GetNextPaymentDate: %v", err)
+			}
+
+			if tt.wantNone {
+				if date != nil {
+					t.Errorf("date %v, want no payment due", date)
+				}
+				return
+			}
+			if date == nil || !date.Before(time.Now()) {
+				t.Errorf("date %v, want an overdue date", date)
+			}
+		})
+	}
+}
+
+func TestIsSuspended(t *testing.T) {
+	tests := []struct {
+		name          string
+		can           string
+		wantSuspended bool
+	}{
+		{name: "active", can: `{"add_user":true,"add_service":true}`},
+		{name: "partly restricted", can: `{"add_user":false,"add_service":true}`},
+		{name: "suspended", can: `{"add_user":false,"add_service":false}`, wantSuspended: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`{"status":"ok","data":{"forecast":"2026-10-19","can":` + tt.can + `}}`))
+			}))
+			defer server.Close()
+
+			p, err := New("key", provider.WithBaseURL(server.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			suspended, reason, err := p.(provider.SuspensionReporter).IsSuspended(context.Background())
+			if err != nil {
+				t.Fatalf("IsSuspended: %v", err)
+			}
+			if suspended != tt.wantSuspended || (reason != "") != tt.wantSuspended {
+				t.Errorf("suspended %v with reason %q, want suspended %v", suspended, reason, tt.wantSuspended)
+			}
+		})
+	}
+}
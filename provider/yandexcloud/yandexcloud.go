@@ -0,0 +1,263 @@
+package yandexcloud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+const (
+	yandexCloudBillingAPIURL = "https://billing.api.cloud.yandex.net/billing/v1"
+)
+
+// ErrTokenExpired is returned when Yandex Cloud rejects the IAM token
+// IAM tokens live at most 12 hours, so the token has to be reissued and the provider recreated
+var ErrTokenExpired = errors.New("yandexcloud: IAM token is expired or invalid")
+
+// YandexCloudProvider implements the Provider interface for Yandex Cloud billing accounts
+type YandexCloudProvider struct {
+	iamToken         string
+	billingAccountID string
+	baseURL          string
+	client           *http.Client
+	location         *time.Location // Time zone in which billing periods close
+}
+
+// New creates a new instance of YandexCloudProvider
+// Returns provider.ErrMissingCredentials if iamToken or billingAccountID is empty
+// Supported options: provider.WithLocation (default: UTC), provider.WithBaseURL, provider.WithTLSConfig, provider.WithCertificatePin
+func New(iamToken, billingAccountID string, opts ...provider.Option) (provider.Provider, error) {
+	if iamToken == "" {
+		return nil, fmt.Errorf("yandexcloud: IAM token is empty: %w", provider.ErrMissingCredentials)
+	}
+	if billingAccountID == "" {
+		return nil, fmt.Errorf("yandexcloud: billing account id is empty: %w", provider.ErrMissingCredentials)
+	}
+	options := provider.ApplyOptions(opts)
+	return &YandexCloudProvider{
+		iamToken:         iamToken,
+		billingAccountID: billingAccountID,
+		baseURL:          options.BaseURLOr(yandexCloudBillingAPIURL),
+		client:           provider.NewHTTPClient(options),
+		location:         options.Location,
+	}, nil
+}
+
+// GetName returns the provider name
+func (y *YandexCloudProvider) GetName() string {
+	return "yandexcloud"
+}
+
+// IsConfigured checks if the provider is configured
+func (y *YandexCloudProvider) IsConfigured() bool {
+	return y != nil && y.iamToken != "" && y.billingAccountID != ""
+}
+
+// Fingerprint returns a stable identifier of the provider account
+// The billing account identifies the account, since IAM tokens rotate
+func (y *YandexCloudProvider) Fingerprint() string {
+	return provider.CredentialFingerprint(y.GetName(), y.billingAccountID)
+}
+
+// billingAccount represents the API response from Yandex Cloud for a billing account
+type billingAccount struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	CreatedAt   string `json:"createdAt"`
+	CountryCode string `json:"countryCode"`
+	Currency    string `json:"currency"`
+	Active      bool   `json:"active"`
+	Balance     string `json:"balance"`
+}
+
+// apiError represents an error response from Yandex Cloud API
+type apiError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// GetBalance retrieves the current billing account balance from Yandex Cloud
+func (y *YandexCloudProvider) GetBalance(ctx context.Context) (provider.Money, error) {
+	account, err := y.fetchBillingAccount(ctx)
+	if err != nil {
+		return provider.Money{}, fmt.Errorf("failed to fetch billing account: %w", err)
+	}
+
+	balance, err := parseBalance(account.Balance)
+	if err != nil {
+		return provider.Money{}, err
+	}
+
+	return provider.Money{Amount: balance, Currency: account.Currency}, nil
+}
+
+// parseBalance parses the decimal balance string, an empty balance is zero
+func parseBalance(value string) (float64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	balance, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse balance: %w", err)
+	}
+	return balance, nil
+}
+
+// GetNextPaymentDate retrieves the next payment date from Yandex Cloud
+// Yandex Cloud closes billing periods at the start of each month, so for a healthy account
+// the next invoice date is the first day of the next month
+// An inactive account or a negative balance is considered overdue (returns a past date)
+func (y *YandexCloudProvider) GetNextPaymentDate(ctx context.Context) (*time.Time, error) {
+	account, err := y.fetchBillingAccount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch billing account: %w", err)
+	}
+
+	balance, err := parseBalance(account.Balance)
+	if err != nil {
+		return nil, err
+	}
+
+	// Suspended account or debt - payment is overdue
+	if !account.Active || balance < 0 {
+		pastDate := time.Now().AddDate(0, 0, -1) // Yesterday - overdue
+		return &pastDate, nil
+	}
+
+	// Next billing period close - first day of the next month in the billing time zone
+	now := time.Now().In(y.location)
+	nextInvoice := time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, y.location)
+
+	return &nextInvoice, nil
+}
+
+// countryLocations maps billing account country codes to the time zone of the console
+var countryLocations = map[string]string{
+	"RU": "Europe/Moscow",
+	"KZ": "Asia/Almaty",
+}
+
+// GetAccountLocation returns the time zone derived from the billing account country
+// Returns an error for countries without a known time zone
+func (y *YandexCloudProvider) GetAccountLocation(ctx context.Context) (*time.Location, error) {
+	account, err := y.fetchBillingAccount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch billing account: %w", err)
+	}
+
+	name, found := countryLocations[account.CountryCode]
+	if !found {
+		return nil, fmt.Errorf("no time zone known for country %q", account.CountryCode)
+	}
+	return time.LoadLocation(name)
+}
+
+// makeRequest creates an HTTP request to Yandex Cloud Billing API
+// method - HTTP method (GET, POST, etc.)
+// path - API path (e.g., "/billingAccounts/{id}")
+// queryParams - query parameters, can be nil
+// body - request body for POST requests, can be nil
+func (y *YandexCloudProvider) makeRequest(ctx context.Context, method, path string, queryParams map[string]string, body io.Reader) (*http.Request, error) {
+	// Build full URL
+	fullURL := y.baseURL + path
+	u, err := url.Parse(fullURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL: %w", err)
+	}
+
+	// Add query parameters if present
+	if len(queryParams) > 0 {
+		q := u.Query()
+		for key, value := range queryParams {
+			q.Set(key, value)
+		}
+		u.RawQuery = q.Encode()
+	}
+
+	// Create request with context
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set headers
+	req.Header.Set("Authorization", "Bearer "+y.iamToken)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	// Tag request for tracing
+	provider.SetRequestIDHeader(req)
+
+	return req, nil
+}
+
+// executeRequest executes an HTTP request and returns the response body
+// Returns the response body as bytes or an error if the request fails
+// A rejected IAM token is reported as ErrTokenExpired
+func (y *YandexCloudProvider) executeRequest(req *http.Request) ([]byte, error) {
+	// Execute request
+	resp, err := y.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// IAM token expired or revoked
+	if resp.StatusCode == http.StatusUnauthorized {
+		var apiErr apiError
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Message != "" {
+			return nil, fmt.Errorf("%w: %s", ErrTokenExpired, apiErr.Message)
+		}
+		return nil, ErrTokenExpired
+	}
+
+	// Check status code
+	if resp.StatusCode != http.StatusOK {
+		return nil, provider.StatusError("Yandex Cloud", resp.StatusCode, "YANDEX_CLOUD_IAM_TOKEN", body)
+	}
+
+	return body, nil
+}
+
+// FetchRaw performs the primary API call (billing account) and returns the raw response body without parsing
+func (y *YandexCloudProvider) FetchRaw(ctx context.Context) ([]byte, error) {
+	// Create request to get billing account
+	req, err := y.makeRequest(ctx, "GET", "/billingAccounts/"+url.PathEscape(y.billingAccountID), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Execute request
+	return y.executeRequest(req)
+}
+
+// fetchBillingAccount fetches billing account information from Yandex Cloud Billing API
+func (y *YandexCloudProvider) fetchBillingAccount(ctx context.Context) (*billingAccount, error) {
+	// Fetch raw response
+	body, err := y.FetchRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse JSON
+	var account billingAccount
+	if err := json.Unmarshal(body, &account); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	return &account, nil
+}
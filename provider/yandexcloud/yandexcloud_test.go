@@ -0,0 +1,115 @@
+package yandexcloud
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+// newBillingServer returns a test API server answering the billing account request of account "acc"
+func newBillingServer(t *testing.T, status int, body string) string {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/billingAccounts/acc" || r.Header.Get("Authorization") != "Bearer token" {
+			http.Error(w, "unexpected request", http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestGetNextPaymentDate(t *testing.T) {
+	now := time.Now().UTC()
+	nextMonth := time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		body    string
+		want    time.Time
+		overdue bool
+	}{
+		{name: "healthy account", body: `{"id":"acc","currency":"RUB","active":true,"balance":"1500.50"}`, want: nextMonth},
+		{name: "negative balance", body: `{"id":"acc","currency":"RUB","active":true,"balance":"-10"}`, overdue: true},
+		{name: "inactive account", body: `{"id":"acc","currency":"RUB","active":false,"balance":"0"}`, overdue: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := New("token", "acc", provider.WithBaseURL(newBillingServer(t, http.StatusOK, tt.body)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			date, err := p.GetNextPaymentDate(context.Background())
+			if err != nil {
+				t.Fatalf("GetNextPaymentDate: %v", err)
+			}
+			if tt.overdue {
+				if !date.Before(time.Now()) {
+					t.Errorf("date %v, want a past date", date)
+				}
+				return
+			}
+			if !date.Equal(tt.want) {
+				t.Errorf("date %v, want the first day of the next month %v", date, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpiredToken(t *testing.T) {
+	baseURL := newBillingServer(t, http.StatusUnauthorized, `{"code":16,"message":"The token has expired"}`)
+	p, err := New("token", "acc", provider.WithBaseURL(baseURL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := p.GetNextPaymentDate(context.Background()); !errors.Is(err, ErrTokenExpired) {
+		t.Fatalf("GetNextPaymentDate: %v, want ErrTokenExpired", err)
+	}
+}
+
+func TestNewMissingCredentials(t *testing.T) {
+	for _, credentials := range [][2]string{{"", "acc"}, {"token", ""}} {
+		if _, err := New(credentials[0], credentials[1]); !errors.Is(err, provider.ErrMissingCredentials) {
+			t.Errorf("New(%q, %q): %v, want ErrMissingCredentials", credentials[0], credentials[1], err)
+		}
+	}
+}
+
+func TestStatusHints(t *testing.T) {
+	tests := []struct {
+		status   int
+		wantHint string
+	}{
+		// 401 reports ErrTokenExpired instead, see TestExpiredToken
+		{status: http.StatusForbidden, wantHint: "Yandex Cloud: 403 Forbidden — YANDEX_CLOUD_IAM_TOKEN is valid but lacks access to billing, check its permissions"},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "denied", tt.status)
+			}))
+			defer server.Close()
+
+			p, err := New("token", "acc", provider.WithBaseURL(server.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = p.GetNextPaymentDate(context.Background())
+			var statusErr *provider.HTTPStatusError
+			if !errors.As(err, &statusErr) || statusErr.Hint != tt.wantHint {
+				t.Fatalf("GetNextPaymentDate: %v, want the hint %q", err, tt.wantHint)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package neverforgetvps
+
+import (
+	"fmt"
+	"time"
+)
+
+// severityRateWindow is the length of the window SeverityRateLimits apply to
+const severityRateWindow = time.Minute
+
+// rateWindow counts the messages of one severity within the current window
+type rateWindow struct {
+	start      time.Time // When the current window started
+	sent       int       // Messages delivered in the current window
+	suppressed int       // Messages dropped since the last delivered message
+}
+
+// validateSeverityRateLimits checks that no severity has a negative limit
+func validateSeverityRateLimits(limits map[Severity]int) error {
+	for severity, limit := range limits {
+		if limit < 0 {
+			return fmt.Errorf("negative limit %d for severity %s", limit, severity)
+		}
+	}
+	return nil
+}
+
+// allowRate checks the message against SeverityRateLimits and records it
+// Dropped messages are counted and reported as a note on the next delivered message of the same severity
+// The first Critical message is never dropped
+func (m *vpsMonitor[T]) allowRate(message *Message) bool {
+	limit, found := m.severityRateLimits[message.Severity]
+	if !found {
+		return true
+	}
+
+	m.rateMu.Lock()
+	defer m.rateMu.Unlock()
+
+	now := m.now()
+	window := m.rateWindows[message.Severity]
+	if now.Sub(window.start) >= severityRateWindow {
+		window.start = now
+		window.sent = 0
+	}
+
+	firstCritical := message.Severity == SeverityCritical && !m.criticalDelivered
+	if window.sent >= limit && !firstCritical {
+		window.suppressed++
+		m.rateWindows[message.Severity] = window
+		return false
+	}
+
+	window.sent++
+	if window.suppressed > 0 {
+		message.Text += fmt.Sprintf("\n\n+%d more suppressed", window.suppressed)
+		window.suppressed = 0
+	}
+	if message.Severity == SeverityCritical {
+		m.criticalDelivered = true
+	}
+	m.rateWindows[message.Severity] = window
+	return true
+}
@@ -0,0 +1,67 @@
+package neverforgetvps
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+func TestSeverityRateLimits(t *testing.T) {
+	clock := newTestClock()
+	var providers []provider.Provider
+	for i := range 5 {
+		providers = append(providers, newStubProvider(fmt.Sprintf("provider%d", i), daysFrom(clock.Now(), 2)))
+	}
+	relaxed := newStubProvider("relaxed", daysFrom(clock.Now(), 20))
+	providers = append(providers, relaxed)
+	m, sent := newTestMonitor(t, Config{SeverityRateLimits: map[Severity]int{SeverityWarning: 2}}, clock, providers...)
+
+	m.CheckNow(context.Background(), 0)
+	texts := sent.texts()
+	if n := countContaining(texts, "WARNING"); n != 2 {
+		t.Fatalf("%d warnings in %q, want 2 within the limit", n, texts)
+	}
+	// Severities without a limit are not affected
+	if n := countContaining(texts, "INFO"); n != 1 {
+		t.Errorf("%d info messages in %q, want 1", n, texts)
+	}
+
+	// The first warning of the next window reports the suppressed ones
+	sent.reset()
+	clock.Advance(severityRateWindow)
+	m.CheckNow(context.Background(), 0)
+	texts = sent.texts()
+	if n := countContaining(texts, "WARNING"); n != 2 {
+		t.Fatalf("%d warnings in %q, want 2 within the limit", n, texts)
+	}
+	if !strings.HasSuffix(texts[0], "\n\n+3 more suppressed") || countContaining(texts, "more suppressed") != 1 {
+		t.Errorf("messages %q, want the first warning to note 3 suppressed", texts)
+	}
+}
+
+func TestFirstCriticalNeverDropped(t *testing.T) {
+	clock := newTestClock()
+	p := newStubProvider("vdsina", daysFrom(clock.Now(), -1))
+	m, sent := newTestMonitor(t, Config{SeverityRateLimits: map[Severity]int{SeverityCritical: 0}}, clock, p)
+
+	m.CheckNow(context.Background(), 0)
+	clock.Advance(time.Hour)
+	m.CheckNow(context.Background(), 0)
+
+	if texts := sent.texts(); len(texts) != 1 || !strings.Contains(texts[0], "CRITICAL") {
+		t.Errorf("messages %q, want only the first critical message", texts)
+	}
+}
+
+func TestInvalidSeverityRateLimits(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(r.(string), "invalid SeverityRateLimits") {
+			t.Errorf("panic %v, want an invalid SeverityRateLimits panic", r)
+		}
+	}()
+	newTestMonitor(t, Config{SeverityRateLimits: map[Severity]int{SeverityInfo: -1}}, nil, newStubProvider("vdsina", nil))
+}
@@ -0,0 +1,244 @@
+package neverforgetvps
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+// ErrDuplicateProvider is returned by AddProvider when the same account is already monitored
+var ErrDuplicateProvider = errors.New("provider is already monitored with the same credentials")
+
+// DefaultProviderTimeout is the check timeout of built-in providers without a Config.ProviderTimeouts entry
+// and of providers added with AddProvider without a timeout
+var DefaultProviderTimeout = 30 * time.Second
+
+// DefaultMaxConcurrentChecks is a recommended Config.MaxConcurrentChecks for monitors with many providers
+// It is not applied automatically: an unset MaxConcurrentChecks means unlimited
+const DefaultMaxConcurrentChecks = 10
+
+// slowProviderMargin extends DefaultProviderTimeout for built-in providers with slow billing APIs (VDSina, Timeweb)
+const slowProviderMargin = 10 * time.Second
+
+// AddProvider starts monitoring an additional provider at runtime
+// The provider is checked from the next cycle on, and a confirmation message with its
+// next payment date is sent after its first successful check
+// timeout limits each check of the provider (0 means DefaultProviderTimeout)
+// Returns ErrDuplicateProvider if a provider with the same name and credentials is already monitored,
+// and an error if the provider is nil, not configured, or its name is already monitored
+func (m *vpsMonitor[T]) AddProvider(p provider.Provider, timeout time.Duration) error {
+	if p == nil {
+		return errors.New("provider is nil")
+	}
+	if !p.IsConfigured() {
+		return fmt.Errorf("provider %s is not configured", p.GetName())
+	}
+
+	if timeout <= 0 {
+		timeout = DefaultProviderTimeout
+	}
+
+	// Mark onboarding first, so a cycle checking the provider right after it is added sends the confirmation
+	m.mu.Lock()
+	if m.onboarding == nil {
+		m.onboarding = make(map[string]bool)
+	}
+	pending := m.onboarding[p.GetName()]
+	m.onboarding[p.GetName()] = true
+	m.mu.Unlock()
+
+	if err := m.addProvider(p, timeout); err != nil {
+		// Keep the onboarding of an already registered provider with the same name
+		if !pending {
+			m.mu.Lock()
+			delete(m.onboarding, p.GetName())
+			m.mu.Unlock()
+		}
+		return err
+	}
+	return nil
+}
+
+// hasFingerprint checks whether a provider with the given fingerprint is registered
+// An empty fingerprint never matches
+func (m *vpsMonitor[T]) hasFingerprint(fingerprint string) bool {
+	if fingerprint == "" {
+		return false
+	}
+
+	m.providersMu.RLock()
+	defer m.providersMu.RUnlock()
+
+	for _, entry := range m.providers {
+		if provider.Fingerprint(entry.Provider) == fingerprint {
+			return true
+		}
+	}
+	return false
+}
+
+// completeOnboarding reports whether the provider was awaiting its first successful check
+// and marks its onboarding as complete
+func (m *vpsMonitor[T]) completeOnboarding(name string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.onboarding[name] {
+		return false
+	}
+	delete(m.onboarding, name)
+	return true
+}
+
+// onboardingMessage formats the confirmation sent after the first successful check of an added provider
+func onboardingMessage(status ProviderStatus) string {
+	if status.Outcome() == OutcomeNoPaymentDue {
+		return fmt.Sprintf("✅ Now monitoring provider %s, no payment due", status.Provider)
+	}
+	return fmt.Sprintf("✅ Now monitoring provider %s, next payment %s", status.Provider, status.displayDate())
+}
+
+// label returns the message label of a provider, empty if none is configured
+func (m *vpsMonitor[T]) label(name string) string {
+	m.providersMu.RLock()
+	defer m.providersMu.RUnlock()
+	return m.labels[name]
+}
+
+// Merge imports the providers and labels of another monitor into this one
+// Both monitors must have been created with the same message type
+// Providers already monitored here with the same credentials are skipped, other name
+// conflicts fail the whole merge before anything is imported
+// Labels configured here take precedence over the labels of the other monitor
+// The other monitor keeps its providers, stop it to avoid checking them twice
+func (m *vpsMonitor[T]) Merge(other VPSMonitor) error {
+	source, ok := other.(*vpsMonitor[T])
+	if !ok {
+		return fmt.Errorf("cannot merge monitor of type %T: message types differ", other)
+	}
+	if source == m {
+		return nil
+	}
+
+	source.providersMu.RLock()
+	entries := append([]providerEntry(nil), source.providers...)
+	labels := make(map[string]string, len(source.labels))
+	for name, label := range source.labels {
+		labels[name] = label
+	}
+	source.providersMu.RUnlock()
+
+	// Resolve conflicts before importing anything
+	var imported []providerEntry
+	var conflicts []string
+	for _, entry := range entries {
+		switch {
+		case m.hasFingerprint(provider.Fingerprint(entry.Provider)):
+			continue
+		case m.hasProvider(entry.Provider.GetName()):
+			conflicts = append(conflicts, entry.Provider.GetName())
+		default:
+			imported = append(imported, entry)
+		}
+	}
+	if len(conflicts) > 0 {
+		return fmt.Errorf("providers already monitored with different credentials: %s", strings.Join(conflicts, ", "))
+	}
+
+	for _, entry := range imported {
+		// The other monitor's cache is dropped, this monitor's CacheTTL applies
+		// An account registered concurrently since the conflict check is skipped like any other duplicate
+		err := m.addProvider(provider.Unwrap(entry.Provider), entry.Timeout)
+		if errors.Is(err, ErrDuplicateProvider) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		name := entry.Provider.GetName()
+		if label := labels[name]; label != "" {
+			m.providersMu.Lock()
+			if _, found := m.labels[name]; !found {
+				m.labels[name] = label
+			}
+			m.providersMu.Unlock()
+		}
+	}
+
+	return nil
+}
+
+// RemoveProvider stops monitoring a provider and forgets its state (status, acknowledgement, pause)
+// Sends a confirmation message if Config.NotifyOnRemove is set
+func (m *vpsMonitor[T]) RemoveProvider(name string) error {
+	m.providersMu.Lock()
+	index := -1
+	for i, entry := range m.providers {
+		if entry.Provider.GetName() == name {
+			index = i
+			break
+		}
+	}
+	if index >= 0 {
+		m.providers = append(m.providers[:index:index], m.providers[index+1:]...)
+		delete(m.disabled, name)
+	}
+	m.providersMu.Unlock()
+
+	if index < 0 {
+		return fmt.Errorf("unknown provider: %s", name)
+	}
+
+	m.mu.Lock()
+	delete(m.statuses, name)
+	delete(m.lastSuccess, name)
+	delete(m.acks, name)
+	delete(m.lastInfoSent, name)
+	delete(m.pausedUntil, name)
+	m.forgetRepeats(name)
+	delete(m.errorCategories, name)
+	delete(m.mismatches, name)
+	delete(m.onboarding, name)
+	delete(m.accountLocations, name)
+	delete(m.accountLocationFailures, name)
+	m.mu.Unlock()
+
+	if m.notifyOnRemove {
+		m.sendMessage(statusMessage(ProviderStatus{Provider: name}, fmt.Sprintf("⏹️ Stopped monitoring provider %s", name)))
+	}
+	return nil
+}
+
+// SetProviderEnabled temporarily stops or resumes checking a provider without removing it
+// Sends a confirmation message on every change if Config.NotifyOnRemove is set
+func (m *vpsMonitor[T]) SetProviderEnabled(name string, enabled bool) error {
+	if !m.hasProvider(name) {
+		return fmt.Errorf("unknown provider: %s", name)
+	}
+
+	m.providersMu.Lock()
+	changed := m.disabled[name] == enabled
+	if enabled {
+		delete(m.disabled, name)
+	} else {
+		if m.disabled == nil {
+			m.disabled = make(map[string]bool)
+		}
+		m.disabled[name] = true
+	}
+	m.providersMu.Unlock()
+
+	if !changed || !m.notifyOnRemove {
+		return nil
+	}
+	text := fmt.Sprintf("⏸️ Stopped monitoring provider %s (disabled)", name)
+	if enabled {
+		text = fmt.Sprintf("▶️ Resumed monitoring provider %s", name)
+	}
+	m.sendMessage(statusMessage(ProviderStatus{Provider: name}, text))
+	return nil
+}
@@ -0,0 +1,171 @@
+package neverforgetvps
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/custom-app/NeverForgetVPS/provider/vdsina"
+)
+
+func TestOnboardingMessage(t *testing.T) {
+	clock := newTestClock()
+	m, sent := newTestMonitor(t, Config{}, clock, newStubProvider("vdsina", daysFrom(clock.Now(), 20)))
+
+	m.CheckNow(context.Background(), 0)
+	if got := countContaining(sent.texts(), "Now monitoring"); got != 0 {
+		t.Fatalf("%d onboarding messages for a provider configured at start, want none", got)
+	}
+
+	added := newStubProvider("oneprovider", daysFrom(clock.Now(), 10))
+	if err := m.AddProvider(added, time.Second); err != nil {
+		t.Fatal(err)
+	}
+	failing := newStubProvider("cloudflare", nil)
+	failing.set(nil, errors.New("boom"))
+	if err := m.AddProvider(failing, time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	sent.reset()
+	m.CheckNow(context.Background(), 0)
+	texts := sent.texts()
+	if countContaining(texts, "✅ Now monitoring provider oneprovider, next payment 2026-10-26") != 1 {
+		t.Fatalf("messages %q, want the onboarding message of the added provider", texts)
+	}
+	if countContaining(texts, "Now monitoring provider cloudflare") != 0 {
+		t.Fatalf("messages %q, want no onboarding message before the first successful check", texts)
+	}
+
+	// The failing provider is confirmed once it succeeds, the other one only once
+	failing.set(nil, nil)
+	sent.reset()
+	m.CheckNow(context.Background(), 0)
+	texts = sent.texts()
+	if countContaining(texts, "Now monitoring provider oneprovider") != 0 || countContaining(texts, "✅ Now monitoring provider cloudflare, no payment due") != 1 {
+		t.Fatalf("messages %q, want only the recovered provider's onboarding message", texts)
+	}
+}
+
+func TestAddProviderDuplicate(t *testing.T) {
+	for _, cacheTTL := range []time.Duration{0, time.Hour} {
+		m, _ := newTestMonitor(t, Config{VdsinaAPIKey: "key", CacheTTL: cacheTTL}, nil)
+
+		same, err := vdsina.New("key")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := m.AddProvider(same, time.Second); !errors.Is(err, ErrDuplicateProvider) {
+			t.Errorf("cache TTL %v: adding the same account: %v, want ErrDuplicateProvider", cacheTTL, err)
+		}
+
+		other, err := vdsina.New("other key")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := m.AddProvider(other, time.Second); err == nil || errors.Is(err, ErrDuplicateProvider) {
+			t.Errorf("cache TTL %v: adding another account under a taken name: %v, want a name conflict", cacheTTL, err)
+		}
+
+		if entries := m.enabledProviders(); len(entries) != 1 {
+			t.Errorf("cache TTL %v: %d providers registered, want the account polled once", cacheTTL, len(entries))
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	clock := newTestClock()
+	own := newStubProvider("vdsina", daysFrom(clock.Now(), 20))
+	m, sent := newTestMonitor(t, Config{Labels: map[string]string{"vdsina": "prod"}}, clock, own)
+
+	imported := newStubProvider("oneprovider", daysFrom(clock.Now(), 20))
+	other, _ := newTestMonitor(t, Config{Labels: map[string]string{"oneprovider": "staging"}}, clock, imported, newStubProvider("cloudflare", nil))
+	if err := m.Merge(other); err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+
+	m.CheckNow(context.Background(), 0)
+	if own.callCount() != 1 || imported.callCount() != 1 {
+		t.Errorf("providers checked %d and %d times, want every provider checked once", own.callCount(), imported.callCount())
+	}
+	if entries := m.enabledProviders(); len(entries) != 3 {
+		t.Errorf("%d providers after the merge, want 3", len(entries))
+	}
+	texts := sent.texts()
+	if countContaining(texts, "[staging] ℹ️ INFO: Provider oneprovider") != 1 || countContaining(texts, "[prod] ℹ️ INFO: Provider vdsina") != 1 {
+		t.Errorf("messages %q, want both providers with their labels", texts)
+	}
+
+	t.Run("name conflict", func(t *testing.T) {
+		conflicting, _ := newTestMonitor(t, Config{}, clock, newStubProvider("timeweb", nil), newStubProvider("vdsina", nil))
+		if err := m.Merge(conflicting); err == nil {
+			t.Fatal("merging another vdsina provider succeeded, want a conflict")
+		}
+		if m.hasProvider("timeweb") {
+			t.Error("timeweb imported despite the conflict, want nothing imported")
+		}
+	})
+
+	t.Run("same account", func(t *testing.T) {
+		first, _ := newTestMonitor(t, Config{VdsinaAPIKey: "key"}, nil)
+		second, _ := newTestMonitor(t, Config{VdsinaAPIKey: "key"}, nil)
+		if err := first.Merge(second); err != nil {
+			t.Fatalf("merging the same account: %v, want it skipped", err)
+		}
+		if entries := first.enabledProviders(); len(entries) != 1 {
+			t.Errorf("%d providers, want the account once", len(entries))
+		}
+	})
+
+	t.Run("different message type", func(t *testing.T) {
+		texts := newVPSMonitor(context.Background(), Config{VdsinaAPIKey: "key"}, func(message Message) string { return message.Text })
+		t.Cleanup(texts.Stop)
+		if err := m.Merge(texts); err == nil {
+			t.Fatal("merging a monitor with another message type succeeded, want an error")
+		}
+	})
+}
+
+func TestNotifyOnRemove(t *testing.T) {
+	for _, notify := range []bool{true, false} {
+		clock := newTestClock()
+		removed := newStubProvider("vdsina", nil)
+		m, sent := newTestMonitor(t, Config{NotifyOnRemove: notify}, clock, removed, newStubProvider("oneprovider", nil))
+
+		if err := m.SetProviderEnabled("oneprovider", false); err != nil {
+			t.Fatal(err)
+		}
+		// Disabling again changes nothing and is not confirmed twice
+		if err := m.SetProviderEnabled("oneprovider", false); err != nil {
+			t.Fatal(err)
+		}
+		if err := m.SetProviderEnabled("oneprovider", true); err != nil {
+			t.Fatal(err)
+		}
+		if err := m.RemoveProvider("vdsina"); err != nil {
+			t.Fatal(err)
+		}
+		if err := m.RemoveProvider("vdsina"); err == nil {
+			t.Error("removing an unknown provider succeeded, want an error")
+		}
+
+		var want []string
+		if notify {
+			want = []string{
+				"⏸️ Stopped monitoring provider oneprovider (disabled)",
+				"▶️ Resumed monitoring provider oneprovider",
+				"⏹️ Stopped monitoring provider vdsina",
+			}
+		}
+		if texts := sent.texts(); !slices.Equal(texts, want) {
+			t.Errorf("notify %v: messages %q, want %q", notify, texts, want)
+		}
+
+		m.CheckNow(context.Background(), 0)
+		if removed.callCount() != 0 {
+			t.Errorf("removed provider checked %d times, want none", removed.callCount())
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package neverforgetvps
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+// syncBuffer is a strings.Builder safe for concurrent writes
+type syncBuffer struct {
+	mu sync.Mutex
+	b  strings.Builder
+}
+
+func (s *syncBuffer) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.b.Write(p)
+}
+
+func (s *syncBuffer) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.b.String()
+}
+
+func TestRequestID(t *testing.T) {
+	for _, sendHeader := range []bool{true, false} {
+		t.Run(fmt.Sprintf("send header %v", sendHeader), func(t *testing.T) {
+			var headersMu sync.Mutex
+			headers := make(map[string]string) // X-Request-ID of the first request per provider, the payment date check
+			newServer := func(name, body string, status int) string {
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					headersMu.Lock()
+					if _, found := headers[name]; !found {
+						headers[name] = r.Header.Get(provider.RequestIDHeader)
+					}
+					headersMu.Unlock()
+					w.WriteHeader(status)
+					w.Write([]byte(body))
+				}))
+				t.Cleanup(server.Close)
+				return server.URL
+			}
+
+			logs := &syncBuffer{}
+			var statusesMu sync.Mutex
+			var statuses []ProviderStatus
+			config := Config{
+				VdsinaAPIKey:         "key",
+				OneProviderAPIKey:    "key",
+				OneProviderClientKey: "client",
+				SendRequestID:        sendHeader,
+				Logger:               slog.New(slog.NewJSONHandler(logs, &slog.HandlerOptions{Level: slog.LevelDebug})),
+				ProviderOptions: map[string][]provider.Option{
+					"vdsina":      {provider.WithBaseURL(newServer("vdsina", `{"status":"ok","data":{"forecast":"2026-11-20","can":{"add_user":true,"add_service":true}}}`, http.StatusOK))},
+					"oneprovider": {provider.WithBaseURL(newServer("oneprovider", "unavailable", http.StatusBadGateway))},
+				},
+				OnResult: func(status ProviderStatus) {
+					statusesMu.Lock()
+					defer statusesMu.Unlock()
+					statuses = append(statuses, status)
+				},
+			}
+			m, _ := newTestMonitor(t, config, newTestClock())
+			m.CheckNow(context.Background(), 0)
+
+			// The request ID logged when each provider check started
+			logged := make(map[string]string)
+			for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+				var record struct {
+					Msg       string `json:"msg"`
+					Provider  string `json:"provider"`
+					RequestID string `json:"request_id"`
+				}
+				if err := json.Unmarshal([]byte(line), &record); err != nil {
+					t.Fatalf("log line %q: %v", line, err)
+				}
+				if record.Msg == "checking provider" {
+					logged[record.Provider] = record.RequestID
+				}
+			}
+
+			headersMu.Lock()
+			defer headersMu.Unlock()
+			for _, name := range []string{"vdsina", "oneprovider"} {
+				if logged[name] == "" {
+					t.Fatalf("no request ID logged for %s: %s", name, logs.String())
+				}
+				want := ""
+				if sendHeader {
+					want = logged[name]
+				}
+				if headers[name] != want {
+					t.Errorf("%s header %q, want %q", name, headers[name], want)
+				}
+			}
+			if logged["vdsina"] == logged["oneprovider"] {
+				t.Errorf("both providers logged request ID %s, want one per call", logged["vdsina"])
+			}
+
+			// The failed check carries its request ID
+			statusesMu.Lock()
+			defer statusesMu.Unlock()
+			for _, status := range statuses {
+				var providerErr *ProviderError
+				if status.Provider != "oneprovider" {
+					continue
+				}
+				if !errors.As(status.Err, &providerErr) || providerErr.RequestID != logged["oneprovider"] || !strings.Contains(providerErr.Error(), logged["oneprovider"]) {
+					t.Errorf("oneprovider error %v, want request ID %s", status.Err, logged["oneprovider"])
+				}
+			}
+		})
+	}
+}
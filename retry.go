@@ -0,0 +1,48 @@
+package neverforgetvps
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+// DefaultCheckRetryDelay is the delay before retrying a transient provider failure when CheckRetryDelay is not set
+const DefaultCheckRetryDelay = 5 * time.Second
+
+// fetchWithRetries fetches the payment dates of a provider, each attempt limited by timeout
+// Failures classified as provider.ErrTransient (timeouts, 429, 5xx, network errors) are retried up to
+// Config.CheckRetries times; provider.ErrPermanent and unclassified failures are returned at once,
+// since repeating the request cannot fix them
+func (m *vpsMonitor[T]) fetchWithRetries(ctx context.Context, p provider.Provider, timeout time.Duration) (*time.Time, []time.Time, string, error) {
+	for attempt := 0; ; attempt++ {
+		attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+		nextDate, candidates, account, err := m.safeFetchPaymentDates(attemptCtx, p)
+		cancel()
+		if err == nil || attempt >= m.checkRetries || !isRetryable(err) {
+			return nextDate, candidates, account, err
+		}
+
+		m.logger.Warn("provider check failed, retrying",
+			slog.String("provider", p.GetName()),
+			slog.Int("attempt", attempt+1),
+			slog.Duration("delay", m.checkRetryDelay),
+			slog.Any("error", err))
+
+		timer := time.NewTimer(m.checkRetryDelay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, nil, "", err
+		}
+	}
+}
+
+// isRetryable reports whether a provider failure is transient and not permanent
+func isRetryable(err error) bool {
+	classified := newProviderError("", err)
+	return errors.Is(classified, provider.ErrTransient) && !errors.Is(classified, provider.ErrPermanent)
+}
@@ -0,0 +1,60 @@
+package neverforgetvps
+
+import (
+	"context"
+)
+
+// riskHorizonDays is the number of days before a payment date at which it starts adding risk
+const riskHorizonDays = 30
+
+// RiskScore returns a 0-100 score summarizing how close any provider is to losing service
+// Results of the last check are reused like in Summary
+//
+// Each provider gets a risk of its own:
+//   - overdue payment: 100
+//   - payment due in d days: 90 at d = 0 falling linearly to 0 at d >= 30, i.e. 90 - 3d
+//   - failed check: 50, the standing is unknown
+//   - no payment due: 0
+//
+// The score is the highest provider risk plus 5 for every other provider with a risk of 50 or more
+// (near, overdue or failing), capped at 100
+func (m *vpsMonitor[T]) RiskScore(ctx context.Context) (int, error) {
+	statuses, err := m.freshStatuses(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	highest, elevated := 0, 0
+	for _, status := range statuses {
+		risk := providerRisk(status)
+		if risk >= 50 {
+			elevated++
+		}
+		highest = max(highest, risk)
+	}
+
+	// The highest risk itself is not counted twice
+	if highest >= 50 {
+		elevated--
+	}
+	return min(highest+5*elevated, 100), nil
+}
+
+// providerRisk returns the 0-100 risk of a single provider status as documented on RiskScore
+func providerRisk(status ProviderStatus) int {
+	switch status.Outcome() {
+	case OutcomeFailed:
+		return 50
+	case OutcomeNoPaymentDue:
+		return 0
+	}
+
+	switch {
+	case status.DaysUntil < 0:
+		return 100
+	case status.DaysUntil >= riskHorizonDays:
+		return 0
+	default:
+		return 90 - status.DaysUntil*90/riskHorizonDays
+	}
+}
@@ -0,0 +1,79 @@
+package neverforgetvps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+func TestRiskScore(t *testing.T) {
+	// state describes the result of a single provider check
+	type state struct {
+		days   int // Days until the payment date, ignored if none or failed
+		none   bool
+		failed bool
+	}
+
+	tests := []struct {
+		name   string
+		states []state
+		want   int
+	}{
+		{name: "nothing due", states: []state{{none: true}, {none: true}}, want: 0},
+		{name: "far payments", states: []state{{days: 30}, {days: 45}}, want: 0},
+		{name: "single near payment", states: []state{{days: 10}, {none: true}}, want: 60},
+		{name: "due today", states: []state{{days: 0}}, want: 90},
+		{name: "overdue", states: []state{{days: -3}, {days: 40}}, want: 100},
+		{name: "failed check", states: []state{{failed: true}, {none: true}}, want: 50},
+		// 60 for the highest plus 5 for the elevated failure, the 20 days away one adds nothing
+		{name: "near payment and failure", states: []state{{days: 10}, {failed: true}, {days: 20}}, want: 65},
+		{name: "several elevated", states: []state{{days: 5}, {days: 12}, {failed: true}}, want: 85},
+		{name: "capped", states: []state{{days: -1}, {days: -2}, {failed: true}}, want: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newTestClock()
+			providers := make([]provider.Provider, len(tt.states))
+			for i, s := range tt.states {
+				p := newStubProvider(fmt.Sprintf("provider%d", i), nil)
+				switch {
+				case s.failed:
+					p.set(nil, errors.New("boom"))
+				case !s.none:
+					p.set(daysFrom(clock.Now(), s.days), nil)
+				}
+				providers[i] = p
+			}
+			m, _ := newTestMonitor(t, Config{}, clock, providers...)
+
+			score, err := m.RiskScore(context.Background())
+			if err != nil {
+				t.Fatalf("RiskScore: %v", err)
+			}
+			if score != tt.want {
+				t.Errorf("score %d, want %d", score, tt.want)
+			}
+		})
+	}
+}
+
+func TestRiskScoreReusesLastCheck(t *testing.T) {
+	clock := newTestClock()
+	p := newStubProvider("vdsina", daysFrom(clock.Now(), 10))
+	m, _ := newTestMonitor(t, Config{}, clock, p)
+
+	m.CheckNow(context.Background(), 0)
+	calls := p.callCount()
+	clock.Advance(time.Minute)
+	if _, err := m.RiskScore(context.Background()); err != nil {
+		t.Fatalf("RiskScore: %v", err)
+	}
+	if p.callCount() != calls {
+		t.Errorf("%d provider calls after RiskScore, want the %d of the last check", p.callCount(), calls)
+	}
+}
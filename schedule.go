@@ -0,0 +1,109 @@
+package neverforgetvps
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Schedule describes fixed check times as an alternative to CheckInterval
+// For example, Weekdays: Monday-Friday and Times: "09:00" checks at 09:00 on weekdays
+type Schedule struct {
+	Weekdays []time.Weekday // Days on which checks run (optional, default: every day)
+	Times    []string       // Times of day in "15:04" format (required, at least one)
+	Location *time.Location // Time zone for Weekdays and Times (optional, default: time.Local)
+}
+
+// schedule is the parsed form of Schedule used by the monitor
+type schedule struct {
+	weekdays map[time.Weekday]bool // Allowed weekdays, empty means every day
+	minutes  []int                 // Check times as minutes since midnight, sorted ascending
+	location *time.Location
+}
+
+// parseSchedule validates the schedule and converts it to its internal form
+func parseSchedule(s Schedule) (*schedule, error) {
+	if len(s.Times) == 0 {
+		return nil, fmt.Errorf("schedule must contain at least one time")
+	}
+
+	parsed := &schedule{
+		weekdays: make(map[time.Weekday]bool, len(s.Weekdays)),
+		location: s.Location,
+	}
+	if parsed.location == nil {
+		parsed.location = time.Local
+	}
+
+	for _, day := range s.Weekdays {
+		if day < time.Sunday || day > time.Saturday {
+			return nil, fmt.Errorf("invalid weekday: %d", day)
+		}
+		parsed.weekdays[day] = true
+	}
+
+	for _, value := range s.Times {
+		t, err := time.Parse("15:04", value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule time %q: %w", value, err)
+		}
+		parsed.minutes = append(parsed.minutes, t.Hour()*60+t.Minute())
+	}
+	sort.Ints(parsed.minutes)
+
+	return parsed, nil
+}
+
+// next returns the first scheduled time strictly after the given moment
+// Candidates are built from the wall clock in the schedule location, so DST transitions
+// keep checks at the configured local time instead of drifting by an hour
+func (s *schedule) next(after time.Time) time.Time {
+	local := after.In(s.location)
+	year, month, day := local.Date()
+
+	// A week ahead is always enough to find an allowed weekday
+	for offset := 0; offset <= 7; offset++ {
+		date := time.Date(year, month, day+offset, 0, 0, 0, 0, s.location)
+		if len(s.weekdays) > 0 && !s.weekdays[date.Weekday()] {
+			continue
+		}
+
+		for _, minutes := range s.minutes {
+			candidate := time.Date(date.Year(), date.Month(), date.Day(), minutes/60, minutes%60, 0, 0, s.location)
+			if candidate.After(after) {
+				return candidate
+			}
+		}
+	}
+
+	// Unreachable for a validated schedule, fall back to one day later
+	return after.Add(24 * time.Hour)
+}
+
+// setNextCheck records when the next automatic check is scheduled
+// Ignored once the monitor is stopped
+func (m *vpsMonitor[T]) setNextCheck(next time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.stopped {
+		m.nextCheck = next
+	}
+}
+
+// NextCheckTime returns when the next automatic check is scheduled
+// Returns the zero time before Start
+func (m *vpsMonitor[T]) NextCheckTime() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.nextCheck
+}
+
+// TimeUntilNextCheck returns the time left until the next automatic check
+// Returns zero if no check is scheduled or the check is already due
+func (m *vpsMonitor[T]) TimeUntilNextCheck() time.Duration {
+	next := m.NextCheckTime()
+	if next.IsZero() {
+		return 0
+	}
+	return max(next.Sub(m.now()), 0)
+}
@@ -0,0 +1,140 @@
+package neverforgetvps
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduleNext(t *testing.T) {
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+	weekdays := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+
+	tests := []struct {
+		name     string
+		schedule Schedule
+		after    time.Time
+		want     time.Time
+	}{
+		{
+			name:     "later time the same day",
+			schedule: Schedule{Weekdays: weekdays, Times: []string{"18:00", "09:00"}, Location: time.UTC},
+			after:    time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC), // Wednesday
+			want:     time.Date(2026, 10, 14, 18, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "friday evening skips the weekend",
+			schedule: Schedule{Weekdays: weekdays, Times: []string{"09:00"}, Location: time.UTC},
+			after:    time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC), // Friday, exactly at the check time
+			want:     time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC), // Monday
+		},
+		{
+			name:     "saturday skips to monday",
+			schedule: Schedule{Weekdays: weekdays, Times: []string{"09:00"}, Location: time.UTC},
+			after:    time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC),
+			want:     time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "every day without weekdays",
+			schedule: Schedule{Times: []string{"09:00"}, Location: time.UTC},
+			after:    time.Date(2026, 10, 17, 10, 0, 0, 0, time.UTC),
+			want:     time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "local time kept across the DST change",
+			schedule: Schedule{Times: []string{"09:00"}, Location: berlin},
+			after:    time.Date(2026, 10, 24, 9, 0, 0, 0, berlin), // Summer time ends on October 25
+			want:     time.Date(2026, 10, 25, 8, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := parseSchedule(tt.schedule)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := s.next(tt.after); !got.Equal(tt.want) {
+				t.Errorf("next(%v) = %v, want %v", tt.after, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseScheduleInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule Schedule
+	}{
+		{name: "no times", schedule: Schedule{}},
+		{name: "invalid time", schedule: Schedule{Times: []string{"25:00"}}},
+		{name: "invalid weekday", schedule: Schedule{Times: []string{"09:00"}, Weekdays: []time.Weekday{7}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseSchedule(tt.schedule); err == nil {
+				t.Error("parseSchedule succeeded, want an error")
+			}
+		})
+	}
+}
+
+// waitForNextCheck waits until the monitor schedules its next automatic check at want
+func waitForNextCheck(t *testing.T, m *vpsMonitor[Message], want time.Time) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !m.NextCheckTime().Equal(want) {
+		if time.Now().After(deadline) {
+			t.Fatalf("next check at %v, want %v", m.NextCheckTime(), want)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestNextCheckTime(t *testing.T) {
+	t.Run("interval", func(t *testing.T) {
+		clock := newTestClock()
+		m, _ := newTestMonitor(t, Config{CheckInterval: time.Hour}, clock, newStubProvider("vdsina", nil))
+		if !m.NextCheckTime().IsZero() || m.TimeUntilNextCheck() != 0 {
+			t.Fatalf("next check at %v before Start, want none", m.NextCheckTime())
+		}
+
+		if err := m.Start(); err != nil {
+			t.Fatal(err)
+		}
+		waitForNextCheck(t, m, testNow.Add(time.Hour))
+		clock.Advance(20 * time.Minute)
+		if got := m.TimeUntilNextCheck(); got != 40*time.Minute {
+			t.Errorf("time until the next check %v, want 40m", got)
+		}
+		clock.Advance(2 * time.Hour)
+		if got := m.TimeUntilNextCheck(); got != 0 {
+			t.Errorf("time until an overdue check %v, want 0", got)
+		}
+
+		m.Stop()
+		if !m.NextCheckTime().IsZero() {
+			t.Errorf("next check at %v after Stop, want none", m.NextCheckTime())
+		}
+	})
+
+	t.Run("advances after a tick", func(t *testing.T) {
+		// Start just before the 12:00 check, so its timer fires right away
+		clock := newTestClock()
+		clock.Advance(-50 * time.Millisecond)
+		schedule := &Schedule{Times: []string{"12:00", "13:00"}, Location: time.UTC}
+		m, _ := newTestMonitor(t, Config{Schedule: schedule}, clock, newStubProvider("vdsina", nil))
+
+		if err := m.Start(); err != nil {
+			t.Fatal(err)
+		}
+		waitForNextCheck(t, m, testNow)
+		clock.Advance(time.Minute)
+
+		waitForNextCheck(t, m, testNow.Add(time.Hour))
+		if got, want := m.TimeUntilNextCheck(), 59*time.Minute+50*time.Millisecond; got != want {
+			t.Errorf("time until the next check %v, want %v", got, want)
+		}
+	})
+}
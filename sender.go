@@ -0,0 +1,73 @@
+package neverforgetvps
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+const (
+	// DefaultSendRetries is the default number of retries for a failed send function call
+	DefaultSendRetries = 3
+	// DefaultSendRetryDelay is the default delay before the first retry, doubled on each attempt
+	DefaultSendRetryDelay = time.Second
+)
+
+// NewVPSMonitorWithSender creates a new instance of VPSMonitor that delivers messages
+// through a synchronous send function instead of a channel
+// sendFunc is required - panic if nil
+// If sendFunc returns an error, the message is retried up to Config.SendRetries times
+// with exponential backoff starting at Config.SendRetryDelay, and every failure is logged
+// messageConverter is a function that converts text string to message type T
+func NewVPSMonitorWithSender[T any](ctx context.Context, config Config, sendFunc func(T) error, messageConverter func(string) T) VPSMonitor {
+	if sendFunc == nil {
+		panic("sendFunc is required")
+	}
+
+	m := newVPSMonitor(ctx, config, textConverter(messageConverter))
+	m.sendFunc = sendFunc
+	return m
+}
+
+// deliver sends a converted message with the send function, retrying failures
+// Returns whether the message was sent
+func (m *vpsMonitor[T]) deliver(msg T) bool {
+	delay := m.sendRetryDelay
+	for attempt := 0; ; attempt++ {
+		err := m.sendFunc(msg)
+		if err == nil {
+			return true
+		}
+
+		if attempt >= m.sendRetries {
+			m.logger.Error("failed to send message, giving up", slog.Int("attempts", attempt+1), slog.Any("error", err))
+			return false
+		}
+		m.logger.Warn("failed to send message, retrying", slog.Int("attempt", attempt+1), slog.Duration("delay", delay), slog.Any("error", err))
+
+		select {
+		case <-time.After(delay):
+			delay *= 2
+		case <-m.ctx.Done():
+			m.logger.Error("failed to send message, monitor stopped", slog.Any("error", err))
+			return false
+		}
+	}
+}
+
+// retrySettings returns send retry settings with defaults applied
+func retrySettings(config Config) (int, time.Duration) {
+	retries := config.SendRetries
+	if retries == 0 {
+		retries = DefaultSendRetries
+	}
+	if retries < 0 {
+		retries = 0
+	}
+
+	delay := config.SendRetryDelay
+	if delay <= 0 {
+		delay = DefaultSendRetryDelay
+	}
+	return retries, delay
+}
@@ -0,0 +1,93 @@
+package neverforgetvps
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakySender fails the first failures calls of send
+type flakySender struct {
+	mu       sync.Mutex
+	failures int
+	attempts int
+	sent     []Message
+}
+
+func (s *flakySender) send(message Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attempts++
+	if s.attempts <= s.failures {
+		return errors.New("send failed")
+	}
+	s.sent = append(s.sent, message)
+	return nil
+}
+
+func TestSendFuncRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		failures     int
+		wantAttempts int
+		wantSent     int
+	}{
+		{name: "succeeds at once", failures: 0, wantAttempts: 1, wantSent: 1},
+		{name: "fails then succeeds", failures: 2, wantAttempts: 3, wantSent: 1},
+		{name: "gives up", failures: 10, wantAttempts: 4, wantSent: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newTestClock()
+			p := newStubProvider("vdsina", daysFrom(clock.Now(), 2))
+			m, _ := newTestMonitor(t, Config{SendRetries: 3, SendRetryDelay: time.Millisecond}, clock, p)
+			sender := &flakySender{failures: tt.failures}
+			m.sendFunc = sender.send
+
+			m.CheckNow(context.Background(), 0)
+
+			sender.mu.Lock()
+			defer sender.mu.Unlock()
+			if sender.attempts != tt.wantAttempts || len(sender.sent) != tt.wantSent {
+				t.Fatalf("%d attempts and %d messages sent, want %d and %d", sender.attempts, len(sender.sent), tt.wantAttempts, tt.wantSent)
+			}
+		})
+	}
+}
+
+func TestConverterPanic(t *testing.T) {
+	clock := newTestClock()
+	logs := &syncBuffer{}
+	config := Config{Logger: slog.New(slog.NewTextHandler(logs, nil))}
+	m, sent := newTestMonitor(t, config, clock, newStubProvider("vdsina", daysFrom(clock.Now(), 2)))
+
+	var broken atomic.Bool
+	broken.Store(true)
+	m.messageConverter = func(message Message) Message {
+		if broken.Load() {
+			panic("converter bug")
+		}
+		return message
+	}
+
+	m.CheckNow(context.Background(), 0)
+	if texts := sent.texts(); len(texts) != 0 {
+		t.Fatalf("messages %q from a panicking converter, want none", texts)
+	}
+	if !strings.Contains(logs.String(), "message converter panicked") {
+		t.Errorf("logs %q, want the converter panic", logs.String())
+	}
+
+	broken.Store(false)
+	clock.Advance(time.Hour)
+	m.CheckNow(context.Background(), 0)
+	if texts := sent.texts(); len(texts) != 1 || !strings.Contains(texts[0], "WARNING: Provider vdsina") {
+		t.Fatalf("messages %q after the converter is fixed, want the warning", texts)
+	}
+}
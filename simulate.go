@@ -0,0 +1,84 @@
+package neverforgetvps
+
+import (
+	"time"
+)
+
+// Simulate replays a scripted sequence of provider states and returns the messages the monitor
+// would have sent, e.g. to validate thresholds, formatters and repeat suppression
+// statuses[i] is the state observed at clock[i]; for statuses with a payment date, DaysUntil and
+// Severity are recalculated for that time. Extra entries of the longer slice are ignored
+// The monitor's settings are used, but its state is neither read nor changed and nothing is sent
+func (m *vpsMonitor[T]) Simulate(statuses []ProviderStatus, clock []time.Time) []string {
+	var now time.Time
+	sim := &vpsMonitor[T]{
+		now:                   func() time.Time { return now },
+		logger:                m.logger,
+		minNotifySeverity:     m.minNotifySeverity,
+		notifyProviders:       m.notifyProviders,
+		notifyNoPaymentDue:    m.notifyNoPaymentDue,
+		dedupeErrors:          m.dedupeErrors,
+		infoNotifyInterval:    m.infoNotifyInterval,
+		repeatBackoff:         m.repeatBackoff,
+		repeatBackoffMax:      m.repeatBackoffMax,
+		ackCooldown:           m.ackCooldown,
+		formatter:             m.formatter,
+		payURLs:               m.payURLs,
+		payURLAlways:          m.payURLAlways,
+		severityFunc:          m.severityFunc,
+		dayRounding:           m.dayRounding,
+		timezone:              m.timezone,
+		displayLocation:       m.displayLocation,
+		expectedDates:         m.expectedDates,
+		expectedDateTolerance: m.expectedDateTolerance,
+	}
+
+	m.providersMu.RLock()
+	sim.labels = make(map[string]string, len(m.labels))
+	for name, label := range m.labels {
+		sim.labels[name] = label
+	}
+	m.providersMu.RUnlock()
+
+	var sent []string
+	for i := 0; i < len(statuses) && i < len(clock); i++ {
+		now = clock[i]
+		status := sim.simulatedStatus(statuses[i])
+
+		messages := sim.statusMessages(status)
+		sim.tagLabel(status.Provider, messages)
+		for _, message := range messages {
+			if sim.shouldNotify(message, nil) {
+				sent = append(sent, message.text)
+				sim.messageDelivered(message)
+			}
+		}
+	}
+
+	return sent
+}
+
+// simulatedStatus completes a scripted status as a check at the current time would have
+func (m *vpsMonitor[T]) simulatedStatus(status ProviderStatus) ProviderStatus {
+	status.CheckedAt = m.now()
+	if m.displayLocation != nil {
+		status.Location = m.displayLocation
+	}
+	if status.Location == nil {
+		status.Location = m.timezone
+	}
+	status.NextDate, status.CandidateDates = normalizeDates(status.NextDate, status.CandidateDates, status.Location)
+
+	switch status.Outcome() {
+	case OutcomeFailed:
+		status.Severity = SeverityWarning
+	case OutcomePaymentDue:
+		status.DaysUntil = daysBetween(status.CheckedAt, *status.NextDate, m.dayRounding)
+		status.Severity = m.severityFunc(status.DaysUntil, status.Provider)
+	default:
+		status.DaysUntil = 0
+		status.Severity = SeverityInfo
+	}
+
+	return status
+}
@@ -0,0 +1,49 @@
+package neverforgetvps
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSimulate(t *testing.T) {
+	m, sent := newTestMonitor(t, Config{InfoNotifyInterval: 72 * time.Hour}, newTestClock(), newStubProvider("vdsina", nil))
+
+	// A payment date approached from 10 days out until it is 2 days overdue, checked daily
+	date := testNow.AddDate(0, 0, 10)
+	var statuses []ProviderStatus
+	var clock []time.Time
+	for day := 0; day <= 12; day++ {
+		statuses = append(statuses, ProviderStatus{Provider: "vdsina", NextDate: &date})
+		clock = append(clock, testNow.AddDate(0, 0, day))
+	}
+
+	// Info messages are throttled to one per 72 hours, the others are sent daily
+	const pay = "\nPay: https://cp.vdsina.com/"
+	want := []string{
+		"ℹ️ INFO: Provider vdsina - Next payment date: 2026-10-26 (10 days left)",
+		"ℹ️ INFO: Provider vdsina - Next payment date: 2026-10-26 (7 days left)",
+		"⚠️ ATTENTION: Provider vdsina - Payment due soon! Payment date: 2026-10-26 (5 days left)",
+		"⚠️ ATTENTION: Provider vdsina - Payment due soon! Payment date: 2026-10-26 (4 days left)",
+		"⚠️ ATTENTION: Provider vdsina - Payment due soon! Payment date: 2026-10-26 (3 days left)",
+		"🚨 WARNING: Provider vdsina - Urgent payment required! Payment due date: 2026-10-26 (2 day(s) left)" + pay,
+		"🚨 WARNING: Provider vdsina - Urgent payment required! Payment due date: 2026-10-26 (1 day(s) left)" + pay,
+		"🚨 WARNING: Provider vdsina - Urgent payment required! Payment due date: 2026-10-26 (0 day(s) left)" + pay,
+		"🚨🚨🚨 CRITICAL: Provider vdsina - Payment overdue! Payment date was 2026-10-26 (1 days ago). Urgent action required!" + pay,
+		"🚨🚨🚨 CRITICAL: Provider vdsina - Payment overdue! Payment date was 2026-10-26 (2 days ago). Urgent action required!" + pay,
+	}
+	if got := m.Simulate(statuses, clock); !slices.Equal(got, want) {
+		t.Errorf("simulated messages\n%q\nwant\n%q", got, want)
+	}
+
+	// The simulation is deterministic and leaves the monitor untouched
+	if got := m.Simulate(statuses, clock); !slices.Equal(got, want) {
+		t.Errorf("second simulation %q, want the same messages", got)
+	}
+	if texts := sent.texts(); len(texts) != 0 {
+		t.Errorf("messages %q sent by a simulation, want none", texts)
+	}
+	if _, _, found := m.LastError("vdsina"); found || len(m.statuses) != 0 {
+		t.Errorf("statuses %v recorded by a simulation, want none", m.statuses)
+	}
+}
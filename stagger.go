@@ -0,0 +1,66 @@
+package neverforgetvps
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// staggerKey is the context key marking automatic check cycles, which honor CheckOffsets
+type staggerKey struct{}
+
+// automaticCheck runs a check cycle of the background loop
+// Unlike CheckNow, automatic cycles delay each provider by its configured offset
+func (m *vpsMonitor[T]) automaticCheck() {
+	m.checkPaymentDates(context.WithValue(m.ctx, staggerKey{}, true))
+
+	// The first automatic check is the initial one
+	m.startOnce.Do(func() {
+		if m.notifyOnStart && m.ctx.Err() == nil {
+			m.sendStartSummary()
+		}
+	})
+}
+
+// validateCheckOffsets checks that every offset is non-negative
+func validateCheckOffsets(offsets map[string]time.Duration) error {
+	for name, offset := range offsets {
+		if offset < 0 {
+			return fmt.Errorf("offset of provider %s must not be negative, got %s", name, offset)
+		}
+	}
+	return nil
+}
+
+// staggerEntries orders providers by their check offset for a sequential cycle
+// Cycles not started by the background loop keep the original order
+func (m *vpsMonitor[T]) staggerEntries(ctx context.Context, entries []providerEntry) {
+	if len(m.checkOffsets) == 0 || ctx.Value(staggerKey{}) == nil {
+		return
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return m.checkOffsets[entries[i].Provider.GetName()] < m.checkOffsets[entries[j].Provider.GetName()]
+	})
+}
+
+// waitForOffset blocks until the provider's offset from the cycle start has passed
+// Returns immediately for cycles not started by the background loop and when ctx is done
+func (m *vpsMonitor[T]) waitForOffset(ctx context.Context, name string, cycleStart time.Time) {
+	offset := m.checkOffsets[name]
+	if offset <= 0 || ctx.Value(staggerKey{}) == nil {
+		return
+	}
+
+	delay := cycleStart.Add(offset).Sub(m.now())
+	if delay <= 0 {
+		return
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
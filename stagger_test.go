@@ -0,0 +1,90 @@
+package neverforgetvps
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCheckOffsets(t *testing.T) {
+	offsets := map[string]time.Duration{"oneprovider": 100 * time.Millisecond, "cloudflare": 50 * time.Millisecond}
+
+	// run checks vdsina, oneprovider and cloudflare, returning the order and delay from the cycle start of each check
+	run := func(check func(m *vpsMonitor[Message])) ([]string, map[string]time.Duration) {
+		var mu sync.Mutex
+		var order []string
+		delays := make(map[string]time.Duration)
+		var start time.Time
+		var stubs []*stubProvider
+		for _, name := range []string{"vdsina", "oneprovider", "cloudflare"} {
+			stub := newStubProvider(name, nil)
+			stub.hook = func(context.Context) {
+				mu.Lock()
+				defer mu.Unlock()
+				order = append(order, name)
+				delays[name] = time.Since(start)
+			}
+			stubs = append(stubs, stub)
+		}
+		m, _ := newTestMonitor(t, Config{CheckOffsets: offsets}, newTestClock(), stubs[0], stubs[1], stubs[2])
+
+		start = time.Now()
+		check(m)
+		mu.Lock()
+		defer mu.Unlock()
+		return order, delays
+	}
+
+	t.Run("automatic checks", func(t *testing.T) {
+		order, delays := run(func(m *vpsMonitor[Message]) { m.automaticCheck() })
+		if want := []string{"vdsina", "cloudflare", "oneprovider"}; !slices.Equal(order, want) {
+			t.Errorf("check order %q, want %q", order, want)
+		}
+		for name, offset := range offsets {
+			if delays[name] < offset {
+				t.Errorf("%s checked %v after the cycle start, want at its offset %v", name, delays[name], offset)
+			}
+		}
+	})
+
+	t.Run("CheckNow ignores offsets", func(t *testing.T) {
+		order, delays := run(func(m *vpsMonitor[Message]) { m.CheckNow(context.Background(), 0) })
+		if want := []string{"vdsina", "oneprovider", "cloudflare"}; !slices.Equal(order, want) {
+			t.Errorf("check order %q, want registration order %q", order, want)
+		}
+		if delays["oneprovider"] >= offsets["oneprovider"] {
+			t.Errorf("oneprovider checked %v after the start, want no offset", delays["oneprovider"])
+		}
+	})
+}
+
+func TestWaitForOffset(t *testing.T) {
+	clock := newTestClock()
+	m, _ := newTestMonitor(t, Config{CheckOffsets: map[string]time.Duration{"vdsina": 30 * time.Minute}}, clock, newStubProvider("vdsina", nil))
+	ctx := context.WithValue(context.Background(), staggerKey{}, true)
+
+	// The offset is measured with the monitor clock: once it has passed, there is nothing to wait for
+	cycleStart := clock.Now()
+	clock.Advance(30 * time.Minute)
+	done := make(chan struct{})
+	go func() {
+		m.waitForOffset(ctx, "vdsina", cycleStart)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("still waiting after the offset passed on the monitor clock")
+	}
+
+	// Before the offset the check waits until the context is done
+	waitCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	started := time.Now()
+	m.waitForOffset(waitCtx, "vdsina", clock.Now().Add(-29*time.Minute))
+	if waited := time.Since(started); waited < 50*time.Millisecond {
+		t.Errorf("waited %v with a minute of the offset left, want until the context is done", waited)
+	}
+}
@@ -0,0 +1,83 @@
+package neverforgetvps
+
+import (
+	"time"
+)
+
+// StateTrigger describes what caused a provider's tracked state to transition
+type StateTrigger int
+
+const (
+	// TriggerFirstCheck - the provider was checked for the first time
+	TriggerFirstCheck StateTrigger = iota
+	// TriggerThreshold - the payment date crossed a severity threshold
+	TriggerThreshold
+	// TriggerDateChanged - the payment date moved, e.g. after a payment
+	TriggerDateChanged
+	// TriggerError - a check failed after a successful one
+	TriggerError
+	// TriggerRecovery - a check succeeded after a failed one
+	TriggerRecovery
+)
+
+// String returns the human-readable trigger name
+func (t StateTrigger) String() string {
+	switch t {
+	case TriggerFirstCheck:
+		return "first_check"
+	case TriggerThreshold:
+		return "threshold"
+	case TriggerDateChanged:
+		return "date_changed"
+	case TriggerError:
+		return "error"
+	case TriggerRecovery:
+		return "recovery"
+	default:
+		return "unknown"
+	}
+}
+
+// StateChange is a record of a provider's tracked state transitioning between two checks
+// Previous fields are zero for TriggerFirstCheck
+type StateChange struct {
+	Provider         string       // Provider name
+	Trigger          StateTrigger // What caused the transition
+	PreviousSeverity Severity     // Severity before the transition
+	PreviousDate     *time.Time   // Next payment date before the transition, nil if unknown
+	Severity         Severity     // Severity after the transition
+	Date             *time.Time   // Next payment date after the transition, nil if unknown
+	Err              error        // Check failure after the transition, nil on success
+	At               time.Time    // When the check causing the transition ran
+}
+
+// newStateChange builds the record of a status change
+func newStateChange(change ProviderStatusChange) StateChange {
+	current := change.Current
+	record := StateChange{
+		Provider: change.Provider,
+		Trigger:  TriggerFirstCheck,
+		Severity: current.Severity,
+		Date:     current.NextDate,
+		Err:      current.Err,
+		At:       current.CheckedAt,
+	}
+	if change.Previous == nil {
+		return record
+	}
+
+	previous := *change.Previous
+	record.PreviousSeverity = previous.Severity
+	record.PreviousDate = previous.NextDate
+	switch {
+	case previous.Err == nil && current.Err != nil:
+		record.Trigger = TriggerError
+	case previous.Err != nil && current.Err == nil:
+		record.Trigger = TriggerRecovery
+	case previous.Severity != current.Severity:
+		record.Trigger = TriggerThreshold
+	default:
+		record.Trigger = TriggerDateChanged
+	}
+	return record
+}
@@ -0,0 +1,92 @@
+package neverforgetvps
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestOnStateChange(t *testing.T) {
+	clock := newTestClock()
+	first := clock.Now().AddDate(0, 0, 4)
+	moved := clock.Now().AddDate(0, 0, 3)
+	boom := errors.New("boom")
+
+	p := newStubProvider("vdsina", &first)
+	var mu sync.Mutex
+	var changes []StateChange
+	config := Config{OnStateChange: func(change StateChange) {
+		mu.Lock()
+		defer mu.Unlock()
+		changes = append(changes, change)
+	}}
+	m, _ := newTestMonitor(t, config, clock, p)
+
+	steps := []struct {
+		name    string
+		advance time.Duration
+		date    *time.Time
+		err     error
+		want    *StateChange // Expected record, nil for no transition
+	}{
+		{name: "first check", date: &first,
+			want: &StateChange{Trigger: TriggerFirstCheck, Severity: SeverityAttention, Date: &first}},
+		{name: "unchanged", advance: time.Hour, date: &first},
+		{name: "threshold crossed", advance: 47 * time.Hour, date: &first,
+			want: &StateChange{Trigger: TriggerThreshold, PreviousSeverity: SeverityAttention, PreviousDate: &first, Severity: SeverityWarning, Date: &first}},
+		{name: "date moved", date: &moved,
+			want: &StateChange{Trigger: TriggerDateChanged, PreviousSeverity: SeverityWarning, PreviousDate: &first, Severity: SeverityWarning, Date: &moved}},
+		{name: "failure", advance: time.Hour, err: boom,
+			want: &StateChange{Trigger: TriggerError, PreviousSeverity: SeverityWarning, PreviousDate: &moved, Severity: SeverityWarning, Err: boom}},
+		{name: "recovery", advance: time.Hour, date: &moved,
+			want: &StateChange{Trigger: TriggerRecovery, PreviousSeverity: SeverityWarning, Severity: SeverityWarning, Date: &moved}},
+	}
+
+	for _, step := range steps {
+		clock.Advance(step.advance)
+		p.set(step.date, step.err)
+		mu.Lock()
+		changes = nil
+		mu.Unlock()
+
+		m.CheckNow(context.Background(), 0)
+
+		mu.Lock()
+		got := changes
+		mu.Unlock()
+		if step.want == nil {
+			if len(got) != 0 {
+				t.Errorf("%s: changes %+v, want none", step.name, got)
+			}
+			continue
+		}
+		if len(got) != 1 {
+			t.Errorf("%s: changes %+v, want one", step.name, got)
+			continue
+		}
+
+		change, want := got[0], *step.want
+		if change.Provider != "vdsina" || change.Trigger != want.Trigger || !change.At.Equal(clock.Now()) {
+			t.Errorf("%s: %s change of %s at %v, want %s at %v", step.name, change.Trigger, change.Provider, change.At, want.Trigger, clock.Now())
+		}
+		if change.PreviousSeverity != want.PreviousSeverity || change.Severity != want.Severity {
+			t.Errorf("%s: severity %v -> %v, want %v -> %v", step.name, change.PreviousSeverity, change.Severity, want.PreviousSeverity, want.Severity)
+		}
+		if !sameDate(change.PreviousDate, want.PreviousDate) || !sameDate(change.Date, want.Date) {
+			t.Errorf("%s: date %v -> %v, want %v -> %v", step.name, change.PreviousDate, change.Date, want.PreviousDate, want.Date)
+		}
+		if !errors.Is(change.Err, want.Err) || (want.Err == nil) != (change.Err == nil) {
+			t.Errorf("%s: error %v, want %v", step.name, change.Err, want.Err)
+		}
+	}
+}
+
+// sameDate reports whether both dates are nil or the same moment
+func sameDate(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
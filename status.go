@@ -0,0 +1,146 @@
+package neverforgetvps
+
+import (
+	"math"
+	"time"
+)
+
+// Severity describes how urgent a provider's payment situation is
+type Severity int
+
+const (
+	// SeverityInfo - more than 5 days left
+	SeverityInfo Severity = iota
+	// SeverityAttention - 3-5 days left
+	SeverityAttention
+	// SeverityWarning - 0-2 days left
+	SeverityWarning
+	// SeverityCritical - payment overdue
+	SeverityCritical
+)
+
+// String returns the human-readable severity name
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityAttention:
+		return "attention"
+	case SeverityWarning:
+		return "warning"
+	case SeverityCritical:
+		return "critical"
+	default:
+		return "unknown"
+	}
+}
+
+// Outcome is the kind of result of a provider check
+// It tells "nothing due" apart from "check failed", which both leave NextDate nil
+type Outcome int
+
+const (
+	// OutcomeNoPaymentDue - the check succeeded and no payment is due
+	OutcomeNoPaymentDue Outcome = iota
+	// OutcomePaymentDue - the check succeeded and NextDate holds the next payment date
+	OutcomePaymentDue
+	// OutcomeFailed - the check failed and Err holds the reason
+	OutcomeFailed
+)
+
+// String returns the human-readable outcome name
+func (o Outcome) String() string {
+	switch o {
+	case OutcomeNoPaymentDue:
+		return "no_payment_due"
+	case OutcomePaymentDue:
+		return "payment_due"
+	case OutcomeFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// ProviderStatus contains the result of a single provider check
+type ProviderStatus struct {
+	Provider  string     // Provider name
+	NextDate  *time.Time // Next payment date, nil if no payment is due or the check failed (see Outcome)
+	DaysUntil int        // Days until the payment date (negative when overdue), 0 if NextDate is nil
+	// CandidateDates are all upcoming due dates the provider considered, sorted ascending
+	// The first one was selected as NextDate; only set for providers supporting candidate dates
+	CandidateDates []time.Time
+	// Account is the ID of the account owning NextDate, set by providers monitoring several accounts
+	// (e.g. OneProvider reseller accounts)
+	Account string
+	// Location is the time zone dates of the status are displayed in, nil means UTC
+	Location  *time.Location
+	Severity  Severity  // Severity derived from DaysUntil (SeverityWarning for failed checks, SeverityInfo if nothing is due)
+	Err       error     // *ProviderError wrapping the provider failure, nil on success
+	CheckedAt time.Time // Time when the check was performed
+}
+
+// Outcome returns the kind of result of the check
+// Use it instead of checking NextDate for nil, which is also nil when the check failed
+func (s ProviderStatus) Outcome() Outcome {
+	switch {
+	case s.Err != nil:
+		return OutcomeFailed
+	case s.NextDate != nil:
+		return OutcomePaymentDue
+	default:
+		return OutcomeNoPaymentDue
+	}
+}
+
+// displayDate formats NextDate as a date in the display time zone of the status
+func (s ProviderStatus) displayDate() string {
+	return formatDate(*s.NextDate, s.Location)
+}
+
+// formatDate formats a moment as a date in the given time zone, nil means UTC
+func formatDate(t time.Time, loc *time.Location) string {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return t.In(loc).Format("2006-01-02")
+}
+
+// DayRounding selects how the time until a payment date is converted to whole days
+type DayRounding int
+
+const (
+	// DayRoundingFloor drops partial days, truncating toward zero (default, e.g. 1.9 days → 1)
+	DayRoundingFloor DayRounding = iota
+	// DayRoundingCeil counts any partial day as a whole day (e.g. 1.1 days → 2)
+	DayRoundingCeil
+	// DayRoundingRound rounds to the nearest whole day (e.g. 1.5 days → 2)
+	DayRoundingRound
+)
+
+// daysBetween returns the number of whole days from one moment to another using the rounding mode
+func daysBetween(from, to time.Time, rounding DayRounding) int {
+	days := to.Sub(from).Hours() / 24
+	switch rounding {
+	case DayRoundingCeil:
+		return int(math.Ceil(days))
+	case DayRoundingRound:
+		return int(math.Round(days))
+	default:
+		return int(days)
+	}
+}
+
+// severityForDays returns the severity bucket for the given number of days until payment
+func severityForDays(daysUntil int) Severity {
+	switch {
+	case daysUntil < 0:
+		return SeverityCritical
+	case daysUntil <= 2:
+		return SeverityWarning
+	case daysUntil <= 5:
+		return SeverityAttention
+	default:
+		return SeverityInfo
+	}
+}
@@ -0,0 +1,133 @@
+package neverforgetvps
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestOutcome(t *testing.T) {
+	date := testNow.Add(48 * time.Hour)
+
+	tests := []struct {
+		name   string
+		status ProviderStatus
+		want   Outcome
+		string string
+	}{
+		{name: "nothing due", status: ProviderStatus{Provider: "vdsina"}, want: OutcomeNoPaymentDue, string: "no_payment_due"},
+		{name: "payment due", status: ProviderStatus{Provider: "vdsina", NextDate: &date}, want: OutcomePaymentDue, string: "payment_due"},
+		{name: "failed", status: ProviderStatus{Provider: "vdsina", Err: errors.New("boom")}, want: OutcomeFailed, string: "failed"},
+		// A failed check wins over a stale date
+		{name: "failed with a date", status: ProviderStatus{Provider: "vdsina", NextDate: &date, Err: errors.New("boom")}, want: OutcomeFailed, string: "failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.status.Outcome(); got != tt.want || got.String() != tt.string {
+				t.Errorf("outcome %v, want %v", got, tt.string)
+			}
+		})
+	}
+}
+
+func TestOutcomePerProvider(t *testing.T) {
+	clock := newTestClock()
+	noDue := newStubProvider("cloudflare", nil)
+	due := newStubProvider("oneprovider", nil)
+	failing := newStubProvider("vdsina", nil)
+
+	outcomes := make(map[string]Outcome)
+	config := Config{OnResult: func(status ProviderStatus) { outcomes[status.Provider] = status.Outcome() }}
+	m, _ := newTestMonitor(t, config, clock, noDue, due, failing)
+
+	// Every provider goes through every state, one per cycle
+	states := []struct {
+		date *time.Time
+		err  error
+		want Outcome
+	}{
+		{date: nil, want: OutcomeNoPaymentDue},
+		{date: daysFrom(clock.Now(), 3), want: OutcomePaymentDue},
+		{err: errors.New("boom"), want: OutcomeFailed},
+	}
+	for i := range states {
+		for j, p := range []*stubProvider{noDue, due, failing} {
+			state := states[(i+j)%len(states)]
+			p.set(state.date, state.err)
+		}
+		m.CheckNow(context.Background(), 0)
+
+		for j, name := range []string{"cloudflare", "oneprovider", "vdsina"} {
+			if want := states[(i+j)%len(states)].want; outcomes[name] != want {
+				t.Errorf("cycle %d: %s outcome %v, want %v", i, name, outcomes[name], want)
+			}
+		}
+	}
+}
+
+func TestDayRounding(t *testing.T) {
+	tests := []struct {
+		name  string
+		until time.Duration
+		want  map[DayRounding]int
+	}{
+		{name: "a day and a half", until: 36 * time.Hour, want: map[DayRounding]int{DayRoundingFloor: 1, DayRoundingCeil: 2, DayRoundingRound: 2}},
+		{name: "just over a day", until: 26 * time.Hour, want: map[DayRounding]int{DayRoundingFloor: 1, DayRoundingCeil: 2, DayRoundingRound: 1}},
+		{name: "exactly two days", until: 48 * time.Hour, want: map[DayRounding]int{DayRoundingFloor: 2, DayRoundingCeil: 2, DayRoundingRound: 2}},
+		{name: "a day and a half overdue", until: -36 * time.Hour, want: map[DayRounding]int{DayRoundingFloor: -1, DayRoundingCeil: -1, DayRoundingRound: -2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for rounding, want := range tt.want {
+				clock := newTestClock()
+				date := clock.Now().Add(tt.until)
+				var daysUntil int
+				config := Config{DayRounding: rounding, OnResult: func(status ProviderStatus) { daysUntil = status.DaysUntil }}
+				m, _ := newTestMonitor(t, config, clock, newStubProvider("vdsina", &date))
+
+				m.CheckNow(context.Background(), 0)
+
+				if daysUntil != want {
+					t.Errorf("rounding %d: %d days, want %d", rounding, daysUntil, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSeverityFunc(t *testing.T) {
+	// VDSina is critical within a week, every other provider keeps the built-in buckets
+	severityFunc := func(daysUntil int, provider string) Severity {
+		if provider == "vdsina" && daysUntil <= 7 {
+			return SeverityCritical
+		}
+		return severityForDays(daysUntil)
+	}
+
+	tests := []struct {
+		name     string
+		provider string
+		days     int
+		want     string
+	}{
+		{name: "overridden", provider: "vdsina", days: 6, want: "🚨🚨🚨 CRITICAL: Provider vdsina - Payment required! Payment due date: 2026-10-22 (6 day(s) left)\nPay: https://cp.vdsina.com/"},
+		{name: "outside the override", provider: "vdsina", days: 10, want: "ℹ️ INFO: Provider vdsina - Next payment date: 2026-10-26 (10 days left)"},
+		{name: "other provider", provider: "oneprovider", days: 6, want: "ℹ️ INFO: Provider oneprovider - Next payment date: 2026-10-22 (6 days left)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newTestClock()
+			m, sent := newTestMonitor(t, Config{SeverityFunc: severityFunc}, clock, newStubProvider(tt.provider, daysFrom(clock.Now(), tt.days)))
+
+			m.CheckNow(context.Background(), 0)
+
+			if texts := sent.texts(); len(texts) != 1 || texts[0] != tt.want {
+				t.Fatalf("messages %q, want %q", texts, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package neverforgetvps
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+)
+
+// NewStdoutMonitor creates a new instance of VPSMonitor that prints every message to stdout
+// It is meant for quick setups and debugging, when there is no channel to wire
+// The returned monitor is ready to Start
+func NewStdoutMonitor(ctx context.Context, config Config) VPSMonitor {
+	return NewVPSMonitorWithSender(ctx, config, printTo(os.Stdout), func(text string) string { return text })
+}
+
+// printTo returns a send function writing each message as a line to w
+func printTo(w io.Writer) func(string) error {
+	return func(text string) error {
+		_, err := fmt.Fprintln(w, text)
+		return err
+	}
+}
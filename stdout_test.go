@@ -0,0 +1,52 @@
+package neverforgetvps
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+func TestStdoutMonitor(t *testing.T) {
+	forecast := time.Now().AddDate(0, 0, 20).Format(time.DateOnly)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"status":"ok","data":{"forecast":"%s","can":{"add_user":true,"add_service":true}}}`, forecast)
+	}))
+	defer server.Close()
+
+	// Capture stdout while the monitor notifies
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = writer
+	defer func() { os.Stdout = stdout }()
+
+	config := Config{
+		VdsinaAPIKey:    "key",
+		ProviderOptions: map[string][]provider.Option{"vdsina": {provider.WithBaseURL(server.URL)}},
+	}
+	m := NewStdoutMonitor(context.Background(), config)
+	m.CheckNow(context.Background(), 0)
+	m.Stop()
+
+	os.Stdout = stdout
+	writer.Close()
+	output, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "ℹ️ INFO: Provider vdsina - Next payment date: " + forecast
+	if lines := strings.Split(strings.TrimSuffix(string(output), "\n"), "\n"); len(lines) != 1 || !strings.HasPrefix(lines[0], want) {
+		t.Fatalf("stdout %q, want one line starting with %q", output, want)
+	}
+}
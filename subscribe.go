@@ -0,0 +1,125 @@
+package neverforgetvps
+
+import (
+	"time"
+)
+
+// subscriberBufferSize is the buffer size of each subscriber channel
+const subscriberBufferSize = 16
+
+// ProviderStatusChange describes a change of provider status between two checks
+type ProviderStatusChange struct {
+	Provider string          // Provider name
+	Previous *ProviderStatus // Status from the previous check, nil for the first check
+	Current  ProviderStatus  // Status from the latest check
+}
+
+// Subscribe returns a channel that receives an event whenever a provider's severity,
+// payment date or error state changes between checks
+// Events are dropped for subscribers that do not keep up, the check loop never blocks on them
+// The channel is closed by Unsubscribe or Stop
+func (m *vpsMonitor[T]) Subscribe() <-chan ProviderStatusChange {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ch := make(chan ProviderStatusChange, subscriberBufferSize)
+	if m.stopped {
+		close(ch)
+		return ch
+	}
+
+	if m.subscribers == nil {
+		m.subscribers = make(map[<-chan ProviderStatusChange]chan ProviderStatusChange)
+	}
+	m.subscribers[ch] = ch
+	return ch
+}
+
+// Unsubscribe stops delivering events to the channel returned by Subscribe and closes it
+func (m *vpsMonitor[T]) Unsubscribe(ch <-chan ProviderStatusChange) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if sub, ok := m.subscribers[ch]; ok {
+		delete(m.subscribers, ch)
+		close(sub)
+	}
+}
+
+// closeSubscribers closes all subscriber channels, must be called with m.mu held
+func (m *vpsMonitor[T]) closeSubscribers() {
+	for key, sub := range m.subscribers {
+		delete(m.subscribers, key)
+		close(sub)
+	}
+}
+
+// recordStatus stores the latest provider status and notifies subscribers, the event writer
+// and OnStateChange if it changed
+func (m *vpsMonitor[T]) recordStatus(status ProviderStatus) {
+	change, changed := m.publishStatus(status)
+	if !changed {
+		return
+	}
+
+	m.writeEvent(statusChangeEvent(status))
+	if m.onStateChange != nil {
+		m.onStateChange(newStateChange(change))
+	}
+}
+
+// publishStatus stores the latest provider status and notifies subscribers if it changed
+// Returns the change and whether the status changed
+func (m *vpsMonitor[T]) publishStatus(status ProviderStatus) (ProviderStatusChange, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.statuses == nil {
+		m.statuses = make(map[string]ProviderStatus)
+	}
+
+	previous, found := m.statuses[status.Provider]
+	m.statuses[status.Provider] = status
+
+	if status.Outcome() != OutcomeFailed {
+		if m.lastSuccess == nil {
+			m.lastSuccess = make(map[string]time.Time)
+		}
+		m.lastSuccess[status.Provider] = status.CheckedAt
+	}
+
+	if found && !statusChanged(previous, status) {
+		return ProviderStatusChange{}, false
+	}
+
+	change := ProviderStatusChange{
+		Provider: status.Provider,
+		Current:  status,
+	}
+	if found {
+		change.Previous = &previous
+	}
+
+	for _, sub := range m.subscribers {
+		select {
+		case sub <- change:
+		default:
+			// Subscriber is not keeping up, drop the event
+		}
+	}
+	return change, true
+}
+
+// statusChanged reports whether two statuses differ in severity, payment date or error state
+func statusChanged(previous, current ProviderStatus) bool {
+	if (previous.Err != nil) != (current.Err != nil) {
+		return true
+	}
+	if previous.Severity != current.Severity {
+		return true
+	}
+	if (previous.NextDate == nil) != (current.NextDate == nil) {
+		return true
+	}
+	return previous.NextDate != nil && !previous.NextDate.Equal(*current.NextDate)
+}
@@ -0,0 +1,59 @@
+package neverforgetvps
+
+import (
+	"context"
+	"testing"
+)
+
+// drainChanges returns the events buffered in the channel without waiting for more
+func drainChanges(ch <-chan ProviderStatusChange) []ProviderStatusChange {
+	var changes []ProviderStatusChange
+	for {
+		select {
+		case change, ok := <-ch:
+			if !ok {
+				return changes
+			}
+			changes = append(changes, change)
+		default:
+			return changes
+		}
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	clock := newTestClock()
+	p := newStubProvider("vdsina", daysFrom(clock.Now(), 20))
+	m, _ := newTestMonitor(t, Config{}, clock, p)
+
+	first := m.Subscribe()
+	second := m.Subscribe()
+
+	m.CheckNow(context.Background(), 0)
+	for i, ch := range []<-chan ProviderStatusChange{first, second} {
+		changes := drainChanges(ch)
+		if len(changes) != 1 || changes[0].Previous != nil || changes[0].Provider != "vdsina" {
+			t.Fatalf("subscriber %d: first check events %+v, want one without a previous status", i, changes)
+		}
+	}
+
+	// An unchanged status is not an event
+	m.CheckNow(context.Background(), 0)
+	if changes := drainChanges(first); len(changes) != 0 {
+		t.Fatalf("unchanged status events %+v, want none", changes)
+	}
+
+	// After a payment the date moves
+	m.Unsubscribe(second)
+	p.set(daysFrom(clock.Now(), 50), nil)
+	m.CheckNow(context.Background(), 0)
+	changes := drainChanges(first)
+	if len(changes) != 1 || changes[0].Previous == nil || !changes[0].Previous.NextDate.Equal(*daysFrom(clock.Now(), 20)) ||
+		!changes[0].Current.NextDate.Equal(*daysFrom(clock.Now(), 50)) {
+		t.Fatalf("date change events %+v, want one from the old to the new date", changes)
+	}
+
+	if _, ok := <-second; ok {
+		t.Fatal("unsubscribed channel received an event, want it closed")
+	}
+}
@@ -0,0 +1,79 @@
+package neverforgetvps
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Summary returns a one-line overview of all enabled providers,
+// e.g. "2 providers OK, 1 due in 3 day(s), 0 overdue"
+// Results of the last check are reused if they are not older than the check interval,
+// other providers are checked on demand without sending any messages
+func (m *vpsMonitor[T]) Summary(ctx context.Context) (string, error) {
+	statuses, err := m.freshStatuses(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return summarize(statuses), nil
+}
+
+// summarize formats the one-line overview of the given statuses
+func summarize(statuses []ProviderStatus) string {
+	var ok, dueSoon, overdue, failed int
+	nearestDays := -1
+	for _, status := range statuses {
+		switch {
+		case status.Outcome() == OutcomeFailed:
+			failed++
+		case status.Outcome() == OutcomeNoPaymentDue || status.Severity == SeverityInfo:
+			ok++
+		case status.Severity == SeverityCritical:
+			overdue++
+		default:
+			dueSoon++
+			if nearestDays < 0 || status.DaysUntil < nearestDays {
+				nearestDays = status.DaysUntil
+			}
+		}
+	}
+
+	parts := []string{fmt.Sprintf("%d providers OK", ok)}
+	if dueSoon > 0 {
+		parts = append(parts, fmt.Sprintf("%d due in %d day(s)", dueSoon, nearestDays))
+	} else {
+		parts = append(parts, "0 due soon")
+	}
+	parts = append(parts, fmt.Sprintf("%d overdue", overdue))
+	if failed > 0 {
+		parts = append(parts, fmt.Sprintf("%d failed", failed))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// freshStatuses returns the latest status of every enabled provider
+// Statuses older than the check interval are refreshed by checking the provider directly
+func (m *vpsMonitor[T]) freshStatuses(ctx context.Context) ([]ProviderStatus, error) {
+	entries := m.enabledProviders()
+	statuses := make([]ProviderStatus, 0, len(entries))
+
+	for _, entry := range entries {
+		m.mu.Lock()
+		status, found := m.statuses[entry.Provider.GetName()]
+		m.mu.Unlock()
+
+		if !found || m.now().Sub(status.CheckedAt) > m.checkInterval {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			status = m.checkProvider(ctx, entry.Provider, entry.Timeout)
+			m.recordStatus(status)
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
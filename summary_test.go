@@ -0,0 +1,71 @@
+package neverforgetvps
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSummarize(t *testing.T) {
+	date := testNow
+	ok := ProviderStatus{NextDate: &date, DaysUntil: 20, Severity: SeverityInfo}
+	noPayment := ProviderStatus{Severity: SeverityInfo}
+	dueIn3 := ProviderStatus{NextDate: &date, DaysUntil: 3, Severity: SeverityAttention}
+	dueIn1 := ProviderStatus{NextDate: &date, DaysUntil: 1, Severity: SeverityWarning}
+	overdue := ProviderStatus{NextDate: &date, DaysUntil: -2, Severity: SeverityCritical}
+	failed := ProviderStatus{Err: errors.New("boom"), Severity: SeverityWarning}
+
+	tests := []struct {
+		name     string
+		statuses []ProviderStatus
+		want     string
+	}{
+		{name: "no providers", want: "0 providers OK, 0 due soon, 0 overdue"},
+		{name: "all OK", statuses: []ProviderStatus{ok, noPayment}, want: "2 providers OK, 0 due soon, 0 overdue"},
+		{name: "one due", statuses: []ProviderStatus{ok, ok, dueIn3}, want: "2 providers OK, 1 due in 3 day(s), 0 overdue"},
+		{name: "nearest due date", statuses: []ProviderStatus{dueIn3, dueIn1}, want: "0 providers OK, 2 due in 1 day(s), 0 overdue"},
+		{name: "overdue and failed", statuses: []ProviderStatus{ok, overdue, failed}, want: "1 providers OK, 0 due soon, 1 overdue, 1 failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := summarize(tt.statuses); got != tt.want {
+				t.Errorf("summarize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummaryReusesFreshResults(t *testing.T) {
+	clock := newTestClock()
+	p := newStubProvider("vdsina", daysFrom(clock.Now(), 3))
+	m, sent := newTestMonitor(t, Config{CheckInterval: time.Hour}, clock, p)
+
+	summary, err := m.Summary(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "0 providers OK, 1 due in 3 day(s), 0 overdue"; summary != want {
+		t.Fatalf("summary %q, want %q", summary, want)
+	}
+	if p.callCount() != 1 || len(sent.texts()) != 0 {
+		t.Fatalf("%d calls and messages %q, want one check without messages", p.callCount(), sent.texts())
+	}
+
+	clock.Advance(30 * time.Minute)
+	if _, err := m.Summary(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if p.callCount() != 1 {
+		t.Fatalf("%d calls, want the fresh result reused", p.callCount())
+	}
+
+	clock.Advance(time.Hour)
+	if _, err := m.Summary(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if p.callCount() != 2 {
+		t.Fatalf("%d calls, want the stale result refreshed", p.callCount())
+	}
+}
@@ -0,0 +1,43 @@
+package neverforgetvps
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+// checkSuspension asks providers supporting it whether the service is suspended
+// and returns a critical alert if it is
+// Failures are only logged, the payment date check reports provider errors already
+func (m *vpsMonitor[T]) checkSuspension(ctx context.Context, entry providerEntry) (pendingMessage, bool) {
+	reporter, ok := provider.Unwrap(entry.Provider).(provider.SuspensionReporter)
+	if !ok || !entry.Provider.IsConfigured() {
+		return pendingMessage{}, false
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, entry.Timeout)
+	defer cancel()
+
+	name := entry.Provider.GetName()
+	suspended, reason, err := reporter.IsSuspended(ctx)
+	if err != nil {
+		m.logger.Warn("suspension check failed", slog.String("provider", name), slog.Any("error", err))
+		return pendingMessage{}, false
+	}
+	if !suspended {
+		return pendingMessage{}, false
+	}
+
+	status := ProviderStatus{
+		Provider:  name,
+		Severity:  SeverityCritical,
+		CheckedAt: m.now(),
+	}
+	text := fmt.Sprintf("❗ SUSPENDED: Provider %s - Service suspended", name)
+	if reason != "" {
+		text += ": " + reason
+	}
+	return pendingMessage{status, text, messageSuspension}, true
+}
@@ -0,0 +1,40 @@
+package neverforgetvps
+
+import (
+	"context"
+	"testing"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+	"github.com/custom-app/NeverForgetVPS/provider/vdsina"
+)
+
+func TestSuspensionAlert(t *testing.T) {
+	tests := []struct {
+		name          string
+		can           string
+		wantSuspended int
+	}{
+		{name: "active", can: `{"add_user":true,"add_service":true}`},
+		{name: "suspended", can: `{"add_user":false,"add_service":false}`, wantSuspended: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseURL := newDateServer(t, `{"status":"ok","data":{"forecast":"2026-10-18","can":`+tt.can+`}}`)
+			p, err := vdsina.New("key", provider.WithBaseURL(baseURL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			m, sent := newTestMonitor(t, Config{}, newTestClock(), p)
+
+			m.CheckNow(context.Background(), 0)
+
+			// The suspension is a separate alert, the date-based warning is still sent
+			texts := sent.texts()
+			suspended := countContaining(texts, "❗ SUSPENDED: Provider vdsina - Service suspended: account is restricted")
+			if suspended != tt.wantSuspended || countContaining(texts, "WARNING: Provider vdsina") != 1 {
+				t.Errorf("messages %q, want %d suspension alerts and the payment warning", texts, tt.wantSuspended)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package neverforgetvps
+
+import (
+	"time"
+)
+
+// isInfoThrottled checks whether an Info-level message for the provider was already sent
+// within the info notify interval, and records the send time otherwise
+// Warning and higher severities and error messages are never throttled
+// Must be called with m.mu held
+func (m *vpsMonitor[T]) isInfoThrottled(status ProviderStatus) bool {
+	if m.infoNotifyInterval <= 0 || status.Outcome() == OutcomeFailed || status.Severity != SeverityInfo {
+		return false
+	}
+
+	now := m.now()
+	if last, found := m.lastInfoSent[status.Provider]; found && now.Sub(last) < m.infoNotifyInterval {
+		return true
+	}
+
+	if m.lastInfoSent == nil {
+		m.lastInfoSent = make(map[string]time.Time)
+	}
+	m.lastInfoSent[status.Provider] = now
+	return false
+}
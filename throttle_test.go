@@ -0,0 +1,36 @@
+package neverforgetvps
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestInfoNotifyInterval(t *testing.T) {
+	// Hourly checks for 30 hours, returning how many info and warning messages were sent
+	run := func(interval time.Duration) (info, warning int) {
+		clock := newTestClock()
+		relaxed := newStubProvider("oneprovider", daysFrom(clock.Now(), 20))
+		urgent := newStubProvider("vdsina", daysFrom(clock.Now(), 2))
+		m, sent := newTestMonitor(t, Config{InfoNotifyInterval: interval}, clock, relaxed, urgent)
+
+		for range 30 {
+			m.CheckNow(context.Background(), 0)
+			clock.Advance(time.Hour)
+		}
+		texts := sent.texts()
+		return countContaining(texts, "INFO"), countContaining(texts, "WARNING")
+	}
+
+	info, warning := run(24 * time.Hour)
+	if info != 2 {
+		t.Errorf("%d info messages in 30 hours with a daily interval, want 2", info)
+	}
+	unthrottledInfo, unthrottledWarning := run(0)
+	if unthrottledInfo <= info {
+		t.Errorf("%d info messages without an interval, want more than the %d throttled ones", unthrottledInfo, info)
+	}
+	if warning != unthrottledWarning || warning == 0 {
+		t.Errorf("%d warnings with the interval and %d without, want the same non-zero count", warning, unthrottledWarning)
+	}
+}
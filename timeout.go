@@ -0,0 +1,36 @@
+package neverforgetvps
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// checkTimeoutKey is the context key of a per-call provider timeout override
+type checkTimeoutKey struct{}
+
+// withCheckTimeout returns a context whose provider checks are bounded by timeout
+// instead of the configured per-provider timeouts, used by CheckNow and CheckProvider
+// A non-positive timeout leaves the configured timeouts in place
+func withCheckTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	if timeout <= 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, checkTimeoutKey{}, timeout)
+}
+
+// CheckProvider checks a single enabled provider immediately and records its status
+// No messages are sent. A positive timeout replaces the provider's configured timeout for this call only
+func (m *vpsMonitor[T]) CheckProvider(ctx context.Context, name string, timeout time.Duration) (ProviderStatus, error) {
+	for _, entry := range m.enabledProviders() {
+		if entry.Provider.GetName() != name {
+			continue
+		}
+
+		status := m.checkProvider(withCheckTimeout(ctx, timeout), entry.Provider, entry.Timeout)
+		m.recordStatus(status)
+		return status, nil
+	}
+
+	return ProviderStatus{}, fmt.Errorf("unknown or disabled provider: %s", name)
+}
@@ -0,0 +1,55 @@
+package neverforgetvps
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCheckTimeoutOverride(t *testing.T) {
+	clock := newTestClock()
+	p := newStubProvider("vdsina", daysFrom(clock.Now(), 20))
+
+	// Every check records how much time its context had left
+	var mu sync.Mutex
+	var remaining time.Duration
+	p.hook = func(ctx context.Context) {
+		deadline, ok := ctx.Deadline()
+		if !ok {
+			t.Error("provider check without a deadline")
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		remaining = time.Until(deadline)
+	}
+	lastRemaining := func() time.Duration {
+		mu.Lock()
+		defer mu.Unlock()
+		return remaining
+	}
+
+	// newTestMonitor configures a timeout of one second
+	m, _ := newTestMonitor(t, Config{}, clock, p)
+
+	calls := []struct {
+		name     string
+		check    func(timeout time.Duration)
+		override time.Duration
+		min, max time.Duration
+	}{
+		{name: "CheckNow override", check: func(timeout time.Duration) { m.CheckNow(context.Background(), timeout) }, override: 50 * time.Millisecond, max: 50 * time.Millisecond},
+		{name: "CheckNow without override", check: func(timeout time.Duration) { m.CheckNow(context.Background(), timeout) }, min: 500 * time.Millisecond, max: time.Second},
+		{name: "CheckProvider override", check: func(timeout time.Duration) { m.CheckProvider(context.Background(), "vdsina", timeout) }, override: time.Minute, min: 30 * time.Second, max: time.Minute},
+		{name: "CheckProvider without override", check: func(timeout time.Duration) { m.CheckProvider(context.Background(), "vdsina", timeout) }, min: 500 * time.Millisecond, max: time.Second},
+	}
+
+	// Each call is checked right after the previous one, so an override must not leak into the next call
+	for _, call := range calls {
+		call.check(call.override)
+		if got := lastRemaining(); got < call.min || got > call.max {
+			t.Errorf("%s: %v left for the check, want between %v and %v", call.name, got, call.min, call.max)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package neverforgetvps
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+// accountLocationRetry is how long a failed account time zone lookup is not repeated
+const accountLocationRetry = 24 * time.Hour
+
+// displayLocationFor returns the time zone the dates of a provider are displayed in
+// Config.DisplayLocation takes precedence. With Config.AccountTimezones the zone reported by the provider
+// account is used once it is known, Config.Timezone otherwise. A failed lookup is retried after
+// accountLocationRetry, not on every check
+func (m *vpsMonitor[T]) displayLocationFor(ctx context.Context, p provider.Provider) *time.Location {
+	if m.displayLocation != nil {
+		return m.displayLocation
+	}
+	if !m.accountTimezones {
+		return m.timezone
+	}
+
+	name := p.GetName()
+	m.mu.Lock()
+	loc, found := m.accountLocations[name]
+	failedAt, failed := m.accountLocationFailures[name]
+	m.mu.Unlock()
+	if found {
+		return loc
+	}
+	if failed && m.now().Sub(failedAt) < accountLocationRetry {
+		return m.timezone
+	}
+
+	reporter, ok := provider.Unwrap(p).(provider.LocationProvider)
+	if !ok {
+		return m.timezone
+	}
+	loc, err := reporter.GetAccountLocation(ctx)
+	if err != nil || loc == nil {
+		m.logger.Debug("account time zone unavailable, using the configured one",
+			slog.String("provider", name),
+			slog.Any("error", err))
+		m.mu.Lock()
+		if m.accountLocationFailures == nil {
+			m.accountLocationFailures = make(map[string]time.Time)
+		}
+		m.accountLocationFailures[name] = m.now()
+		m.mu.Unlock()
+		return m.timezone
+	}
+
+	m.mu.Lock()
+	if m.accountLocations == nil {
+		m.accountLocations = make(map[string]*time.Location)
+	}
+	m.accountLocations[name] = loc
+	delete(m.accountLocationFailures, name)
+	m.mu.Unlock()
+
+	return loc
+}
+
+// normalizeDates moves payment dates to loc, the zone the status is displayed in,
+// so every provider's dates are displayed and counted alike regardless of the zone the provider parsed them in
+func normalizeDates(nextDate *time.Time, candidates []time.Time, loc *time.Location) (*time.Time, []time.Time) {
+	if nextDate == nil {
+		return nextDate, candidates
+	}
+
+	normalized := normalizeDate(*nextDate, loc)
+	normalizedCandidates := make([]time.Time, len(candidates))
+	for i, candidate := range candidates {
+		normalizedCandidates[i] = normalizeDate(candidate, loc)
+	}
+	return &normalized, normalizedCandidates
+}
+
+// normalizeDate moves a payment date to loc
+// Date-only values, i.e. midnight in the zone they were parsed in, keep their calendar day: a due date of
+// 2025-06-01 stays 2025-06-01 instead of becoming the evening before in a zone west of the provider's.
+// This relies on providers returning date-only values in their billing zone, see provider.Provider.
+// Dates with a time of day are instants and are converted, keeping the moment
+func normalizeDate(t time.Time, loc *time.Location) time.Time {
+	hour, minute, second := t.Clock()
+	if hour != 0 || minute != 0 || second != 0 || t.Nanosecond() != 0 {
+		return t.In(loc)
+	}
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,168 @@
+package neverforgetvps
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+	"github.com/custom-app/NeverForgetVPS/provider/oneprovider"
+	"github.com/custom-app/NeverForgetVPS/provider/vdsina"
+)
+
+// newDateServer returns a test API server answering every request with the given JSON body
+func newDateServer(t *testing.T, body string) string {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("time zone %s unavailable: %v", name, err)
+	}
+	return loc
+}
+
+func TestDateOnlyDatesKeepCalendarDay(t *testing.T) {
+	moscow := mustLoadLocation(t, "Europe/Moscow")
+	newYork := mustLoadLocation(t, "America/New_York")
+	tokyo := mustLoadLocation(t, "Asia/Tokyo")
+
+	vdsinaURL := newDateServer(t, `{"status":"ok","data":{"forecast":"2026-10-19","can":{"add_user":true,"add_service":true}}}`)
+	oneProviderURL := newDateServer(t, `{"result":"success","response":{"current_page":1,"total_pages":1,`+
+		`"invoices":[{"id":"1","client_id":"7","status":"Unpaid","due_date":"2026-10-19","balance":"10.00"}]}}`)
+
+	tests := []struct {
+		name   string
+		config Config
+	}{
+		{name: "UTC timezone", config: Config{Timezone: time.UTC}},
+		{name: "timezone west of UTC", config: Config{Timezone: newYork}},
+		{name: "display location east of the billing zone", config: Config{DisplayLocation: tokyo}},
+		{name: "display location overrides account time zones", config: Config{AccountTimezones: true, DisplayLocation: newYork}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vdsinaProvider, err := vdsina.New("key", provider.WithBaseURL(vdsinaURL), provider.WithLocation(moscow))
+			if err != nil {
+				t.Fatal(err)
+			}
+			oneProviderProvider, err := oneprovider.New("key", "client", provider.WithBaseURL(oneProviderURL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			m, sent := newTestMonitor(t, tt.config, newTestClock(), vdsinaProvider, oneProviderProvider)
+
+			m.CheckNow(context.Background(), 0)
+
+			texts := sent.texts()
+			for _, name := range []string{"vdsina", "oneprovider"} {
+				var found bool
+				for _, text := range texts {
+					if strings.Contains(strings.ToLower(text), name) {
+						found = true
+						if !strings.Contains(text, "2026-10-19") {
+							t.Errorf("%s message shows another day than 2026-10-19: %q", name, text)
+						}
+					}
+				}
+				if !found {
+					t.Errorf("no %s message in %q", name, texts)
+				}
+			}
+		})
+	}
+}
+
+// locationStub is a stubProvider reporting the time zone of its account
+type locationStub struct {
+	*stubProvider
+
+	mu      sync.Mutex
+	loc     *time.Location
+	err     error
+	lookups int
+}
+
+func (p *locationStub) GetAccountLocation(context.Context) (*time.Location, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.lookups++
+	return p.loc, p.err
+}
+
+func TestAccountTimezones(t *testing.T) {
+	tokyo := mustLoadLocation(t, "Asia/Tokyo")
+	// Late in the evening in UTC, already the next day in Tokyo
+	due := time.Date(2026, 10, 19, 22, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		config   Config
+		err      error
+		wantDate string
+	}{
+		{name: "configured time zone", config: Config{Timezone: time.UTC}, wantDate: "2026-10-19"},
+		{name: "account time zone", config: Config{Timezone: time.UTC, AccountTimezones: true}, wantDate: "2026-10-20"},
+		{name: "failed lookup falls back", config: Config{Timezone: time.UTC, AccountTimezones: true}, err: errors.New("boom"), wantDate: "2026-10-19"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &locationStub{stubProvider: newStubProvider("vdsina", &due), loc: tokyo, err: tt.err}
+			m, sent := newTestMonitor(t, tt.config, newTestClock(), p)
+
+			m.CheckNow(context.Background(), 0)
+
+			texts := sent.texts()
+			if len(texts) != 1 || !strings.Contains(texts[0], tt.wantDate) {
+				t.Errorf("messages %q, want the payment date %s", texts, tt.wantDate)
+			}
+		})
+	}
+}
+
+func TestAccountTimezoneLookupRetry(t *testing.T) {
+	clock := newTestClock()
+	p := &locationStub{stubProvider: newStubProvider("vdsina", daysFrom(clock.Now(), 2)), err: errors.New("boom")}
+	m, _ := newTestMonitor(t, Config{AccountTimezones: true}, clock, p)
+
+	lookups := func() int {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		return p.lookups
+	}
+
+	m.CheckNow(context.Background(), 0)
+	clock.Advance(time.Hour)
+	m.CheckNow(context.Background(), 0)
+	if n := lookups(); n != 1 {
+		t.Fatalf("%d lookups within a day of a failure, want 1", n)
+	}
+
+	// Retried after a day, then cached once it succeeds
+	p.mu.Lock()
+	p.loc, p.err = time.UTC, nil
+	p.mu.Unlock()
+	clock.Advance(accountLocationRetry)
+	m.CheckNow(context.Background(), 0)
+	m.CheckNow(context.Background(), 0)
+	if n := lookups(); n != 2 {
+		t.Errorf("%d lookups, want 2", n)
+	}
+}
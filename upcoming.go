@@ -0,0 +1,61 @@
+package neverforgetvps
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+)
+
+// UpcomingPayment is a single payment due date reported by UpcomingWithin
+type UpcomingPayment struct {
+	Provider  string    // Provider name
+	DueDate   time.Time // Payment due date
+	DaysUntil int       // Days until the due date (negative when overdue)
+}
+
+// UpcomingWithin returns all payments due within the window from now across enabled providers,
+// sorted by due date ascending; overdue payments are included
+// Providers listing several invoices contribute every due date, others their next payment date
+// Results of the last check are reused like in Summary
+// Failed providers are skipped, and their errors are returned joined together with the payments of the others
+func (m *vpsMonitor[T]) UpcomingWithin(ctx context.Context, window time.Duration) ([]UpcomingPayment, error) {
+	statuses, err := m.freshStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := m.now()
+	horizon := now.Add(window)
+
+	var payments []UpcomingPayment
+	var errs []error
+	for _, status := range statuses {
+		if status.Outcome() == OutcomeFailed {
+			errs = append(errs, status.Err)
+			continue
+		}
+
+		dates := status.CandidateDates
+		if len(dates) == 0 && status.NextDate != nil {
+			dates = []time.Time{*status.NextDate}
+		}
+
+		for _, date := range dates {
+			if date.After(horizon) {
+				continue
+			}
+			payments = append(payments, UpcomingPayment{
+				Provider:  status.Provider,
+				DueDate:   date,
+				DaysUntil: daysBetween(now, date, m.dayRounding),
+			})
+		}
+	}
+
+	sort.SliceStable(payments, func(i, j int) bool {
+		return payments[i].DueDate.Before(payments[j].DueDate)
+	})
+
+	return payments, errors.Join(errs...)
+}
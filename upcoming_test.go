@@ -0,0 +1,48 @@
+package neverforgetvps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+// candidateStub is a stub provider listing several invoice due dates, sorted ascending
+type candidateStub struct {
+	*stubProvider
+	dates []time.Time
+}
+
+func (p *candidateStub) GetCandidateDates(ctx context.Context) ([]time.Time, error) {
+	return p.dates, nil
+}
+
+func TestUpcomingWithin(t *testing.T) {
+	clock := newTestClock()
+	invoices := &candidateStub{
+		stubProvider: newStubProvider("oneprovider", nil),
+		dates:        []time.Time{*daysFrom(clock.Now(), -2), *daysFrom(clock.Now(), 12), *daysFrom(clock.Now(), 45)},
+	}
+	soon := newStubProvider("vdsina", daysFrom(clock.Now(), 5))
+	later := newStubProvider("timeweb", daysFrom(clock.Now(), 31))
+	nothingDue := newStubProvider("cloudflare", nil)
+	failing := newStubProvider("gcore", nil)
+	failing.set(nil, errors.New("boom"))
+	m, _ := newTestMonitor(t, Config{}, clock, invoices, soon, later, nothingDue, failing)
+
+	payments, err := m.UpcomingWithin(context.Background(), 30*24*time.Hour)
+	if err == nil {
+		t.Error("no error, want the failed provider reported")
+	}
+
+	var got []string
+	for _, payment := range payments {
+		got = append(got, fmt.Sprintf("%s %s %d", payment.Provider, payment.DueDate.Format(time.DateOnly), payment.DaysUntil))
+	}
+	want := []string{"oneprovider 2026-10-14 -2", "vdsina 2026-10-21 5", "oneprovider 2026-10-28 12"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("payments %q, want %q", got, want)
+	}
+}
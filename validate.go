@@ -0,0 +1,67 @@
+package neverforgetvps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/custom-app/NeverForgetVPS/provider"
+)
+
+// ValidateCredentials checks every enabled provider once and returns the result keyed by provider name,
+// a nil error means the provider authenticated and answered successfully
+// No payment messages are sent; with NotifyOnValidation a summary of the results is sent instead
+// Cached results are never used, every provider API is called
+func (m *vpsMonitor[T]) ValidateCredentials(ctx context.Context) (map[string]error, error) {
+	ctx = provider.WithForceRefresh(ctx)
+	entries := m.enabledProviders()
+	results := make(map[string]error, len(entries))
+
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		status := m.checkProvider(ctx, entry.Provider, entry.Timeout)
+		m.recordStatus(status)
+		results[status.Provider] = status.Err
+	}
+
+	if m.notifyOnValidation {
+		m.sendMessage(validationMessage(results))
+	}
+
+	return results, nil
+}
+
+// validationMessage formats the credential validation results, one provider per line
+func validationMessage(results map[string]error) Message {
+	names := make([]string, 0, len(results))
+	failed := 0
+	for name, err := range results {
+		names = append(names, name)
+		if err != nil {
+			failed++
+		}
+	}
+	sort.Strings(names)
+
+	severity := SeverityInfo
+	header := fmt.Sprintf("✅ Credential validation: all %d providers OK", len(results))
+	if failed > 0 {
+		severity = SeverityWarning
+		header = fmt.Sprintf("❌ Credential validation: %d of %d providers failed", failed, len(results))
+	}
+
+	lines := []string{header}
+	for _, name := range names {
+		if err := results[name]; err != nil {
+			lines = append(lines, fmt.Sprintf("%s: failed (%v)", name, errors.Unwrap(err)))
+		} else {
+			lines = append(lines, fmt.Sprintf("%s: OK", name))
+		}
+	}
+
+	return monitorMessage(severity, strings.Join(lines, "\n"))
+}
@@ -0,0 +1,60 @@
+package neverforgetvps
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestNotifyOnValidation(t *testing.T) {
+	tests := []struct {
+		name          string
+		notify        bool
+		failVdsina    bool
+		wantSummaries []string
+	}{
+		{
+			name:          "all passed",
+			notify:        true,
+			wantSummaries: []string{"✅ Credential validation: all 2 providers OK\noneprovider: OK\nvdsina: OK"},
+		},
+		{
+			name:          "one failed",
+			notify:        true,
+			failVdsina:    true,
+			wantSummaries: []string{"❌ Credential validation: 1 of 2 providers failed\noneprovider: OK\nvdsina: failed (invalid token)"},
+		},
+		{name: "disabled", failVdsina: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newTestClock()
+			vdsina := newStubProvider("vdsina", daysFrom(clock.Now(), 2))
+			if tt.failVdsina {
+				vdsina.set(nil, errors.New("invalid token"))
+			}
+			oneprovider := newStubProvider("oneprovider", daysFrom(clock.Now(), 20))
+			m, sent := newTestMonitor(t, Config{NotifyOnValidation: tt.notify}, clock, vdsina, oneprovider)
+
+			results, err := m.ValidateCredentials(context.Background())
+			if err != nil {
+				t.Fatalf("ValidateCredentials: %v", err)
+			}
+			if (results["vdsina"] != nil) != tt.failVdsina || results["oneprovider"] != nil {
+				t.Errorf("results %v, want vdsina failed %v and oneprovider passed", results, tt.failVdsina)
+			}
+
+			// Payment messages are never sent, only the summary
+			texts := sent.texts()
+			if len(texts) != len(tt.wantSummaries) {
+				t.Fatalf("messages %q, want %q", texts, tt.wantSummaries)
+			}
+			for i, want := range tt.wantSummaries {
+				if texts[i] != want {
+					t.Errorf("summary %q, want %q", texts[i], want)
+				}
+			}
+		})
+	}
+}
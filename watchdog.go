@@ -0,0 +1,47 @@
+package neverforgetvps
+
+import (
+	"fmt"
+	"time"
+)
+
+// runWatchdog alerts when no check cycle has completed within the threshold until the monitor stops
+// The alert is sent once per stall and re-armed by the next completed cycle
+func (m *vpsMonitor[T]) runWatchdog(threshold time.Duration) {
+	ticker := time.NewTicker(m.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if since, stalled := m.watchdogStalled(threshold); stalled {
+				m.sendMessage(monitorMessage(SeverityCritical, fmt.Sprintf("🚨 WATCHDOG: No check cycle has completed for %s (expected every %s), the monitor may be stuck", since.Round(time.Second), m.checkInterval)))
+			}
+		case <-m.ctx.Done():
+			return
+		}
+	}
+}
+
+// watchdogStalled reports whether the last completed cycle is older than the threshold
+// and the stall was not reported yet, marking it as reported
+func (m *vpsMonitor[T]) watchdogStalled(threshold time.Duration) (time.Duration, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	since := m.now().Sub(m.lastCycleEnd)
+	if m.watchdogFired || since <= threshold {
+		return since, false
+	}
+	m.watchdogFired = true
+	return since, true
+}
+
+// markCycleComplete records the end of a check cycle for the watchdog
+func (m *vpsMonitor[T]) markCycleComplete() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.lastCycleEnd = m.now()
+	m.watchdogFired = false
+}
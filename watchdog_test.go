@@ -0,0 +1,68 @@
+package neverforgetvps
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWatchdogStalled(t *testing.T) {
+	clock := newTestClock()
+	m, _ := newTestMonitor(t, Config{CheckInterval: time.Hour}, clock, newStubProvider("vdsina", nil))
+	const threshold = 3 * time.Hour
+
+	m.markCycleComplete()
+	clock.Advance(threshold)
+	if _, stalled := m.watchdogStalled(threshold); stalled {
+		t.Fatal("stalled at the threshold, want only after it")
+	}
+
+	clock.Advance(time.Minute)
+	if since, stalled := m.watchdogStalled(threshold); !stalled || since != threshold+time.Minute {
+		t.Fatalf("stalled %v for %v, want a stall of %v", stalled, since, threshold+time.Minute)
+	}
+	// A stall is reported once
+	clock.Advance(time.Hour)
+	if _, stalled := m.watchdogStalled(threshold); stalled {
+		t.Fatal("stall reported twice")
+	}
+
+	// A completed cycle re-arms the watchdog
+	m.markCycleComplete()
+	clock.Advance(threshold + time.Minute)
+	if _, stalled := m.watchdogStalled(threshold); !stalled {
+		t.Fatal("new stall after a completed cycle not reported")
+	}
+}
+
+func TestWatchdogFires(t *testing.T) {
+	p := newStubProvider("vdsina", nil)
+	// The initial check hangs until its timeout, far longer than the watchdog threshold
+	p.hook = func(ctx context.Context) { <-ctx.Done() }
+	m, _ := newTestMonitor(t, Config{CheckInterval: 20 * time.Millisecond, WatchdogMultiple: 3}, nil, p)
+
+	fired := make(chan string, 1)
+	m.sendFunc = func(message Message) error {
+		if strings.Contains(message.Text, "WATCHDOG") {
+			select {
+			case fired <- message.Text:
+			default:
+			}
+		}
+		return nil
+	}
+
+	if err := m.Start(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case text := <-fired:
+		if !strings.Contains(text, "expected every 20ms") {
+			t.Errorf("watchdog alert %q, want the check interval", text)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("watchdog did not fire within 5 seconds of a stalled check")
+	}
+	m.Stop()
+}